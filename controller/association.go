@@ -0,0 +1,393 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopcep/pcep"
+	"math"
+	"net"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+//AssociationGroup is an RFC 8697 association group created
+// on the controller and the controller LSPs which are members of it
+type AssociationGroup struct {
+	Name        string
+	Association pcep.Association
+	LSPs        []string
+	// LSPs the routers reported as members of the group
+	// filled in when groups are retrieved and never stored
+	ReportedLSPs []string `json:",omitempty"`
+}
+
+type Associations struct {
+	sync.Map
+}
+
+func (a *Associations) StoreAssociation(key string, value *AssociationGroup) {
+	a.Store(key, value)
+}
+
+func (a *Associations) GetAssociation(key string) (*AssociationGroup, bool) {
+	v, ok := a.Load(key)
+	if ok {
+		return v.(*AssociationGroup), ok
+	}
+	return nil, ok
+}
+
+func (a *Associations) DelAssociation(key string) {
+	a.Delete(key)
+}
+
+func (a *Associations) RangeAssociations(f func(key interface{}, value interface{}) bool) {
+	a.Range(f)
+}
+
+// LoadAssociations retrive all association groups stored in Bolt DB
+func (c *Controller) LoadAssociations() error {
+	return c.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("associations"))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var g AssociationGroup
+			err := json.Unmarshal(v, &g)
+			if err != nil {
+				return err
+			}
+			c.StoreAssociation(string(k), &g)
+			return nil
+		})
+	})
+}
+
+// GetAssociations returns all association groups along with
+// the LSPs the routers reported as members of each group
+func (c *Controller) GetAssociations() []*AssociationGroup {
+	reported := make(map[string][]string)
+	for _, lsp := range c.GetLSPs() {
+		for _, a := range lsp.Associations {
+			reported[a.Key()] = append(reported[a.Key()], lsp.Name)
+		}
+	}
+
+	groups := make([]*AssociationGroup, 0)
+	c.RangeAssociations(func(key, value interface{}) bool {
+		g := *value.(*AssociationGroup)
+		g.ReportedLSPs = reported[g.Association.Key()]
+		groups = append(groups, &g)
+		return true
+	})
+	return groups
+}
+
+// associationIDsInUse returns the IDs of the given association type and source
+// used by association groups, by the associations LSPs carry such as path
+// protection and by SR Policies, the LSPs matched by skip are left out
+func (c *Controller) associationIDsInUse(assocType uint16, source string, skip func(lspName string) bool) map[uint16]bool {
+	used := make(map[uint16]bool)
+	c.RangeAssociations(func(key, value interface{}) bool {
+		g := value.(*AssociationGroup)
+		if g.Association.Type == assocType && g.Association.Source == source {
+			used[g.Association.ID] = true
+		}
+		return true
+	})
+	c.RangeLSPs(func(key, value interface{}) bool {
+		l := value.(*pcep.SRLSP)
+		if skip != nil && skip(l.Name) {
+			return true
+		}
+		for _, a := range l.Associations {
+			if a.Type == assocType && a.Source == source {
+				used[a.ID] = true
+			}
+		}
+		return true
+	})
+	// SR Policies without candidate paths have no LSPs yet
+	if assocType == pcep.AssocTypeSRPolicy {
+		c.RangeSRPolicies(func(key, value interface{}) bool {
+			sp := value.(*SRPolicy)
+			if sp.HeadEnd == source && sp.AssociationID != 0 {
+				used[sp.AssociationID] = true
+			}
+			return true
+		})
+	}
+	return used
+}
+
+// https://tools.ietf.org/html/rfc8697#section-6.1
+// 0 and 0xffff are reserved
+func validAssociationID(id uint16) bool {
+	return id != 0 && id != math.MaxUint16
+}
+
+// freeAssociationID returns the lowest association ID which is not used
+func freeAssociationID(used map[uint16]bool) (uint16, bool) {
+	for id := uint16(1); validAssociationID(id); id++ {
+		if !used[id] {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// nextAssociationID returns the lowest association ID not used
+// by anything else of the same type and source
+func (c *Controller) nextAssociationID(a *pcep.Association) (uint16, error) {
+	id, ok := freeAssociationID(c.associationIDsInUse(a.Type, a.Source, nil))
+	if !ok {
+		return 0, fmt.Errorf("no free association ID left for type %d and source %s", a.Type, a.Source)
+	}
+	return id, nil
+}
+
+// reserveAssociation sets the ID of the association group, a new group is
+// stored in memory without members while holding the lock so concurrent
+// requests can not pick the same ID, the group stored before is returned
+func (c *Controller) reserveAssociation(g *AssociationGroup) (*AssociationGroup, error) {
+	defer c.Unlock()
+
+	c.Lock()
+	// the group identity can not change once LSPs joined it
+	old, ok := c.GetAssociation(g.Name)
+	if ok {
+		g.Association = old.Association
+		return old, nil
+	}
+	if g.Association.ID != 0 {
+		if !validAssociationID(g.Association.ID) {
+			return nil, fmt.Errorf("association ID %d is reserved", g.Association.ID)
+		}
+		if c.associationIDsInUse(g.Association.Type, g.Association.Source, nil)[g.Association.ID] {
+			return nil, fmt.Errorf("association ID %d of type %d and source %s is already in use",
+				g.Association.ID, g.Association.Type, g.Association.Source)
+		}
+	} else {
+		id, err := c.nextAssociationID(&g.Association)
+		if err != nil {
+			return nil, err
+		}
+		g.Association.ID = id
+	}
+	g.Association.Remove = false
+	c.StoreAssociation(g.Name, &AssociationGroup{
+		Name:        g.Name,
+		Association: g.Association,
+	})
+	return nil, nil
+}
+
+// CreateUpdAssociation creates an association group or updates its members,
+// LSPs joining or leaving the group are updated on the routers. Everything is
+// validated first and the group is only stored once all members joined it
+func (c *Controller) CreateUpdAssociation(g *AssociationGroup) error {
+	if g.Name == "" {
+		return errors.New("association group name must not be empty")
+	}
+	if net.ParseIP(g.Association.Source) == nil {
+		return fmt.Errorf("invalid association source: %s", g.Association.Source)
+	}
+	members := make(map[string]bool)
+	for _, name := range g.LSPs {
+		if _, ok := c.GetLSP(name); !ok {
+			return fmt.Errorf("no LSP named: %s found in controller db", name)
+		}
+		members[name] = true
+	}
+	old, err := c.reserveAssociation(g)
+	if err != nil {
+		return err
+	}
+	g.ReportedLSPs = nil
+	// a new group gives its ID back when it can not be stored
+	release := func() {
+		if old == nil {
+			c.Associations.DelAssociation(g.Name)
+		}
+	}
+
+	// LSPs which were not in the group yet are rolled back on failure
+	joined := make([]string, 0)
+	for _, name := range g.LSPs {
+		lsp, ok := c.GetLSP(name)
+		if ok && hasAssociation(lsp, &g.Association) {
+			continue
+		}
+		err := c.joinAssociation(name, &g.Association)
+		if err != nil {
+			c.rollbackJoins(joined, &g.Association)
+			release()
+			return err
+		}
+		joined = append(joined, name)
+	}
+
+	err = c.storeAssociation(g)
+	if err != nil {
+		c.rollbackJoins(joined, &g.Association)
+		release()
+		return err
+	}
+
+	if old != nil {
+		for _, name := range old.LSPs {
+			if members[name] {
+				continue
+			}
+			err = c.leaveAssociation(name, &old.Association)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rollbackJoins takes the association away from the LSPs which joined it
+func (c *Controller) rollbackJoins(lspNames []string, a *pcep.Association) {
+	for _, name := range lspNames {
+		err := c.leaveAssociation(name, a)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"type":     "controller",
+				"event":    "association_rollback",
+				"lsp_name": name,
+			}).Error(err)
+		}
+	}
+}
+
+func hasAssociation(lsp *pcep.SRLSP, a *pcep.Association) bool {
+	for _, la := range lsp.Associations {
+		if la.Key() == a.Key() {
+			return true
+		}
+	}
+	return false
+}
+
+// AddLSPToAssociation makes the LSP a member of the association group
+func (c *Controller) AddLSPToAssociation(name, lspName string) error {
+	g, ok := c.GetAssociation(name)
+	if !ok {
+		return fmt.Errorf("no association group named: %s found", name)
+	}
+	upd := *g
+	upd.LSPs = append([]string{}, g.LSPs...)
+	for _, l := range g.LSPs {
+		if l == lspName {
+			return nil
+		}
+	}
+	upd.LSPs = append(upd.LSPs, lspName)
+	return c.CreateUpdAssociation(&upd)
+}
+
+// DelAssociation removes the association group and all its members from it
+func (c *Controller) DelAssociation(name string) error {
+	g, ok := c.GetAssociation(name)
+	if !ok {
+		return fmt.Errorf("no association group named: %s found", name)
+	}
+	for _, lspName := range g.LSPs {
+		err := c.leaveAssociation(lspName, &g.Association)
+		if err != nil {
+			return err
+		}
+	}
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("associations"))
+		if err != nil {
+			return err
+		}
+		return b.Delete([]byte(name))
+	})
+	if err != nil {
+		return err
+	}
+	c.Associations.DelAssociation(name)
+	return nil
+}
+
+func (c *Controller) storeAssociation(g *AssociationGroup) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("associations"))
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(g)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(g.Name), data)
+	})
+	if err != nil {
+		return err
+	}
+	c.StoreAssociation(g.Name, g)
+	return nil
+}
+
+// joinAssociation adds the association to the controller LSP
+// and pushes the change to the router if the LSP is not in it yet
+func (c *Controller) joinAssociation(lspName string, a *pcep.Association) error {
+	lsp, ok := c.GetLSP(lspName)
+	if !ok {
+		return fmt.Errorf("no LSP named: %s found in controller db", lspName)
+	}
+	if hasAssociation(lsp, a) {
+		return nil
+	}
+	upd := *lsp
+	assoc := *a
+	upd.Associations = append(append([]*pcep.Association{}, lsp.Associations...), &assoc)
+	return c.CreateUpdSRLSP(&upd)
+}
+
+// leaveAssociation removes the association from the controller LSP, the
+// router is told about it with the R flag set https://tools.ietf.org/html/rfc8697#section-6.1
+func (c *Controller) leaveAssociation(lspName string, a *pcep.Association) error {
+	lsp, ok := c.GetLSP(lspName)
+	if !ok {
+		// the LSP is gone so is its membership
+		return nil
+	}
+	upd := *lsp
+	upd.Associations = make([]*pcep.Association, 0)
+	for _, la := range lsp.Associations {
+		if la.Key() != a.Key() {
+			upd.Associations = append(upd.Associations, la)
+		}
+	}
+	if len(upd.Associations) == len(lsp.Associations) {
+		return nil
+	}
+	c.RLock()
+	session, ok := c.PCEPSessionsByLoopback[lsp.Src]
+	c.RUnlock()
+	// an LSP not delegated to us keeps the association until it is initiated again
+	if ok {
+		sessionLSP := session.GetLSP(lspName)
+		if sessionLSP != nil && sessionLSP.Delegate {
+			removal := upd
+			removal.PLSPID = sessionLSP.PLSPID
+			assoc := *a
+			assoc.Remove = true
+			removal.Associations = append(append([]*pcep.Association{}, upd.Associations...), &assoc)
+			err := session.UpdateSRLSP(&removal)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return c.storeSRLSP(&upd)
+}
@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"gopcep/pcep"
+)
+
+func TestNextAssociationID(t *testing.T) {
+	c := newTestController(t)
+	// path protection association carried by an LSP
+	c.StoreLSP("lsp1", &pcep.SRLSP{
+		Name: "lsp1",
+		Src:  "10.0.0.1",
+		Associations: []*pcep.Association{
+			{Type: pcep.AssocTypePathProtection, ID: 1, Source: "10.0.0.1"},
+		},
+	})
+	c.StoreSRPolicy("policy1", &SRPolicy{Name: "policy1", HeadEnd: "10.0.0.1", AssociationID: 1})
+	c.StoreAssociation("group1", &AssociationGroup{
+		Name:        "group1",
+		Association: pcep.Association{Type: pcep.AssocTypePathProtection, ID: 2, Source: "10.0.0.1"},
+	})
+
+	for _, tc := range []struct {
+		name string
+		a    pcep.Association
+		id   uint16
+	}{
+		{name: "used by LSP and group", a: pcep.Association{Type: pcep.AssocTypePathProtection, Source: "10.0.0.1"}, id: 3},
+		{name: "used by SR Policy", a: pcep.Association{Type: pcep.AssocTypeSRPolicy, Source: "10.0.0.1"}, id: 2},
+		{name: "other source", a: pcep.Association{Type: pcep.AssocTypePathProtection, Source: "10.0.0.2"}, id: 1},
+		{name: "other type", a: pcep.Association{Type: pcep.AssocTypeDisjoint, Source: "10.0.0.1"}, id: 1},
+	} {
+		id, err := c.nextAssociationID(&tc.a)
+		if err != nil {
+			t.Fatalf("%s: must not see any errors, instead got: %s", tc.name, err.Error())
+		}
+		if id != tc.id {
+			t.Errorf("%s: expected ID %d got %d", tc.name, tc.id, id)
+		}
+	}
+}
+
+func TestFreeAssociationID(t *testing.T) {
+	used := make(map[uint16]bool)
+	for id := uint16(1); id < 0xffff; id++ {
+		used[id] = true
+	}
+	if _, ok := freeAssociationID(used); ok {
+		t.Error("expected no free ID as 0 and 0xffff are reserved")
+	}
+	delete(used, 0xfffe)
+	if id, ok := freeAssociationID(used); !ok || id != 0xfffe {
+		t.Errorf("expected ID 0xfffe got %d %t", id, ok)
+	}
+}
+
+func TestCreateUpdAssociationID(t *testing.T) {
+	c := newTestController(t)
+	c.StoreLSP("lsp1", &pcep.SRLSP{
+		Name: "lsp1",
+		Src:  "10.0.0.1",
+		Associations: []*pcep.Association{
+			{Type: pcep.AssocTypePathProtection, ID: 5, Source: "10.0.0.1"},
+		},
+	})
+
+	for _, tc := range []struct {
+		name  string
+		id    uint16
+		valid bool
+	}{
+		{name: "used by LSP", id: 5},
+		{name: "reserved", id: 0xffff},
+		{name: "free", id: 6, valid: true},
+		{name: "used by group", id: 6},
+	} {
+		err := c.CreateUpdAssociation(&AssociationGroup{
+			Name:        "group-" + tc.name,
+			Association: pcep.Association{Type: pcep.AssocTypePathProtection, ID: tc.id, Source: "10.0.0.1"},
+		})
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: ID %d must be accepted: %t got %v", tc.name, tc.id, tc.valid, err)
+		}
+		_, stored := c.GetAssociation("group-" + tc.name)
+		if stored != tc.valid {
+			t.Errorf("%s: group must be stored: %t", tc.name, tc.valid)
+		}
+	}
+
+	// an existing group keeps its ID
+	err := c.CreateUpdAssociation(&AssociationGroup{
+		Name:        "group-free",
+		Association: pcep.Association{Type: pcep.AssocTypePathProtection, ID: 9, Source: "10.0.0.1"},
+	})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	g, _ := c.GetAssociation("group-free")
+	if g.Association.ID != 6 {
+		t.Errorf("expected ID 6 to be kept got %d", g.Association.ID)
+	}
+}
+
+func TestCreateUpdAssociationMembers(t *testing.T) {
+	c := newTestController(t)
+	for _, lsp := range []*pcep.SRLSP{
+		{Name: "lsp1", Src: "10.0.0.1", Dst: "10.0.0.9"},
+		{Name: "lsp2", Src: "10.0.0.1", Dst: "10.0.0.9"},
+		{Name: "lsp3", Src: "10.0.0.1", Dst: "10.0.0.9"},
+	} {
+		err := c.CreateUpdSRLSP(lsp)
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+	}
+	a := pcep.Association{Type: pcep.AssocTypeDisjoint, Source: "10.0.0.1"}
+
+	err := c.CreateUpdAssociation(&AssociationGroup{Name: "group1", Association: a, LSPs: []string{"lsp1", "lsp2"}})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	g, _ := c.GetAssociation("group1")
+	for _, name := range []string{"lsp1", "lsp2"} {
+		lsp, _ := c.GetLSP(name)
+		if !hasAssociation(lsp, &g.Association) {
+			t.Errorf("expected %s to join the group", name)
+		}
+	}
+
+	// unknown member, nothing changes
+	err = c.CreateUpdAssociation(&AssociationGroup{Name: "group1", Association: a, LSPs: []string{"lsp3", "lsp4"}})
+	if err == nil {
+		t.Fatal("expected error for unknown LSP")
+	}
+	lsp3, _ := c.GetLSP("lsp3")
+	if hasAssociation(lsp3, &g.Association) {
+		t.Error("expected lsp3 not to join the group")
+	}
+	if g, _ := c.GetAssociation("group1"); len(g.LSPs) != 2 {
+		t.Errorf("expected group members to be kept got %v", g.LSPs)
+	}
+
+	// lsp3 fails to join as its binding SID is used by lsp1
+	err = c.CreateUpdSRLSP(&pcep.SRLSP{Name: "lsp1", Src: "10.0.0.1", Dst: "10.0.0.9", BindingSID: 1000050,
+		Associations: []*pcep.Association{&g.Association}})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	c.StoreLSP("lsp3", &pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1", Dst: "10.0.0.9", BindingSID: 1000050})
+	c.StoreLSP("lsp4", &pcep.SRLSP{Name: "lsp4", Src: "10.0.0.1", Dst: "10.0.0.9"})
+	err = c.CreateUpdAssociation(&AssociationGroup{Name: "group2", Association: a, LSPs: []string{"lsp4", "lsp3"}})
+	if err == nil {
+		t.Fatal("expected error when a member fails to join")
+	}
+	if _, ok := c.GetAssociation("group2"); ok {
+		t.Error("expected group2 not to be stored")
+	}
+	lsp4, _ := c.GetLSP("lsp4")
+	if len(lsp4.Associations) != 0 {
+		t.Errorf("expected lsp4 to leave group2 again got %v", lsp4.Associations)
+	}
+
+	// lsp2 leaves the group
+	err = c.CreateUpdAssociation(&AssociationGroup{Name: "group1", Association: a, LSPs: []string{"lsp1"}})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	lsp2, _ := c.GetLSP("lsp2")
+	if hasAssociation(lsp2, &g.Association) {
+		t.Error("expected lsp2 to leave the group")
+	}
+}
+
+func TestCreateUpdAssociationConcurrentIDs(t *testing.T) {
+	c := newTestController(t)
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- c.CreateUpdAssociation(&AssociationGroup{
+				Name:        fmt.Sprintf("group%d", i),
+				Association: pcep.Association{Type: pcep.AssocTypeDisjoint, Source: "10.0.0.1"},
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+	}
+	ids := make(map[uint16]string)
+	for _, g := range c.GetAssociations() {
+		if other, ok := ids[g.Association.ID]; ok {
+			t.Errorf("ID %d is used by %s and %s", g.Association.ID, other, g.Name)
+		}
+		ids[g.Association.ID] = g.Name
+	}
+	if len(ids) != 20 {
+		t.Errorf("expected 20 groups got %d", len(ids))
+	}
+}
@@ -29,8 +29,12 @@ type Node struct {
 	IGPRouteID   string
 	Name         string
 	Age          string
-	SRRangeStart int
-	SRRangeEnd   int
+	SRv6Locators []*SRv6Locator
+	SRv6EndSIDs  []*SRv6SID
+	// SR Capabilities and SR Local Block label ranges
+	// https://tools.ietf.org/html/rfc8667#section-3
+	SRGB []*SRRange
+	SRLB []*SRRange
 }
 
 type Prefix struct {
@@ -42,20 +46,24 @@ type Prefix struct {
 type TopoView struct {
 	*sync.RWMutex
 	Paths
-	LinksByIGPRouteID  []*Link
-	NodesByIGPRouteID  map[string]*Node
-	PrefixByIGPRouteID map[string]*Prefix
-	TopologyUpdate     chan bool `json:"-"`
+	LinksByIGPRouteID    []*Link
+	NodesByIGPRouteID    map[string]*Node
+	PrefixByIGPRouteID   map[string]*Prefix
+	PrefixV6ByIGPRouteID map[string]*Prefix
+	TopologyUpdate       chan bool `json:"-"`
+	// SPF results per source node used to build SID lists
+	spfCache sync.Map
 }
 
 func NewTopoView() *TopoView {
 	return &TopoView{
-		NodesByIGPRouteID:  make(map[string]*Node),
-		LinksByIGPRouteID:  make([]*Link, 0),
-		PrefixByIGPRouteID: make(map[string]*Prefix),
-		Paths:              Paths{},
-		TopologyUpdate:     make(chan bool),
-		RWMutex:            &sync.RWMutex{},
+		NodesByIGPRouteID:    make(map[string]*Node),
+		LinksByIGPRouteID:    make([]*Link, 0),
+		PrefixByIGPRouteID:   make(map[string]*Prefix),
+		PrefixV6ByIGPRouteID: make(map[string]*Prefix),
+		Paths:                Paths{},
+		TopologyUpdate:       make(chan bool),
+		RWMutex:              &sync.RWMutex{},
 	}
 }
 
@@ -163,21 +171,26 @@ func (t *TopoView) HandleNodeNLRI(lsMessage *anypb.Any, p *api.Path) {
 			node.RouterID = LsAttribute.Node.LocalRouterId
 			node.Name = LsAttribute.Node.Name
 			if LsAttribute.Node.SrCapabilities != nil {
-				node.SRRangeStart = int(LsAttribute.Node.SrCapabilities.Ranges[0].Begin)
-				node.SRRangeEnd = int(LsAttribute.Node.SrCapabilities.Ranges[0].End)
+				node.SRGB = newSRRanges(LsAttribute.Node.SrCapabilities.Ranges)
 			} else {
 				printAsJSON(LsAttribute)
 			}
+			if LsAttribute.Node.SrLocalBlock != nil {
+				node.SRLB = newSRRanges(LsAttribute.Node.SrLocalBlock.Ranges)
+			}
 		}
 	}
 	t.Lock()
+	// SRv6 SIDs come in separate NLRI and must survive node updates
+	if old, ok := t.NodesByIGPRouteID[node.IGPRouteID]; ok {
+		node.SRv6Locators = old.SRv6Locators
+		node.SRv6EndSIDs = old.SRv6EndSIDs
+	}
 	t.NodesByIGPRouteID[node.IGPRouteID] = node
 	t.Unlock()
 }
 
 func (t *TopoView) HandleLinkNLRI(lsMessage *anypb.Any, p *api.Path) {
-	defer t.Unlock()
-
 	var NLRILink api.LsLinkNLRI
 	err := ptypes.UnmarshalAny(lsMessage, &NLRILink)
 	if err != nil {
@@ -185,10 +198,14 @@ func (t *TopoView) HandleLinkNLRI(lsMessage *anypb.Any, p *api.Path) {
 		return
 	}
 	link := &Link{
-		LocalNode:   NLRILink.LocalNode.IgpRouterId,
-		RemoteNode:  NLRILink.RemoteNode.IgpRouterId,
-		IntIP:       NLRILink.LinkDescriptor.InterfaceAddrIpv4,
-		NeighbourIP: NLRILink.LinkDescriptor.NeighborAddrIpv4,
+		LocalNode:     NLRILink.LocalNode.IgpRouterId,
+		RemoteNode:    NLRILink.RemoteNode.IgpRouterId,
+		IntIP:         NLRILink.LinkDescriptor.InterfaceAddrIpv4,
+		NeighbourIP:   NLRILink.LinkDescriptor.NeighborAddrIpv4,
+		IntIPv6:       NLRILink.LinkDescriptor.InterfaceAddrIpv6,
+		NeighbourIPv6: NLRILink.LinkDescriptor.NeighborAddrIpv6,
+		LocalLinkID:   NLRILink.LinkDescriptor.LinkLocalId,
+		RemoteLinkID:  NLRILink.LinkDescriptor.LinkRemoteId,
 	}
 	var LsAttribute api.LsAttribute
 	for _, item := range p.Pattrs {
@@ -206,22 +223,90 @@ func (t *TopoView) HandleLinkNLRI(lsMessage *anypb.Any, p *api.Path) {
 			link.SRAdjacencySID = LsAttribute.Link.SrAdjacencySid
 		}
 	}
+	// GoBGP does not tell an SRLB index from a label
+	link.SRAdjacencyIndex = adjSIDIsIndex(lsAttr(p.PattrsBinary))
+	// SRv6 End.X SIDs are only available in the raw attribute
+	// the link is still usable for SR-MPLS when SRv6 data is malformed
+	srv6, err := parseSRv6LsAttr(lsAttr(p.PattrsBinary))
+	if err != nil {
+		logrus.Println(err)
+	} else {
+		link.SRv6EndXSIDs = srv6.endXSIDs
+	}
 	t.Lock()
 	t.LinksByIGPRouteID = append(t.LinksByIGPRouteID, link)
+	t.resetSPFCache()
+	t.Unlock()
 }
 
 func (t *TopoView) HandlePrefixV4NLRI(lsMessage *anypb.Any, p *api.Path) {
-	defer t.Unlock()
-
 	var NLRIPrefix api.LsPrefixV4NLRI
 	err := ptypes.UnmarshalAny(lsMessage, &NLRIPrefix)
 	if err != nil {
 		logrus.Println(err)
 		return
 	}
+	prefix := newPrefix(NLRIPrefix.LocalNode, NLRIPrefix.PrefixDescriptor, p)
+	if prefix == nil {
+		return
+	}
+	t.Lock()
+	t.PrefixByIGPRouteID[prefix.LocalNode] = prefix
+	t.Unlock()
+}
+
+func (t *TopoView) HandlePrefixV6NLRI(lsMessage *anypb.Any, p *api.Path) {
+	var NLRIPrefix api.LsPrefixV6NLRI
+	err := ptypes.UnmarshalAny(lsMessage, &NLRIPrefix)
+	if err != nil {
+		logrus.Println(err)
+		return
+	}
+	prefix := newPrefix(NLRIPrefix.LocalNode, NLRIPrefix.PrefixDescriptor, p)
+	if prefix == nil {
+		return
+	}
+	srv6, err := parseSRv6LsAttr(lsAttr(p.PattrsBinary))
+	if err != nil {
+		// the prefix is still usable as node address without SRv6 data
+		logrus.WithFields(logrus.Fields{
+			"type":   "bgp",
+			"event":  "parse_srv6_attr",
+			"prefix": prefix.Prefix,
+		}).Error(err)
+		srv6 = &srv6LsAttr{}
+	}
+	t.Lock()
+	defer t.Unlock()
+	// locator prefixes are not used as node addresses
+	if srv6.locator != nil {
+		node, ok := t.NodesByIGPRouteID[prefix.LocalNode]
+		if !ok {
+			node = &Node{IGPRouteID: prefix.LocalNode}
+			t.NodesByIGPRouteID[prefix.LocalNode] = node
+		}
+		srv6.locator.Prefix = prefix.Prefix
+		for i, l := range node.SRv6Locators {
+			if l.Prefix == prefix.Prefix {
+				node.SRv6Locators[i] = srv6.locator
+				return
+			}
+		}
+		node.SRv6Locators = append(node.SRv6Locators, srv6.locator)
+		return
+	}
+	t.PrefixV6ByIGPRouteID[prefix.LocalNode] = prefix
+}
+
+// newPrefix builds a Prefix out of IPv4 or IPv6 prefix NLRI
+// as both carry the same descriptors and attributes
+func newPrefix(node *api.LsNodeDescriptor, desc *api.LsPrefixDescriptor, p *api.Path) *Prefix {
+	if node == nil || desc == nil || len(desc.IpReachability) == 0 {
+		return nil
+	}
 	prefix := &Prefix{
-		Prefix:    NLRIPrefix.PrefixDescriptor.IpReachability[0],
-		LocalNode: NLRIPrefix.LocalNode.IgpRouterId,
+		Prefix:    desc.IpReachability[0],
+		LocalNode: node.IgpRouterId,
 	}
 	var LsAttribute api.LsAttribute
 	for _, item := range p.Pattrs {
@@ -229,16 +314,33 @@ func (t *TopoView) HandlePrefixV4NLRI(lsMessage *anypb.Any, p *api.Path) {
 			err := ptypes.UnmarshalAny(item, &LsAttribute)
 			if err != nil {
 				logrus.Println(err)
-				return
+				return nil
+			}
+			if LsAttribute.Prefix != nil {
+				prefix.SRPrefixSID = LsAttribute.Prefix.SrPrefixSid
 			}
-			prefix.SRPrefixSID = LsAttribute.Prefix.SrPrefixSid
 		}
 	}
-	t.Lock()
-	t.PrefixByIGPRouteID[prefix.LocalNode] = prefix
+	return prefix
+}
+
+// getNodePrefix returns the node prefix of the requested address family
+func (t *TopoView) getNodePrefix(routerID string, v6 bool) (*Prefix, bool) {
+	if v6 {
+		prefix, ok := t.PrefixV6ByIGPRouteID[routerID]
+		return prefix, ok
+	}
+	prefix, ok := t.PrefixByIGPRouteID[routerID]
+	return prefix, ok
 }
 
 func (t *TopoView) Monitor(p *api.Path) {
+	// SRv6 SID NLRI is not decoded by the BGP library
+	if isSRv6SIDNLRI(p) {
+		t.HandleSRv6SIDNLRI(p)
+		t.TopologyUpdate <- true
+		return
+	}
 	var lsMessage api.LsAddrPrefix
 	err := ptypes.UnmarshalAny(p.Nlri, &lsMessage)
 	if err != nil {
@@ -252,6 +354,8 @@ func (t *TopoView) Monitor(p *api.Path) {
 		t.HandleLinkNLRI(lsMessage.Nlri, p)
 	case lsMessage.Type == 3:
 		t.HandlePrefixV4NLRI(lsMessage.Nlri, p)
+	case lsMessage.Type == 4:
+		t.HandlePrefixV6NLRI(lsMessage.Nlri, p)
 	default:
 		logrus.WithFields(logrus.Fields{
 			"type":        "bgp",
@@ -298,16 +402,78 @@ func (t *TopoView) findBestPath(bwNeeded int, src, dst string) *Path {
 	return bestPath
 }
 
-func (t *TopoView) getSIDByIGPRouterID(routerID string) (uint32, error) {
-	node, ok := t.NodesByIGPRouteID[routerID]
+// findBestDisjointPath returns the lowest cost path which is disjoint from all
+// the given ones, has enough bandwidth on all of its links and fits into the MSD
+func (t *TopoView) findBestDisjointPath(bwNeeded int, primaries []*Path, nodeDisjoint bool, msd uint8) *Path {
+	if len(primaries) == 0 {
+		return nil
+	}
+	var bestPath *Path
+	paths, _ := t.GetPath(primaries[0].Src + ":" + primaries[0].Dst)
+	for _, path := range paths {
+		disjoint := true
+		for _, primary := range primaries {
+			if path == primary || !primary.disjoint(path, nodeDisjoint) {
+				disjoint = false
+				break
+			}
+		}
+		if !disjoint {
+			continue
+		}
+		bwAvailiable := true
+		for _, link := range path.Links {
+			if link.UnreservedBW <= float32(bwNeeded) {
+				bwAvailiable = false
+				break
+			}
+		}
+		if !bwAvailiable || !t.fitsMSD(path, msd) {
+			continue
+		}
+		if bestPath == nil || path.Cost < bestPath.Cost {
+			bestPath = path
+		}
+	}
+	return bestPath
+}
+
+// getSIDByIGPRouterID returns the label of the node SID of the router as
+// understood by the upstream node which processes it, nodes do not have to
+// share the same SRGB https://tools.ietf.org/html/rfc8660#section-2.2
+func (t *TopoView) getSIDByIGPRouterID(upstream, routerID string, v6 bool) (uint32, error) {
+	node, ok := t.NodesByIGPRouteID[upstream]
 	if !ok {
-		return 0, fmt.Errorf("no node found for id: %s", routerID)
+		return 0, fmt.Errorf("no node found for id: %s", upstream)
 	}
-	prefix, ok := t.PrefixByIGPRouteID[routerID]
+	prefix, ok := t.getNodePrefix(routerID, v6)
 	if !ok {
 		return 0, fmt.Errorf("no node found for id: %s", routerID)
 	}
-	return uint32(node.SRRangeStart) + prefix.SRPrefixSID, nil
+	label, err := srLabel(node.SRGB, prefix.SRPrefixSID)
+	if err != nil {
+		return 0, fmt.Errorf("no label for node %s in SRGB of %s: %s", routerID, upstream, err.Error())
+	}
+	return label, nil
+}
+
+// getUnnuAdj identifies an unnumbered link by TE router IDs of both ends
+// and link IDs https://tools.ietf.org/html/rfc8664#section-4.3.2
+func (t *TopoView) getUnnuAdj(link *Link) (*pcep.UnnuAdjIPv4NodeIDs, error) {
+	local, ok := t.NodesByIGPRouteID[link.LocalNode]
+	if !ok || local.RouterID == "" {
+		return nil, fmt.Errorf("no router id found for IGPID %s", link.LocalNode)
+	}
+	remote, ok := t.NodesByIGPRouteID[link.RemoteNode]
+	if !ok || remote.RouterID == "" {
+		return nil, fmt.Errorf("no router id found for IGPID %s", link.RemoteNode)
+	}
+	return &pcep.UnnuAdjIPv4NodeIDs{
+		LocalNodeID:       local.RouterID,
+		LocalInterfaceID:  link.LocalLinkID,
+		RemoteNodeID:      remote.RouterID,
+		RemoteInterfaceID: link.RemoteLinkID,
+	}, nil
 }
 
 func (t *TopoView) createSRLSP(bw uint32, path *Path) (*pcep.SRLSP, error) {
@@ -318,11 +484,14 @@ func (t *TopoView) createSRLSP(bw uint32, path *Path) (*pcep.SRLSP, error) {
 	}
 
 	t.Lock()
-	srcPrefix, ok := t.PrefixByIGPRouteID[path.Src]
+	// IPv4 is used when the head-end has it, IPv6 only cores fall back to IPv6
+	_, v4 := t.PrefixByIGPRouteID[path.Src]
+	v6 := !v4
+	srcPrefix, ok := t.getNodePrefix(path.Src, v6)
 	if !ok {
 		return nil, fmt.Errorf("src prefix not found for IGPID %s", path.Src)
 	}
-	dstPrefix, ok := t.PrefixByIGPRouteID[path.Dst]
+	dstPrefix, ok := t.getNodePrefix(path.Dst, v6)
 	if !ok {
 		return nil, fmt.Errorf("dst prefix not found for IGPID %s", path.Dst)
 	}
@@ -342,42 +511,13 @@ func (t *TopoView) createSRLSP(bw uint32, path *Path) (*pcep.SRLSP, error) {
 		HoldPrio:     7,
 		LocalProtect: false,
 		BW:           bw,
-		EROList:      make([]pcep.SREROSub, 0),
 	}
 
-	for i, link := range path.Links {
-		SID, err := t.getSIDByIGPRouterID(link.LocalNode)
-		if err != nil {
-			return nil, err
-		}
-		if i == 0 {
-			lsp.EROList = append(lsp.EROList, pcep.SREROSub{
-				LooseHop:   false,
-				MBit:       true,
-				NT:         3,
-				IPv4NodeID: "",
-				SID:        SID,
-				NoSID:      false,
-				IPv4Adjacency: []string{
-					0: link.IntIP,
-					1: link.NeighbourIP,
-				},
-			})
-			continue
-		}
-		nodePrefix, ok := t.PrefixByIGPRouteID[link.LocalNode]
-		if !ok {
-			return nil, fmt.Errorf("node prefix not found for IGPID %s", link.LocalNode)
-		}
-		lsp.EROList = append(lsp.EROList, pcep.SREROSub{
-			LooseHop:   false,
-			MBit:       true,
-			NT:         1,
-			IPv4NodeID: strings.Split(nodePrefix.Prefix, "/")[0],
-			SID:        SID,
-			NoSID:      false,
-		})
+	eroList, err := t.compressSRPath(path, v6)
+	if err != nil {
+		return nil, err
 	}
+	lsp.EROList = eroList
 
 	return lsp, nil
 }
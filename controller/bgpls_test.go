@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	api "github.com/osrg/gobgp/api"
+)
+
+func TestHandlePrefixV6NLRI(t *testing.T) {
+	nlri, err := ptypes.MarshalAny(&api.LsPrefixV6NLRI{
+		LocalNode:        &api.LsNodeDescriptor{IgpRouterId: "0000.0000.0001"},
+		PrefixDescriptor: &api.LsPrefixDescriptor{IpReachability: []string{"2001:db8::1/128"}},
+	})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	for _, c := range []struct {
+		name    string
+		attr    []byte
+		locator bool
+	}{
+		{
+			name: "no SRv6 data",
+		},
+		{
+			name:    "SRv6 locator",
+			attr:    []byte{0x90, bgpAttrTypeLS, 0, 12, 0x04, 0x8a, 0, 8, 0, 0, 0, 0, 0, 0, 0, 10},
+			locator: true,
+		},
+		{
+			// locator TLV is too short
+			name: "malformed SRv6 locator",
+			attr: []byte{0x80, bgpAttrTypeLS, 8, 0x04, 0x8a, 0, 4, 0, 0, 0, 0},
+		},
+	} {
+		topo := NewTopoView()
+		p := &api.Path{}
+		if c.attr != nil {
+			p.PattrsBinary = [][]byte{c.attr}
+		}
+		topo.HandlePrefixV6NLRI(nlri, p)
+		node := topo.NodesByIGPRouteID["0000.0000.0001"]
+		if c.locator {
+			if node == nil || len(node.SRv6Locators) != 1 || node.SRv6Locators[0].Metric != 10 {
+				t.Errorf("%s: expected locator with metric 10 got %+v", c.name, node)
+			}
+			continue
+		}
+		prefix, ok := topo.PrefixV6ByIGPRouteID["0000.0000.0001"]
+		if !ok || prefix.Prefix != "2001:db8::1/128" {
+			t.Errorf("%s: expected the prefix to be kept got %+v", c.name, prefix)
+		}
+		if node != nil && len(node.SRv6Locators) != 0 {
+			t.Errorf("%s: expected no locator got %+v", c.name, node.SRv6Locators)
+		}
+	}
+}
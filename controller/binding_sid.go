@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"gopcep/pcep"
+)
+
+//BSIDRange is the MPLS label range Binding SIDs are allocated from
+type BSIDRange struct {
+	Start uint32
+	End   uint32
+}
+
+// bsidsInUse returns MPLS Binding SIDs used on the head-end by LSPs other
+// than the given one, both created by the controller and reported by the PCC,
+// the caller holds the lock
+func (c *Controller) bsidsInUse(src, name string) map[uint32]string {
+	used := make(map[uint32]string)
+	c.RangeLSPs(func(key, value interface{}) bool {
+		lsp := value.(*pcep.SRLSP)
+		if lsp.Src == src && lsp.Name != name && lsp.BindingSID != 0 {
+			used[lsp.BindingSID] = lsp.Name
+		}
+		return true
+	})
+	session, ok := c.PCEPSessionsByLoopback[src]
+	if !ok {
+		return used
+	}
+	session.RLock()
+	for lspName, lsp := range session.LSPs {
+		if lspName == name {
+			continue
+		}
+		for _, bsid := range lsp.BindingSIDs {
+			if bsid.Type == pcep.BindingTypeMPLSLabel || bsid.Type == pcep.BindingTypeMPLSLSE {
+				used[bsid.Label] = lspName
+			}
+		}
+	}
+	session.RUnlock()
+	return used
+}
+
+// assignBSID allocates the lowest free Binding SID from the configured range when
+// the LSP asks for one, the one allocated before is kept, a Binding SID
+// used by another LSP of the same head-end is rejected, the caller holds the lock
+func (c *Controller) assignBSID(lsp *pcep.SRLSP) error {
+	if lsp.BindingSID == 0 && !lsp.AllocBindingSID {
+		return nil
+	}
+	if lsp.BindingSID != 0 && !validLabel(lsp.BindingSID) {
+		return fmt.Errorf("binding SID %d of LSP %s is not a valid MPLS label", lsp.BindingSID, lsp.Name)
+	}
+	used := c.bsidsInUse(lsp.Src, lsp.Name)
+	if lsp.BindingSID == 0 {
+		if old, ok := c.GetLSP(lsp.Name); ok && old.BindingSID != 0 {
+			lsp.BindingSID = old.BindingSID
+		} else {
+			bsid, err := c.allocBSID(used)
+			if err != nil {
+				return err
+			}
+			lsp.BindingSID = bsid
+		}
+	}
+	if other, ok := used[lsp.BindingSID]; ok {
+		return fmt.Errorf("binding SID %d is already used by LSP %s on %s", lsp.BindingSID, other, lsp.Src)
+	}
+	return nil
+}
+
+// https://tools.ietf.org/html/rfc3032#section-2.1
+// labels 0-15 are reserved and labels are 20 bits long
+func validLabel(label uint32) bool {
+	return label >= 16 && label <= 1048575
+}
+
+func (c *Controller) allocBSID(used map[uint32]string) (uint32, error) {
+	r := c.BSIDRange
+	if r.Start == 0 && r.End == 0 {
+		return 0, errors.New("no binding SID range configured")
+	}
+	if !validLabel(r.Start) || !validLabel(r.End) || r.Start > r.End {
+		return 0, fmt.Errorf("invalid binding SID range %d-%d", r.Start, r.End)
+	}
+	for label := r.Start; label <= r.End; label++ {
+		if _, ok := used[label]; !ok {
+			return label, nil
+		}
+	}
+	return 0, fmt.Errorf("no free binding SID left in range %d-%d", r.Start, r.End)
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"fmt"
+	"gopcep/pcep"
+	"sync"
+	"testing"
+)
+
+func TestAssignBSID(t *testing.T) {
+	c := newTestController(t)
+	c.StoreLSP("lsp1", &pcep.SRLSP{Name: "lsp1", Src: "10.0.0.1", BindingSID: 1000000})
+	c.StoreLSP("lsp2", &pcep.SRLSP{Name: "lsp2", Src: "10.0.0.2", BindingSID: 1000001})
+
+	for _, tc := range []struct {
+		name     string
+		lsp      *pcep.SRLSP
+		expected uint32
+		err      bool
+	}{
+		{name: "no binding SID", lsp: &pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1"}},
+		{name: "lowest free one", lsp: &pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1", AllocBindingSID: true}, expected: 1000001},
+		{name: "allocated one is kept", lsp: &pcep.SRLSP{Name: "lsp1", Src: "10.0.0.1", AllocBindingSID: true}, expected: 1000000},
+		{name: "explicit one", lsp: &pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1", BindingSID: 20000}, expected: 20000},
+		{name: "explicit one of another head-end", lsp: &pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1", BindingSID: 1000001}, expected: 1000001},
+		{name: "explicit one in use", lsp: &pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1", BindingSID: 1000000}, err: true},
+		{name: "reserved label", lsp: &pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1", BindingSID: 3}, err: true},
+		{name: "label longer than 20 bits", lsp: &pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1", BindingSID: 1048576}, err: true},
+	} {
+		err := c.assignBSID(tc.lsp)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: expected error got binding SID %d", tc.name, tc.lsp.BindingSID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: must not see any errors, instead got: %s", tc.name, err.Error())
+			continue
+		}
+		if tc.lsp.BindingSID != tc.expected {
+			t.Errorf("%s: expected binding SID %d got %d", tc.name, tc.expected, tc.lsp.BindingSID)
+		}
+	}
+
+	c.BSIDRange = BSIDRange{Start: 1000000, End: 1000000}
+	err := c.assignBSID(&pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1", AllocBindingSID: true})
+	if err == nil {
+		t.Error("expected error once the range is exhausted")
+	}
+	c.BSIDRange = BSIDRange{Start: 10, End: 1000}
+	err = c.assignBSID(&pcep.SRLSP{Name: "lsp3", Src: "10.0.0.1", AllocBindingSID: true})
+	if err == nil {
+		t.Error("expected error for a range with reserved labels")
+	}
+}
+
+func TestCreateUpdSRLSPConcurrentBSID(t *testing.T) {
+	c := newTestController(t)
+	lsps := make([]*pcep.SRLSP, 20)
+	var wg sync.WaitGroup
+	for i := range lsps {
+		lsps[i] = &pcep.SRLSP{
+			Name:            fmt.Sprintf("lsp%d", i),
+			Src:             "10.0.0.1",
+			Dst:             "10.0.0.4",
+			AllocBindingSID: true,
+		}
+		wg.Add(1)
+		go func(lsp *pcep.SRLSP) {
+			defer wg.Done()
+			err := c.CreateUpdSRLSP(lsp)
+			if err != nil {
+				t.Errorf("must not see any errors, instead got: %s", err.Error())
+			}
+		}(lsps[i])
+	}
+	wg.Wait()
+
+	seen := make(map[uint32]string)
+	for _, lsp := range lsps {
+		if other, ok := seen[lsp.BindingSID]; ok {
+			t.Errorf("binding SID %d allocated to both %s and %s", lsp.BindingSID, other, lsp.Name)
+		}
+		seen[lsp.BindingSID] = lsp.Name
+	}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"fmt"
+	"gopcep/pcep"
+
+	"github.com/sirupsen/logrus"
+)
+
+var pstNames = map[uint8]string{
+	pcep.PSTRSVPTE: "RSVP-TE",
+	pcep.PSTSRMPLS: "SR-MPLS",
+	pcep.PSTSRv6:   "SRv6",
+}
+
+// lspSkipReason returns why the LSP can not be initiated or updated over the
+// session given the capabilities the PCC advertised in its Open message,
+// it is empty when nothing prevents pushing the LSP
+func lspSkipReason(session *pcep.Session, lsp *pcep.SRLSP, update bool) string {
+	if update && !session.CanUpdate() {
+		return "PCC does not support LSP update, U flag is not set in STATEFUL-PCE-CAPABILITY"
+	}
+	if !update && !session.CanInitiate() {
+		return "PCC does not support LSP initiation, I flag is not set in STATEFUL-PCE-CAPABILITY"
+	}
+	// SR LSPs without a path setup type are SR-MPLS ones
+	pst := lsp.PST
+	if pst == pcep.PSTRSVPTE {
+		pst = pcep.PSTSRMPLS
+	}
+	if !session.SupportsPST(pst) {
+		return fmt.Sprintf("PCC does not support %s path setup type", pstNames[pst])
+	}
+	if pst == pcep.PSTSRMPLS {
+		msd := session.SRMSD()
+		if msd != 0 && len(lsp.EROList) > int(msd) {
+			return fmt.Sprintf("SID list depth %d exceeds PCC MSD %d", len(lsp.EROList), msd)
+		}
+	}
+	for _, a := range lsp.Associations {
+		if !session.SupportsAssocType(a.Type) {
+			return fmt.Sprintf("PCC does not support association type %d", a.Type)
+		}
+	}
+	return ""
+}
+
+// headEndMSD is the MSD advertised by the PCC with the given
+// loopback, 0 when it has no session or no MSD limit
+func (c *Controller) headEndMSD(src string) uint8 {
+	c.RLock()
+	session, ok := c.PCEPSessionsByLoopback[src]
+	c.RUnlock()
+	if !ok {
+		return 0
+	}
+	return session.SRMSD()
+}
+
+// skipLSP records on the LSP why it is not pushed to the PCC
+// and is true when it must not be pushed, the LSP must not be
+// one stored in the controller as it is modified in place
+func skipLSP(session *pcep.Session, lsp *pcep.SRLSP, update bool) bool {
+	lsp.SkipReason = lspSkipReason(session, lsp, update)
+	if lsp.SkipReason == "" {
+		return false
+	}
+	logrus.WithFields(logrus.Fields{
+		"type":     "session",
+		"event":    "lsp_skipped",
+		"lsp_name": lsp.Name,
+		"reason":   lsp.SkipReason,
+	}).Warn("pcc capabilities do not allow pushing lsp")
+	return true
+}
@@ -0,0 +1,282 @@
+package controller
+
+import (
+	"gopcep/pcep"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestCreateUpdSRLSPClearsSkipReason(t *testing.T) {
+	c := newTestController(t)
+	// without a session the LSP is only stored and nothing prevents pushing it yet
+	lsp := &pcep.SRLSP{Name: "lsp1", Src: "10.0.0.1", Dst: "10.0.0.4", SkipReason: "PCC does not support SRv6 path setup type"}
+	err := c.CreateUpdSRLSP(lsp)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	stored, ok := c.GetLSP("lsp1")
+	if !ok {
+		t.Fatal("expected LSP to be stored")
+	}
+	if stored.SkipReason != "" {
+		t.Errorf("expected skip reason to be cleared got %q", stored.SkipReason)
+	}
+}
+
+func TestUpdateSkipReason(t *testing.T) {
+	c := newTestController(t)
+	lsp := &pcep.SRLSP{Name: "lsp1", Src: "10.0.0.1", Dst: "10.0.0.4"}
+	err := c.CreateUpdSRLSP(lsp)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+
+	upd := *lsp
+	upd.SkipReason = "PCC does not support LSP initiation, I flag is not set in STATEFUL-PCE-CAPABILITY"
+	c.updateSkipReason(lsp, &upd)
+	stored, _ := c.GetLSP("lsp1")
+	if stored.SkipReason != upd.SkipReason {
+		t.Errorf("expected skip reason %q got %q", upd.SkipReason, stored.SkipReason)
+	}
+	if lsp.SkipReason != "" {
+		t.Error("stored LSP must not be modified in place")
+	}
+	// the reason survives a restart
+	c.DelLSP("lsp1")
+	err = c.LoadLSPs()
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	loaded, ok := c.GetLSP("lsp1")
+	if !ok || loaded.SkipReason != upd.SkipReason {
+		t.Errorf("expected skip reason %q to be persisted got %v", upd.SkipReason, loaded)
+	}
+
+	// a stale copy does not replace an LSP updated meanwhile
+	stale := *loaded
+	stale.SkipReason = ""
+	err = c.CreateUpdSRLSP(&pcep.SRLSP{Name: "lsp1", Src: "10.0.0.1", Dst: "10.0.0.4", BW: 100})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	c.updateSkipReason(loaded, &stale)
+	stored, _ = c.GetLSP("lsp1")
+	if stored.BW != 100 {
+		t.Errorf("expected the updated LSP to be kept got %v", stored)
+	}
+
+	// LSPs only kept in memory such as full mesh ones are not persisted
+	mesh := &pcep.SRLSP{Name: "LSP-10.0.0.1-10.0.0.2", Src: "10.0.0.1", Dst: "10.0.0.2"}
+	c.StoreLSP(mesh.Name, mesh)
+	upd = *mesh
+	upd.SkipReason = "PCC does not support SR-MPLS path setup type"
+	c.updateSkipReason(mesh, &upd)
+	c.DelLSP(mesh.Name)
+	err = c.LoadLSPs()
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if _, ok := c.GetLSP(mesh.Name); ok {
+		t.Error("expected in memory LSP not to be written to the DB")
+	}
+}
+
+// newTestPCC returns a session advertising LSP update, initiation and SR-MPLS
+// without MSD limit, messages sent to the PCC are written to the channel
+func newTestPCC(t *testing.T) (*pcep.Session, <-chan []byte) {
+	conn, peer := net.Pipe()
+	t.Cleanup(func() {
+		conn.Close()
+		peer.Close()
+	})
+	session := pcep.NewSession(conn)
+	session.StatefulCap = &pcep.StatefulPCECapability{Type: 16, UPDFlag: true, LSPInitCap: true}
+	session.SRCap = &pcep.SRPCECap{Type: 26, NoMSDLimit: true}
+
+	msgs := make(chan []byte, 10)
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := peer.Read(buf)
+			if err != nil {
+				return
+			}
+			if n >= 4 {
+				msgs <- append([]byte{}, buf[:n]...)
+			}
+		}
+	}()
+	return session, msgs
+}
+
+// expectPCCMsg reads the next message sent to the PCC and checks its type
+func expectPCCMsg(t *testing.T, msgs <-chan []byte, msgType uint8) []byte {
+	t.Helper()
+	select {
+	case msg := <-msgs:
+		if msg[1] != msgType {
+			t.Fatalf("expected msg type %d got %d", msgType, msg[1])
+		}
+		return msg
+	case <-time.After(time.Second):
+		t.Fatalf("expected msg type %d got nothing", msgType)
+	}
+	return nil
+}
+
+func TestLSPSkipReason(t *testing.T) {
+	sids := func(n int) []pcep.SREROSub {
+		return make([]pcep.SREROSub, n)
+	}
+	for _, c := range []struct {
+		name   string
+		setup  func(s *pcep.Session)
+		lsp    *pcep.SRLSP
+		update bool
+		reason string
+	}{
+		{
+			name: "nothing prevents initiation",
+			lsp:  &pcep.SRLSP{EROList: sids(3)},
+		},
+		{
+			name:   "nothing prevents update",
+			lsp:    &pcep.SRLSP{EROList: sids(3)},
+			update: true,
+		},
+		{
+			name:   "no LSP initiation",
+			setup:  func(s *pcep.Session) { s.StatefulCap.LSPInitCap = false },
+			lsp:    &pcep.SRLSP{},
+			reason: "PCC does not support LSP initiation, I flag is not set in STATEFUL-PCE-CAPABILITY",
+		},
+		{
+			name:   "no LSP update",
+			setup:  func(s *pcep.Session) { s.StatefulCap.UPDFlag = false },
+			lsp:    &pcep.SRLSP{},
+			update: true,
+			reason: "PCC does not support LSP update, U flag is not set in STATEFUL-PCE-CAPABILITY",
+		},
+		{
+			name:   "no SR-MPLS",
+			setup:  func(s *pcep.Session) { s.SRCap = nil },
+			lsp:    &pcep.SRLSP{},
+			reason: "PCC does not support SR-MPLS path setup type",
+		},
+		{
+			name:   "no SRv6",
+			lsp:    &pcep.SRLSP{PST: pcep.PSTSRv6},
+			reason: "PCC does not support SRv6 path setup type",
+		},
+		{
+			name:  "SRv6 listed in PATH-SETUP-TYPE-CAPABILITY",
+			setup: func(s *pcep.Session) { s.Open.PSTCap = &pcep.PSTCap{PSTs: []uint8{pcep.PSTSRMPLS, pcep.PSTSRv6}} },
+			lsp:   &pcep.SRLSP{PST: pcep.PSTSRv6},
+		},
+		{
+			name:   "SR-MPLS not listed in PATH-SETUP-TYPE-CAPABILITY",
+			setup:  func(s *pcep.Session) { s.Open.PSTCap = &pcep.PSTCap{PSTs: []uint8{pcep.PSTSRv6}} },
+			lsp:    &pcep.SRLSP{PST: pcep.PSTSRMPLS},
+			reason: "PCC does not support SR-MPLS path setup type",
+		},
+		{
+			name:  "SID list within MSD",
+			setup: func(s *pcep.Session) { s.SRCap = &pcep.SRPCECap{Type: 26, MSD: 3} },
+			lsp:   &pcep.SRLSP{EROList: sids(3)},
+		},
+		{
+			name:   "SID list exceeds MSD",
+			setup:  func(s *pcep.Session) { s.SRCap = &pcep.SRPCECap{Type: 26, MSD: 3} },
+			lsp:    &pcep.SRLSP{EROList: sids(4)},
+			reason: "SID list depth 4 exceeds PCC MSD 3",
+		},
+		{
+			name: "MSD does not apply to SRv6",
+			setup: func(s *pcep.Session) {
+				s.SRCap = &pcep.SRPCECap{Type: 26, MSD: 1}
+				s.Open.PSTCap = &pcep.PSTCap{PSTs: []uint8{pcep.PSTSRMPLS, pcep.PSTSRv6}}
+			},
+			lsp: &pcep.SRLSP{PST: pcep.PSTSRv6, SRv6EROList: make([]pcep.SRv6EROSub, 3)},
+		},
+		{
+			name: "association types not listed",
+			lsp: &pcep.SRLSP{Associations: []*pcep.Association{
+				{Type: pcep.AssocTypeSRPolicy},
+			}},
+		},
+		{
+			name: "association type listed",
+			setup: func(s *pcep.Session) {
+				s.Open.AssocTypes = []uint16{pcep.AssocTypePathProtection, pcep.AssocTypeSRPolicy}
+			},
+			lsp: &pcep.SRLSP{Associations: []*pcep.Association{
+				{Type: pcep.AssocTypeSRPolicy},
+			}},
+		},
+		{
+			name:  "association type not listed",
+			setup: func(s *pcep.Session) { s.Open.AssocTypes = []uint16{pcep.AssocTypePathProtection} },
+			lsp: &pcep.SRLSP{Associations: []*pcep.Association{
+				{Type: pcep.AssocTypePathProtection},
+				{Type: pcep.AssocTypeSRPolicy},
+			}},
+			reason: "PCC does not support association type 6",
+		},
+	} {
+		session, _ := newTestPCC(t)
+		if c.setup != nil {
+			c.setup(session)
+		}
+		if reason := lspSkipReason(session, c.lsp, c.update); reason != c.reason {
+			t.Errorf("%s: expected reason %q got %q", c.name, c.reason, reason)
+		}
+		if skipLSP(session, c.lsp, c.update) != (c.reason != "") || c.lsp.SkipReason != c.reason {
+			t.Errorf("%s: expected LSP skipped with reason %q got %q", c.name, c.reason, c.lsp.SkipReason)
+		}
+	}
+}
+
+func TestProvisionFullMeshLSPAfterSkip(t *testing.T) {
+	c := newTestController(t)
+	session, msgs := newTestPCC(t)
+	newLSP := func(ero ...uint32) *pcep.SRLSP {
+		lsp := &pcep.SRLSP{Name: "LSP-10.0.0.1-10.0.0.4", Src: "10.0.0.1", Dst: "10.0.0.4", Delegate: true, Admin: true}
+		for _, sid := range ero {
+			lsp.EROList = append(lsp.EROList, pcep.SREROSub{NT: 1, SID: sid, IPv4NodeID: "10.0.0.4"})
+		}
+		return lsp
+	}
+
+	// initiation is skipped until the PCC supports SR-MPLS
+	session.SRCap = nil
+	c.provisionFullMeshLSP(session, newLSP(16004))
+	stored, ok := c.GetLSP("LSP-10.0.0.1-10.0.0.4")
+	if !ok || stored.SkipReason == "" || len(stored.EROList) != 0 {
+		t.Fatalf("expected skipped LSP to be stored without path got %+v", stored)
+	}
+	session.SRCap = &pcep.SRPCECap{Type: 26, NoMSDLimit: true}
+	c.provisionFullMeshLSP(session, newLSP(16004))
+	expectPCCMsg(t, msgs, 12)
+	stored, _ = c.GetLSP("LSP-10.0.0.1-10.0.0.4")
+	if stored.SkipReason != "" || len(stored.EROList) != 1 {
+		t.Errorf("expected initiated LSP to be stored with its path got %+v", stored)
+	}
+
+	// the PCC reports the LSP delegated to us, updates are skipped
+	// until the SID list fits into its MSD
+	session.LSPs["LSP-10.0.0.1-10.0.0.4"] = &pcep.LSP{Name: "LSP-10.0.0.1-10.0.0.4", Delegate: true, PLSPID: 1}
+	session.SRCap = &pcep.SRPCECap{Type: 26, MSD: 1}
+	c.provisionFullMeshLSP(session, newLSP(16002, 16004))
+	stored, _ = c.GetLSP("LSP-10.0.0.1-10.0.0.4")
+	if stored.SkipReason == "" || len(stored.EROList) != 0 {
+		t.Fatalf("expected skipped update to be stored without path got %+v", stored)
+	}
+	session.SRCap = &pcep.SRPCECap{Type: 26, MSD: 2}
+	c.provisionFullMeshLSP(session, newLSP(16002, 16004))
+	expectPCCMsg(t, msgs, 11)
+	stored, _ = c.GetLSP("LSP-10.0.0.1-10.0.0.4")
+	if stored.SkipReason != "" || len(stored.EROList) != 2 {
+		t.Errorf("expected updated LSP to be stored with its path got %+v", stored)
+	}
+}
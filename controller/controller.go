@@ -5,7 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"gopcep/pcep"
-	"strings"
+	"net"
+	"reflect"
 	"sync"
 	"time"
 
@@ -24,6 +25,7 @@ type Controller struct {
 	db         *bolt.DB
 	bgpServer  *gobgp.BgpServer
 	BGPLSCfg   *BGPGlobalCfg
+	BSIDRange  BSIDRange
 	// The LSP list is maintained by the controller and
 	// inside PCEP libriry as well. If I just use one list in
 	// PCEP then the controller does not know it created an LSP
@@ -34,8 +36,12 @@ type Controller struct {
 	// lsps                   map[string]*pcep.SRLSP
 	PCEPSessions           map[string]*pcep.Session
 	PCEPSessionsByLoopback map[string]*pcep.Session
+	// signals PCEP listener to resync TCP auth keys
+	authUpdate chan struct{}
 	Routers
 	LSPs
+	Associations
+	SRPolicies
 }
 
 func (c *Controller) GetSRLSPs() []*pcep.SRLSP {
@@ -65,6 +71,14 @@ func (c *Controller) GetLSPs() []*pcep.LSP {
 }
 
 func (c *Controller) DelSRLSP(name string) error {
+	// the protecting LSP goes away together with the working one
+	if lsp, ok := c.GetLSP(name); ok && lsp.Protected {
+		err := c.removeSRLSP(secondaryLSPName(name))
+		if err != nil {
+			return err
+		}
+	}
+
 	defer c.Unlock()
 
 	c.Lock()
@@ -113,16 +127,79 @@ func (c *Controller) DelSRLSP(name string) error {
 	return nil
 }
 
+// removeSRLSP deletes the LSP from the router when it knows
+// about it otherwise the LSP is only removed from the controller
+func (c *Controller) removeSRLSP(name string) error {
+	lsp, ok := c.GetLSP(name)
+	if !ok {
+		return nil
+	}
+	c.RLock()
+	session, ok := c.PCEPSessionsByLoopback[lsp.Src]
+	c.RUnlock()
+	if ok && session.GetLSP(name) != nil {
+		return c.DelSRLSP(name)
+	}
+	if lsp.Protected {
+		err := c.removeSRLSP(secondaryLSPName(name))
+		if err != nil {
+			return err
+		}
+	}
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("lsps"))
+		if err != nil {
+			return err
+		}
+		return b.Delete([]byte(name))
+	})
+	if err != nil {
+		return err
+	}
+	c.DelLSP(name)
+	return nil
+}
+
 func (c *Controller) CreateUpdSRLSP(lsp *pcep.SRLSP) error {
+	// paths of protected LSPs are computed before they are pushed
+	secondary, err := c.updateProtection(lsp)
+	if err != nil {
+		return err
+	}
+
 	defer c.Unlock()
 
 	c.Lock()
+	// association IDs and Binding SIDs are allocated while holding the lock
+	// so concurrent requests can not pick the same ones
+	if secondary != nil {
+		err = c.protect(lsp, secondary)
+		if err != nil {
+			return err
+		}
+		err = c.createUpdSRLSP(secondary)
+		if err != nil {
+			return err
+		}
+	}
+	return c.createUpdSRLSP(lsp)
+}
+
+// createUpdSRLSP pushes the LSP to its head-end and stores it,
+// the caller holds the lock
+func (c *Controller) createUpdSRLSP(lsp *pcep.SRLSP) error {
+	err := c.assignBSID(lsp)
+	if err != nil {
+		return err
+	}
 
+	// the reason is set again when the LSP is skipped
+	lsp.SkipReason = ""
 	session, ok := c.PCEPSessionsByLoopback[lsp.Src]
-	// if sesstion exists we init the LSP
+	// if sesstion exists we init or update the LSP
 	// if not we just save it to use once we get session esteblished
 	if ok {
-		err := session.InitSRLSP(lsp)
+		err := pushSRLSP(session, lsp)
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"type":  "session",
@@ -132,6 +209,11 @@ func (c *Controller) CreateUpdSRLSP(lsp *pcep.SRLSP) error {
 		}
 	}
 
+	return c.storeSRLSP(lsp)
+}
+
+// storeSRLSP saves the LSP to the DB without pushing it to the router
+func (c *Controller) storeSRLSP(lsp *pcep.SRLSP) error {
 	err := c.db.Update(func(tx *bolt.Tx) error {
 		b, err := tx.CreateBucketIfNotExists([]byte("lsps"))
 		if err != nil {
@@ -151,6 +233,71 @@ func (c *Controller) CreateUpdSRLSP(lsp *pcep.SRLSP) error {
 	return nil
 }
 
+// updateSkipReason replaces the stored LSP with the copy carrying the new skip
+// reason unless the LSP was changed meanwhile, LSPs the controller only keeps
+// in memory are not written to the DB
+func (c *Controller) updateSkipReason(old, lsp *pcep.SRLSP) {
+	defer c.Unlock()
+
+	c.Lock()
+	if cur, ok := c.GetLSP(lsp.Name); !ok || cur != old {
+		return
+	}
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("lsps"))
+		if err != nil {
+			return err
+		}
+		if b.Get([]byte(lsp.Name)) == nil {
+			return nil
+		}
+		data, err := json.Marshal(lsp)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(lsp.Name), data)
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type":     "session",
+			"event":    "lsp_skip_reason",
+			"lsp_name": lsp.Name,
+		}).Error(err)
+		return
+	}
+	c.StoreLSP(lsp.Name, lsp)
+}
+
+// pushSRLSP modifies the LSP in place using PCUpd when the PCC
+// already reported it and delegated it to us, otherwise the LSP is initiated
+func pushSRLSP(session *pcep.Session, lsp *pcep.SRLSP) error {
+	sessionLSP := session.GetLSP(lsp.Name)
+	if sessionLSP == nil || !sessionLSP.Delegate {
+		// the LSP is initiated once the PCC is synced
+		// and no longer overloaded
+		if !session.Synced() || session.Overloaded() {
+			logrus.WithFields(logrus.Fields{
+				"type":     "session",
+				"event":    "lsp_init_paused",
+				"lsp_name": lsp.Name,
+			}).Info("pcc is not synced or overloaded postponing lsp init")
+			return nil
+		}
+		if skipLSP(session, lsp, false) {
+			return nil
+		}
+		return session.InitSRLSP(lsp)
+	}
+	if skipLSP(session, lsp, true) {
+		return nil
+	}
+	// working on a copy so the PLSP-ID assigned by the PCC
+	// does not end up in the controller DB
+	upd := *lsp
+	upd.PLSPID = sessionLSP.PLSPID
+	return session.UpdateSRLSP(&upd)
+}
+
 // LoadLSPs retrive all LSP stored in Bolt DB used to init
 func (c *Controller) LoadLSPs() error {
 
@@ -182,9 +329,13 @@ func (c *Controller) LoadLSPs() error {
 
 // CreateUpdRouter aa
 func (c *Controller) CreateUpdRouter(router *Router) error {
+	err := ValidateRouter(router)
+	if err != nil {
+		return err
+	}
 	defer c.Unlock()
 	c.Lock()
-	err := c.db.Update(func(tx *bolt.Tx) error {
+	err = c.db.Update(func(tx *bolt.Tx) error {
 		b, err := tx.CreateBucketIfNotExists([]byte("routers"))
 		if err != nil {
 			return err
@@ -220,6 +371,7 @@ func (c *Controller) CreateUpdRouter(router *Router) error {
 		return err
 	}
 	c.StoreRouter(router.ID, router)
+	c.notifyPeerAuthUpdate()
 	return nil
 }
 
@@ -244,6 +396,7 @@ func (c *Controller) DeleteRouter(id string) error {
 	if err != nil {
 		return err
 	}
+	c.notifyPeerAuthUpdate()
 	return nil
 }
 
@@ -389,24 +542,106 @@ func (c *Controller) SessionStart(session *pcep.Session) error {
 	return err
 }
 
+// GetOpenParams returns per router PCEP Open overrides if configured
+func (c *Controller) GetOpenParams(srcIP string) *pcep.OpenParams {
+	router := c.GetRouterByPCEPSessionSrcIP(srcIP)
+	if router == nil {
+		return nil
+	}
+	return router.PCEPOpenParams
+}
+
+// GetTLSPolicy returns per router PCEPS policy if configured
+func (c *Controller) GetTLSPolicy(srcIP string) pcep.TLSPolicy {
+	router := c.GetRouterByPCEPSessionSrcIP(srcIP)
+	if router == nil {
+		return ""
+	}
+	return router.PCEPTLSPolicy
+}
+
+// GetTLSIdentities returns the router name and loopback which
+// the PCC certificate may be issued for besides the session address
+func (c *Controller) GetTLSIdentities(srcIP string) []string {
+	router := c.GetRouterByPCEPSessionSrcIP(srcIP)
+	if router == nil {
+		return nil
+	}
+	ids := make([]string, 0, 2)
+	for _, id := range []string{router.Name, router.LoopbackIP} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// GetPeerAuth returns TCP auth keys by PCEP session source address
+func (c *Controller) GetPeerAuth() map[string]*pcep.AuthKey {
+	keys := make(map[string]*pcep.AuthKey)
+
+	c.RangeRouters(func(key, value interface{}) bool {
+		r := value.(*Router)
+		if r.PCEPAuth != nil && r.PCEPSessionSrcIP != "" {
+			keys[r.PCEPSessionSrcIP] = r.PCEPAuth
+		}
+		return true
+	})
+
+	return keys
+}
+
+// PeerAuthUpdates signals when routers and so the keys have changed
+func (c *Controller) PeerAuthUpdates() <-chan struct{} {
+	return c.authUpdate
+}
+
+func (c *Controller) notifyPeerAuthUpdate() {
+	// one pending update is enough as all keys are synced at once
+	select {
+	case c.authUpdate <- struct{}{}:
+	default:
+	}
+}
+
+// GetAuthStats returns TCP authentication failures of the whole host
+func (c *Controller) GetAuthStats() (*pcep.AuthStats, error) {
+	return pcep.GetAuthStats()
+}
+
+// SendNotification sends PCNtf to the PCC with the given session source address
+func (c *Controller) SendNotification(srcIP string, n *pcep.Notification) error {
+	c.RLock()
+	session, ok := c.PCEPSessions[srcIP]
+	c.RUnlock()
+	if !ok {
+		return fmt.Errorf("no PCEP session found for: %s", srcIP)
+	}
+	return session.SendNotification(n)
+}
+
 // SessionEnd aa
 func (c *Controller) SessionEnd(key string) {
 	c.DeletePSession(key)
 }
 
 // Start  controller
-func Start(db *bolt.DB, bgpcfg *BGPGlobalCfg) *Controller {
+func Start(db *bolt.DB, bgpcfg *BGPGlobalCfg, bsidRange *BSIDRange) *Controller {
 	c := &Controller{
 		PCEPSessions:           make(map[string]*pcep.Session),
 		PCEPSessionsByLoopback: make(map[string]*pcep.Session),
+		authUpdate:             make(chan struct{}, 1),
 		NewSession:             make(chan *pcep.Session),
 		TopoView:               NewTopoView(),
 		StopBGP:                make(chan bool),
 		Routers:                Routers{},
 		LSPs:                   LSPs{},
+		Associations:           Associations{},
+		SRPolicies:             SRPolicies{},
 		RWMutex:                &sync.RWMutex{},
 		db:                     db,
 		BGPLSCfg:               bgpcfg,
+		BSIDRange:              *bsidRange,
 	}
 
 	err := c.LoadRouters()
@@ -425,6 +660,22 @@ func Start(db *bolt.DB, bgpcfg *BGPGlobalCfg) *Controller {
 		}).Fatal(err)
 	}
 
+	err = c.LoadAssociations()
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type":  "controller",
+			"event": "load_associations",
+		}).Fatal(err)
+	}
+
+	err = c.LoadSRPolicies()
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type":  "controller",
+			"event": "load_srpolicies",
+		}).Fatal(err)
+	}
+
 	go c.StartBGPLS()
 
 	go func() {
@@ -442,11 +693,17 @@ func Start(db *bolt.DB, bgpcfg *BGPGlobalCfg) *Controller {
 					"type":  "topology",
 					"event": "update",
 				}).Info("new topology update running LSP optimisation")
+				// LSPs are pushed without holding the lock
+				// as storing them takes it
 				c.RLock()
+				sessions := make([]*pcep.Session, 0, len(c.PCEPSessions))
 				for _, session := range c.PCEPSessions {
-					c.InitSRLSPs(session)
+					sessions = append(sessions, session)
 				}
 				c.RUnlock()
+				for _, session := range sessions {
+					c.InitSRLSPs(session)
+				}
 
 			}
 		}
@@ -463,7 +720,20 @@ func (c *Controller) watchSession(session *pcep.Session) {
 				"type":           "session",
 				"event":          "ready",
 				"router_address": session.Conn.RemoteAddr().String(),
-			}).Info("new session is ready")
+			}).Info("new session is ready waiting for lsp state sync")
+		case <-session.SessionSynced:
+			logrus.WithFields(logrus.Fields{
+				"type":           "session",
+				"event":          "synced",
+				"router_address": session.Conn.RemoteAddr().String(),
+			}).Info("lsp state sync done")
+			c.InitSRLSPs(session)
+		case <-session.OverloadEnded:
+			logrus.WithFields(logrus.Fields{
+				"type":           "session",
+				"event":          "overload_ended",
+				"router_address": session.Conn.RemoteAddr().String(),
+			}).Info("resuming lsp init")
 			c.InitSRLSPs(session)
 		case <-session.SessionClosed:
 			logrus.WithFields(logrus.Fields{
@@ -476,10 +746,34 @@ func (c *Controller) watchSession(session *pcep.Session) {
 }
 
 func getSrcAddrFromSession(session *pcep.Session) string {
-	return strings.Split(session.Conn.RemoteAddr().String(), ":")[0]
+	host, _, err := net.SplitHostPort(session.Conn.RemoteAddr().String())
+	if err != nil {
+		return session.Conn.RemoteAddr().String()
+	}
+	return host
 }
 
 func (c *Controller) InitSRLSPs(session *pcep.Session) {
+	// until the PCC reported all of its LSPs we do not know
+	// which ones it has already and would init them again
+	if !session.Synced() {
+		logrus.WithFields(logrus.Fields{
+			"type":           "session",
+			"event":          "lsp_init_paused",
+			"router_address": session.Conn.RemoteAddr().String(),
+		}).Info("lsp state sync in progress skipping lsp init")
+		return
+	}
+	// no new LSPs while the PCC is overloaded
+	// all of them are pushed once the overload ends
+	if session.Overloaded() {
+		logrus.WithFields(logrus.Fields{
+			"type":           "session",
+			"event":          "lsp_init_paused",
+			"router_address": session.Conn.RemoteAddr().String(),
+		}).Info("pcc is overloaded skipping lsp init")
+		return
+	}
 
 	// get details of the router using session src address
 	router := c.GetRouterByPCEPSessionSrcIP(session.GetSrcAddrFromSession())
@@ -502,13 +796,26 @@ func (c *Controller) InitSRLSPs(session *pcep.Session) {
 		if sessionLSP != nil && sessionLSP.Oper == 2 {
 			continue
 		}
+		// stored LSPs are shared with API readers so
+		// the skip reason is recorded on a copy
+		upd := *lsp
+		if skipLSP(session, &upd, false) {
+			if upd.SkipReason != lsp.SkipReason {
+				c.updateSkipReason(lsp, &upd)
+			}
+			continue
+		}
 
-		err := session.InitSRLSP(lsp)
+		err := session.InitSRLSP(&upd)
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"type":  "session",
 				"event": "lsp_init",
 			}).Error(err)
+			continue
+		}
+		if lsp.SkipReason != "" {
+			c.updateSkipReason(lsp, &upd)
 		}
 	}
 	// Init full mesh after we init all the manually created ones
@@ -550,13 +857,15 @@ func (c *Controller) InitSRLSPFullMesh(session *pcep.Session) {
 
 	for _, dst := range destinations {
 
-		bestPath := c.TopoView.findBestPath(0, srcAddr, dst)
+		// the SID list has to fit into the MSD the PCC advertised
+		bestPath := c.TopoView.findBestSRPath(0, srcAddr, dst, session.SRMSD())
 		if bestPath == nil {
 			logrus.WithFields(logrus.Fields{
 				"type":        "lsp_init",
 				"event":       "no_best_path_found",
 				"src_address": srcAddr,
 				"dst":         dst,
+				"msd":         session.SRMSD(),
 			}).Info("failed to find any path to destination")
 			continue
 		}
@@ -576,44 +885,108 @@ func (c *Controller) InitSRLSPFullMesh(session *pcep.Session) {
 			}).Error(err)
 			continue
 		}
-		// this needs to be turned into proper comparation of LSPs
-		// so if the new LSP is the same no point touching it
-		// need to copare ERO list and other options to decide if we need to update
-		_, ok := c.GetLSP(lsp.Name)
-		if ok {
+		c.provisionFullMeshLSP(session, lsp)
+
+		// SRv6 policies go alongside SR-MPLS ones when both the PCC
+		// and the nodes on the path support SRv6
+		if !session.SRv6Capable() || !c.TopoView.pathHasSRv6(bestPath) {
 			continue
 		}
+		lsp, err = c.TopoView.createSRv6LSP(100, bestPath)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"type":  "session",
+				"event": "create_srv6_lsp",
+			}).Error(err)
+			continue
+		}
+		c.provisionFullMeshLSP(session, lsp)
+	}
 
-		logrus.WithFields(logrus.Fields{
-			"type":        "lsp_init",
-			"event":       "lsp_created",
-			"src_address": srcAddr,
-			"dss":         dst,
-			"lsp":         lsp,
-		}).Info("lsp created now running pcep init")
+	logrus.WithFields(logrus.Fields{
+		"type":      "after",
+		"func":      "InitSRLSP",
+		"time_took": time.Since(start),
+	}).Info("LSP init done")
+
+}
 
-		err = session.InitSRLSP(lsp)
+// provisionFullMeshLSP initiates a new full mesh LSP or re-optimises
+// the existing one in place as long as it is delegated to us
+func (c *Controller) provisionFullMeshLSP(session *pcep.Session, lsp *pcep.SRLSP) {
+	// if the new path is the same no point touching the LSP
+	// otherwise re-optimise it in place as long as it is delegated to us
+	ctrLSP, ok := c.GetLSP(lsp.Name)
+	// an LSP skipped before the PCC reported it is initiated again
+	if ok && ctrLSP.SkipReason != "" && session.GetLSP(lsp.Name) == nil {
+		ok = false
+	}
+	if ok {
+		if reflect.DeepEqual(ctrLSP.EROList, lsp.EROList) && reflect.DeepEqual(ctrLSP.SRv6EROList, lsp.SRv6EROList) {
+			return
+		}
+		sessionLSP := session.GetLSP(lsp.Name)
+		if sessionLSP == nil || !sessionLSP.Delegate {
+			return
+		}
+		err := pushSRLSP(session, lsp)
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"type":  "session",
-				"event": "lsp_init",
+				"event": "lsp_upd",
 			}).Error(err)
+			return
+		}
+		if lsp.SkipReason != "" {
+			c.storeSkippedLSP(lsp)
+			return
 		}
-
 		c.StoreLSP(lsp.Name, lsp)
 
 		logrus.WithFields(logrus.Fields{
 			"type": "lsp_provision",
-			"func": "InitSRLSP",
+			"func": "UpdateSRLSP",
 			"src":  lsp.Src,
 			"dst":  lsp.Dst,
-		}).Info("new lsp provisioned")
+		}).Info("lsp re-optimised")
+		return
 	}
 
 	logrus.WithFields(logrus.Fields{
-		"type":      "after",
-		"func":      "InitSRLSP",
-		"time_took": time.Since(start),
-	}).Info("LSP init done")
+		"type":  "lsp_init",
+		"event": "lsp_created",
+		"src":   lsp.Src,
+		"dst":   lsp.Dst,
+		"lsp":   lsp,
+	}).Info("lsp created now running pcep init")
+
+	if skipLSP(session, lsp, false) {
+		c.storeSkippedLSP(lsp)
+		return
+	}
+	err := session.InitSRLSP(lsp)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type":  "session",
+			"event": "lsp_init",
+		}).Error(err)
+	}
 
+	c.StoreLSP(lsp.Name, lsp)
+
+	logrus.WithFields(logrus.Fields{
+		"type": "lsp_provision",
+		"func": "InitSRLSP",
+		"src":  lsp.Src,
+		"dst":  lsp.Dst,
+	}).Info("new lsp provisioned")
+}
+
+// storeSkippedLSP keeps a full mesh LSP which was not pushed so its skip reason
+// can be looked up, its path is left out as otherwise an equal path computed
+// later would be taken for the one on the router and the LSP never pushed
+func (c *Controller) storeSkippedLSP(lsp *pcep.SRLSP) {
+	lsp.EROList = nil
+	lsp.SRv6EROList = nil
+	c.StoreLSP(lsp.Name, lsp)
 }
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"gopcep/pcep"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// newTestController returns a controller backed by a temporary bolt db
+// without starting BGP-LS or any of the background routines of Start
+func newTestController(t *testing.T) *Controller {
+	t.Helper()
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	t.Cleanup(func() { db.Close() })
+	return &Controller{
+		PCEPSessions:           make(map[string]*pcep.Session),
+		PCEPSessionsByLoopback: make(map[string]*pcep.Session),
+		authUpdate:             make(chan struct{}, 1),
+		TopoView:               NewTopoView(),
+		RWMutex:                &sync.RWMutex{},
+		db:                     db,
+		BSIDRange:              BSIDRange{Start: 1000000, End: 1000100},
+	}
+}
+
+func TestLoadLSPsBaselineFormat(t *testing.T) {
+	c := newTestController(t)
+	// LSP as written before interface IDs of unnumbered adjacencies became numbers
+	stored := `{"Delegate":true,"Sync":false,"Remove":false,"Admin":true,"Name":"lsp1","Src":"10.0.0.1","Dst":"10.0.0.4",` +
+		`"EROList":[{"LooseHop":false,"NT":1,"MBit":true,"CBit":false,"NoSID":false,"NoNAI":false,"SID":16004,` +
+		`"IPv4NodeID":"10.0.0.4","IPv4Adjacency":null,` +
+		`"UnnuV4Adj":{"LocalNodeID":"","LocalInterfaceID":"","RemoteNodeID":"","RemoteInterfaceID":""}}],` +
+		`"SetupPrio":7,"HoldPrio":7,"LocalProtect":false,"BW":0,"SRPRemove":false,"PLSPID":0}`
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("lsps"))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("lsp1"), []byte(stored))
+	})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	err = c.LoadLSPs()
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	lsp, ok := c.GetLSP("lsp1")
+	if !ok {
+		t.Fatal("expected LSP to be loaded")
+	}
+	if len(lsp.EROList) != 1 || lsp.EROList[0].SID != 16004 || lsp.EROList[0].UnnuV4Adj != (pcep.UnnuAdjIPv4NodeIDs{}) {
+		t.Errorf("unexpected ERO %+v", lsp.EROList)
+	}
+}
+
+func TestPushSRLSP(t *testing.T) {
+	newLSP := func() *pcep.SRLSP {
+		return &pcep.SRLSP{
+			Name:     "lsp1",
+			Src:      "10.0.0.1",
+			Dst:      "10.0.0.4",
+			Delegate: true,
+			Admin:    true,
+			EROList:  []pcep.SREROSub{{NT: 1, MBit: true, SID: 16004, IPv4NodeID: "10.0.0.4"}},
+		}
+	}
+	for _, c := range []struct {
+		name string
+		// LSP the PCC reported, nil when it did not report any
+		reported *pcep.LSP
+		unsynced bool
+		// type of the msg sent to the PCC, 0 when nothing is sent
+		msgType uint8
+	}{
+		{name: "LSP not reported", msgType: 12},
+		{name: "LSP not delegated", reported: &pcep.LSP{Name: "lsp1", PLSPID: 9}, msgType: 12},
+		{name: "delegated LSP", reported: &pcep.LSP{Name: "lsp1", Delegate: true, PLSPID: 9}, msgType: 11},
+		{name: "PCC not synced", unsynced: true},
+	} {
+		session, msgs := newTestPCC(t)
+		if !c.unsynced {
+			session.SyncState = pcep.SyncStateSynced
+		}
+		if c.reported != nil {
+			session.LSPs["lsp1"] = c.reported
+		}
+		lsp := newLSP()
+		err := pushSRLSP(session, lsp)
+		if err != nil {
+			t.Fatalf("%s: must not see any errors, instead got: %s", c.name, err.Error())
+		}
+		if c.msgType == 0 {
+			select {
+			case msg := <-msgs:
+				t.Errorf("%s: expected no msgs got type %d", c.name, msg[1])
+			case <-time.After(50 * time.Millisecond):
+			}
+			continue
+		}
+		msg := expectPCCMsg(t, msgs, c.msgType)
+		if c.msgType != 11 {
+			continue
+		}
+		// PCUpd refers to the LSP by the PLSP-ID the PCC assigned
+		// which stays out of the controller LSP
+		srpLen := int(binary.BigEndian.Uint16(msg[6:8]))
+		plspID := binary.BigEndian.Uint32(msg[4+srpLen+4:]) >> 12
+		if msg[4+srpLen] != 32 || plspID != 9 {
+			t.Errorf("%s: expected LSP object with PLSP-ID 9 got class %d PLSP-ID %d", c.name, msg[4+srpLen], plspID)
+		}
+		if lsp.PLSPID != 0 {
+			t.Errorf("%s: expected controller LSP without PLSP-ID got %d", c.name, lsp.PLSPID)
+		}
+	}
+}
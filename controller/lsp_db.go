@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopcep/pcep"
+
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+// GetLSPDB returns the LSP-DB stored under the given SPEAKER-ENTITY-ID or
+// address, when a PCC with SPEAKER-ENTITY-ID is looked up by its address
+// the DB it last stored from there is returned, nil if there is none
+func (c *Controller) GetLSPDB(key string) *pcep.LSPDB {
+	var db *pcep.LSPDB
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("lspdb"))
+		if b == nil {
+			return nil
+		}
+		v := b.Get([]byte(key))
+		if v == nil {
+			idx := tx.Bucket([]byte("lspdbaddr"))
+			if idx == nil {
+				return nil
+			}
+			k := idx.Get([]byte(key))
+			if k == nil {
+				return nil
+			}
+			v = b.Get(k)
+			if v == nil {
+				return nil
+			}
+		}
+		var d pcep.LSPDB
+		err := json.Unmarshal(v, &d)
+		if err != nil {
+			return err
+		}
+		db = &d
+		return nil
+	})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type":  "controller",
+			"event": "load_lspdb",
+			"key":   key,
+		}).Error(err)
+		return nil
+	}
+	return db
+}
+
+// StoreLSPDB saves the LSP-DB of a PCC keyed by its SPEAKER-ENTITY-ID
+// so the state synchronization can be skipped when it reconnects
+// even from a different address
+func (c *Controller) StoreLSPDB(db *pcep.LSPDB) error {
+	data, err := json.Marshal(db)
+	if err != nil {
+		return err
+	}
+	return c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("lspdb"))
+		if err != nil {
+			return err
+		}
+		idx, err := tx.CreateBucketIfNotExists([]byte("lspdbaddr"))
+		if err != nil {
+			return err
+		}
+		// the PCC may have been stored under its address before
+		// it sent SPEAKER-ENTITY-ID, the DBs of other PCCs which
+		// used the address before are kept under their own ID
+		if db.Key() != db.Addr && b.Get([]byte(db.Addr)) != nil {
+			err = b.Delete([]byte(db.Addr))
+			if err != nil {
+				return err
+			}
+		}
+		err = idx.Put([]byte(db.Addr), []byte(db.Key()))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(db.Key()), data)
+	})
+}
+
+// TriggerResync asks the PCC with the given session source address to report
+// the LSP with the given PLSP-ID again or all of them when PLSP-ID is 0
+func (c *Controller) TriggerResync(srcIP string, plspID uint32) error {
+	c.RLock()
+	session, ok := c.PCEPSessions[srcIP]
+	c.RUnlock()
+	if !ok {
+		return fmt.Errorf("no PCEP session found for: %s", srcIP)
+	}
+	return session.TriggerResync(plspID)
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"testing"
+
+	"gopcep/pcep"
+)
+
+func TestLSPDBKeyedBySpeakerEntityID(t *testing.T) {
+	c := newTestController(t)
+
+	// PCC without SPEAKER-ENTITY-ID is stored under its address
+	err := c.StoreLSPDB(&pcep.LSPDB{Addr: "10.0.0.1", Version: 1})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	db := c.GetLSPDB("10.0.0.1")
+	if db == nil || db.Version != 1 {
+		t.Fatalf("expected LSP-DB version 1 got %+v", db)
+	}
+
+	// once it sends SPEAKER-ENTITY-ID the DB moves under the ID
+	err = c.StoreLSPDB(&pcep.LSPDB{SpeakerEntityID: "pcc1", Addr: "10.0.0.1", Version: 2})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	db = c.GetLSPDB("pcc1")
+	if db == nil || db.Version != 2 {
+		t.Fatalf("expected LSP-DB version 2 got %+v", db)
+	}
+	// the address still leads to the DB for the version sent in our Open
+	db = c.GetLSPDB("10.0.0.1")
+	if db == nil || db.SpeakerEntityID != "pcc1" {
+		t.Errorf("expected LSP-DB of pcc1 by address got %+v", db)
+	}
+
+	// PCC connects from another address and keeps its DB
+	db = c.GetLSPDB("pcc1")
+	db.Addr = "10.0.0.2"
+	db.Version = 3
+	err = c.StoreLSPDB(db)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	db = c.GetLSPDB("pcc1")
+	if db == nil || db.Version != 3 || db.Addr != "10.0.0.2" {
+		t.Errorf("expected LSP-DB version 3 from 10.0.0.2 got %+v", db)
+	}
+
+	// another PCC reusing the old address does not remove the DB of pcc1
+	err = c.StoreLSPDB(&pcep.LSPDB{SpeakerEntityID: "pcc2", Addr: "10.0.0.1", Version: 7})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if db = c.GetLSPDB("pcc1"); db == nil || db.Version != 3 {
+		t.Errorf("expected LSP-DB of pcc1 to be kept got %+v", db)
+	}
+	if db = c.GetLSPDB("10.0.0.1"); db == nil || db.SpeakerEntityID != "pcc2" {
+		t.Errorf("expected LSP-DB of pcc2 by address got %+v", db)
+	}
+
+	if c.GetLSPDB("pcc3") != nil {
+		t.Error("expected no LSP-DB for unknown PCC")
+	}
+}
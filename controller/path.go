@@ -1,6 +1,11 @@
 package controller
 
-import "sync"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
 
 type Path struct {
 	Src   string
@@ -14,12 +19,70 @@ type Link struct {
 	RemoteNode      string
 	IntIP           string
 	NeighbourIP     string
+	IntIPv6         string
+	NeighbourIPv6   string
+	LocalLinkID     uint32
+	RemoteLinkID    uint32
 	DefaultTEMetric uint32
 	IGPMetric       uint32
 	BW              float32
 	ReservableBW    float32
 	UnreservedBW    float32
 	SRAdjacencySID  uint32
+	// SRAdjacencySID is an index into the SRLB of the local node
+	SRAdjacencyIndex bool
+	SRv6EndXSIDs     []*SRv6SID
+}
+
+// unnumbered links have no interface addresses only link IDs
+func (l *Link) unnumbered() bool {
+	return l.IntIP == "" && l.IntIPv6 == "" && l.LocalLinkID != 0
+}
+
+// key identifies the link regardless of its direction
+func (l *Link) key() string {
+	ends := []string{
+		fmt.Sprintf("%s/%s/%s/%d", l.LocalNode, l.IntIP, l.IntIPv6, l.LocalLinkID),
+		fmt.Sprintf("%s/%s/%s/%d", l.RemoteNode, l.NeighbourIP, l.NeighbourIPv6, l.RemoteLinkID),
+	}
+	sort.Strings(ends)
+	return strings.Join(ends, "-")
+}
+
+// transitNodes returns the nodes the path goes through
+// not counting its source and destination
+func (p *Path) transitNodes() map[string]bool {
+	nodes := make(map[string]bool)
+	for _, link := range p.Links {
+		if link.RemoteNode != p.Dst {
+			nodes[link.RemoteNode] = true
+		}
+	}
+	return nodes
+}
+
+// disjoint is true when the paths share no links and
+// with nodeDisjoint set no transit nodes either
+func (p *Path) disjoint(other *Path, nodeDisjoint bool) bool {
+	links := make(map[string]bool)
+	for _, link := range p.Links {
+		links[link.key()] = true
+	}
+	for _, link := range other.Links {
+		if links[link.key()] {
+			return false
+		}
+	}
+	if !nodeDisjoint {
+		return true
+	}
+	nodes := p.transitNodes()
+	for node := range other.transitNodes() {
+		if nodes[node] {
+			return false
+		}
+	}
+	return true
 }
 
 type Paths struct {
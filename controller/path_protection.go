@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"gopcep/pcep"
+)
+
+// https://tools.ietf.org/html/rfc8231#section-7.3
+// LSP operational state active
+const lspOperActive uint8 = 2
+
+//ProtectionStatus of a protected LSP and its protecting LSP
+// as reported by the PCC https://tools.ietf.org/html/rfc8745
+type ProtectionStatus struct {
+	Name          string
+	Secondary     string
+	PrimaryOper   uint8
+	SecondaryOper uint8
+	// name of the LSP the PCC reports active, empty when none is
+	Active string
+}
+
+// secondaryLSPName is the name of the protecting LSP of a protected LSP
+func secondaryLSPName(name string) string {
+	return name + "-secondary"
+}
+
+// withoutPathProtection returns the associations other than path protection
+func withoutPathProtection(as []*pcep.Association) []*pcep.Association {
+	filtered := make([]*pcep.Association, 0, len(as))
+	for _, a := range as {
+		if a.Type != pcep.AssocTypePathProtection {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
+// pathProtectionID returns the path protection association ID the LSP
+// has already or the lowest one not used by any other LSP of the head-end
+func (c *Controller) pathProtectionID(lsp *pcep.SRLSP) (uint16, error) {
+	if old, ok := c.GetLSP(lsp.Name); ok {
+		for _, a := range old.Associations {
+			if a.Type == pcep.AssocTypePathProtection {
+				return a.ID, nil
+			}
+		}
+	}
+	// the IDs the LSP and its protecting LSP have are given out again
+	used := c.associationIDsInUse(pcep.AssocTypePathProtection, lsp.Src, func(name string) bool {
+		return name == lsp.Name || name == secondaryLSPName(lsp.Name)
+	})
+	id, ok := freeAssociationID(used)
+	if ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("no free path protection association ID left for %s", lsp.Src)
+}
+
+// updateProtection computes disjoint working and protecting paths of a
+// protected LSP and returns the protecting LSP to push along with it
+// https://tools.ietf.org/html/rfc8745#section-3
+// when protection is turned off the protecting LSP is removed
+func (c *Controller) updateProtection(lsp *pcep.SRLSP) (*pcep.SRLSP, error) {
+	if !lsp.Protected {
+		old, ok := c.GetLSP(lsp.Name)
+		if !ok || !old.Protected {
+			return nil, nil
+		}
+		lsp.Associations = withoutPathProtection(lsp.Associations)
+		return nil, c.removeSRLSP(secondaryLSPName(lsp.Name))
+	}
+	if lsp.PST == pcep.PSTSRv6 {
+		return nil, errors.New("path protection is not supported for SRv6 LSPs")
+	}
+	var primaryERO, secondaryERO []pcep.SREROSub
+	var err error
+	if len(lsp.EROList) > 0 {
+		// explicit working path is kept and only the protecting one is computed
+		primaryERO = lsp.EROList
+		secondaryERO, err = c.TopoView.computeProtectingSRPath(float32(lsp.BW), lsp.Src, lsp.Dst, primaryERO, lsp.NodeDisjoint, c.headEndMSD(lsp.Src))
+	} else {
+		primaryERO, secondaryERO, err = c.TopoView.computeDisjointSRPaths(float32(lsp.BW), lsp.Src, lsp.Dst, lsp.NodeDisjoint, c.headEndMSD(lsp.Src))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lsp.EROList = primaryERO
+	return &pcep.SRLSP{
+		Delegate:     lsp.Delegate,
+		Admin:        lsp.Admin,
+		Name:         secondaryLSPName(lsp.Name),
+		Src:          lsp.Src,
+		Dst:          lsp.Dst,
+		EROList:      secondaryERO,
+		PST:          lsp.PST,
+		SetupPrio:    lsp.SetupPrio,
+		HoldPrio:     lsp.HoldPrio,
+		LocalProtect: lsp.LocalProtect,
+		BW:           lsp.BW,
+		ExcludeAny:   lsp.ExcludeAny,
+		IncludeAny:   lsp.IncludeAny,
+		IncludeAll:   lsp.IncludeAll,
+		Metrics:      lsp.Metrics,
+	}, nil
+}
+
+// protect puts the LSP and its protecting LSP into the same path
+// protection association, the caller holds the lock so that
+// no other LSP is given the same association ID meanwhile
+func (c *Controller) protect(lsp, secondary *pcep.SRLSP) error {
+	id, err := c.pathProtectionID(lsp)
+	if err != nil {
+		return err
+	}
+	lsp.Associations = append(withoutPathProtection(lsp.Associations), &pcep.Association{
+		Type:   pcep.AssocTypePathProtection,
+		ID:     id,
+		Source: lsp.Src,
+		PathProtection: &pcep.PathProtection{
+			ProtectionType: pcep.ProtectionType1toN,
+		},
+	})
+	secondary.Associations = []*pcep.Association{
+		{
+			Type:   pcep.AssocTypePathProtection,
+			ID:     id,
+			Source: lsp.Src,
+			PathProtection: &pcep.PathProtection{
+				ProtectionType: pcep.ProtectionType1toN,
+				Protecting:     true,
+			},
+		},
+	}
+	return nil
+}
+
+// GetProtectionStatus returns the operational state of the working and
+// protecting LSPs reported by the PCC and which one of them is active
+func (c *Controller) GetProtectionStatus(name string) (*ProtectionStatus, error) {
+	lsp, ok := c.GetLSP(name)
+	if !ok {
+		return nil, fmt.Errorf("no LSP named: %s found in controller db", name)
+	}
+	if !lsp.Protected {
+		return nil, fmt.Errorf("LSP %s is not protected", name)
+	}
+	status := &ProtectionStatus{
+		Name:      name,
+		Secondary: secondaryLSPName(name),
+	}
+	c.RLock()
+	session, ok := c.PCEPSessionsByLoopback[lsp.Src]
+	c.RUnlock()
+	if !ok {
+		return status, nil
+	}
+	if primary := session.GetLSP(status.Name); primary != nil {
+		status.PrimaryOper = primary.Oper
+		if primary.Oper == lspOperActive {
+			status.Active = status.Name
+		}
+	}
+	if secondary := session.GetLSP(status.Secondary); secondary != nil {
+		status.SecondaryOper = secondary.Oper
+		if secondary.Oper == lspOperActive && status.Active == "" {
+			status.Active = status.Secondary
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,255 @@
+package controller
+
+import (
+	"fmt"
+	"gopcep/pcep"
+	"net"
+	"strings"
+)
+
+// ComputeSRPath runs path computation over the TopoView
+// for a PCC which sent a stateless PCReq
+func (c *Controller) ComputeSRPath(req *pcep.PathCompRequest) (*pcep.PathCompReply, error) {
+	return c.TopoView.computeSRPath(req.BW, req.Src, req.Dst, c.headEndMSD(req.Src))
+}
+
+func (t *TopoView) getIGPRouterIDByAddr(addr string) (string, bool) {
+	defer t.RUnlock()
+
+	t.RLock()
+	ip := net.ParseIP(addr)
+	for _, prefixes := range []map[string]*Prefix{t.PrefixByIGPRouteID, t.PrefixV6ByIGPRouteID} {
+		for id, prefix := range prefixes {
+			if net.ParseIP(strings.Split(prefix.Prefix, "/")[0]).Equal(ip) {
+				return id, true
+			}
+		}
+	}
+	return "", false
+}
+
+// computeSRPath picks the lowest cost path whose SID list fits into
+// the MSD of the head-end, 0 MSD means no limit
+func (t *TopoView) computeSRPath(bw float32, src, dst string, msd uint8) (*pcep.PathCompReply, error) {
+	reply := &pcep.PathCompReply{}
+
+	srcID, ok := t.getIGPRouterIDByAddr(src)
+	if !ok {
+		reply.NoPathVector |= pcep.NoPathUnknownSrc
+	}
+	dstID, ok := t.getIGPRouterIDByAddr(dst)
+	if !ok {
+		reply.NoPathVector |= pcep.NoPathUnknownDst
+	}
+	if reply.NoPathVector != 0 {
+		return reply, nil
+	}
+
+	pID := srcID + ":" + dstID
+	_, ok = t.GetPath(pID)
+	if !ok {
+		t.Lock()
+		t.FindAllPathsForSrcDst(srcID, dstID)
+		t.Unlock()
+	}
+
+	bestPath := t.findBestSRPath(int(bw), srcID, dstID, msd)
+	if bestPath == nil {
+		if t.findBestPath(int(bw), srcID, dstID) != nil {
+			return nil, &pcep.NoPathError{Src: src, Dst: dst, Constraint: fmt.Sprintf("MSD %d", msd)}
+		}
+		// if there are paths none of them has enough bandwidth
+		paths, _ := t.GetPath(pID)
+		reply.BWUnsatisfied = len(paths) > 0
+		return reply, nil
+	}
+
+	lsp, err := t.createSRLSP(uint32(bw), bestPath)
+	if err != nil {
+		return nil, err
+	}
+	reply.EROList = lsp.EROList
+	reply.Cost = uint32(bestPath.Cost)
+
+	return reply, nil
+}
+
+// computeDisjointSRPaths returns the EROs of the best path and of the best
+// path disjoint from it, used for working and protecting paths
+// both SID lists fit into the MSD of the head-end
+func (t *TopoView) computeDisjointSRPaths(bw float32, src, dst string, nodeDisjoint bool, msd uint8) ([]pcep.SREROSub, []pcep.SREROSub, error) {
+	srcID, ok := t.getIGPRouterIDByAddr(src)
+	if !ok {
+		return nil, nil, fmt.Errorf("no node found for src: %s", src)
+	}
+	dstID, ok := t.getIGPRouterIDByAddr(dst)
+	if !ok {
+		return nil, nil, fmt.Errorf("no node found for dst: %s", dst)
+	}
+
+	pID := srcID + ":" + dstID
+	_, ok = t.GetPath(pID)
+	if !ok {
+		t.Lock()
+		t.FindAllPathsForSrcDst(srcID, dstID)
+		t.Unlock()
+	}
+
+	primary := t.findBestSRPath(int(bw), srcID, dstID, msd)
+	if primary == nil {
+		return nil, nil, &pcep.NoPathError{Src: src, Dst: dst, Constraint: fmt.Sprintf("MSD %d", msd)}
+	}
+	secondary := t.findBestDisjointPath(int(bw), []*Path{primary}, nodeDisjoint, msd)
+	if secondary == nil {
+		return nil, nil, fmt.Errorf("no disjoint path found from %s to %s", src, dst)
+	}
+
+	primaryLSP, err := t.createSRLSP(uint32(bw), primary)
+	if err != nil {
+		return nil, nil, err
+	}
+	secondaryLSP, err := t.createSRLSP(uint32(bw), secondary)
+	if err != nil {
+		return nil, nil, err
+	}
+	return primaryLSP.EROList, secondaryLSP.EROList, nil
+}
+
+// computeProtectingSRPath returns the ERO of the best path disjoint from the
+// explicit primary ERO, node SIDs are routed over all the IGP shortest paths
+// to the nodes they name so the protecting path is disjoint from all of them
+func (t *TopoView) computeProtectingSRPath(bw float32, src, dst string, primaryERO []pcep.SREROSub, nodeDisjoint bool, msd uint8) ([]pcep.SREROSub, error) {
+	srcID, ok := t.getIGPRouterIDByAddr(src)
+	if !ok {
+		return nil, fmt.Errorf("no node found for src: %s", src)
+	}
+	dstID, ok := t.getIGPRouterIDByAddr(dst)
+	if !ok {
+		return nil, fmt.Errorf("no node found for dst: %s", dst)
+	}
+
+	pID := srcID + ":" + dstID
+	_, ok = t.GetPath(pID)
+	if !ok {
+		t.Lock()
+		t.FindAllPathsForSrcDst(srcID, dstID)
+		t.Unlock()
+	}
+
+	hops, err := t.eroHops(primaryERO)
+	if err != nil {
+		return nil, err
+	}
+	paths, _ := t.GetPath(pID)
+	primaries := make([]*Path, 0)
+	for _, path := range paths {
+		if t.followsERO(path, hops) {
+			primaries = append(primaries, path)
+		}
+	}
+	if len(primaries) == 0 {
+		return nil, fmt.Errorf("explicit primary path from %s to %s does not match the topology", src, dst)
+	}
+	secondary := t.findBestDisjointPath(int(bw), primaries, nodeDisjoint, msd)
+	if secondary == nil {
+		return nil, fmt.Errorf("no path from %s to %s disjoint from the explicit primary path", src, dst)
+	}
+	secondaryLSP, err := t.createSRLSP(uint32(bw), secondary)
+	if err != nil {
+		return nil, err
+	}
+	return secondaryLSP.EROList, nil
+}
+
+// followsERO is true when traffic steered by the hops may take the path,
+// an adjacency is the very next link while a node and finally the
+// destination are reached over any of the IGP shortest paths
+func (t *TopoView) followsERO(path *Path, hops []eroHop) bool {
+	defer t.RUnlock()
+	t.RLock()
+	pos := 0
+	cur := path.Src
+	for _, hop := range append(hops, eroHop{node: path.Dst}) {
+		if hop.link != nil {
+			if pos >= len(path.Links) || path.Links[pos].key() != hop.link.key() || path.Links[pos].LocalNode != hop.link.LocalNode {
+				return false
+			}
+			cur = path.Links[pos].RemoteNode
+			pos++
+			continue
+		}
+		if hop.node == cur {
+			continue
+		}
+		dist, _ := t.igpShortestPaths(cur)
+		cost := 0
+		for pos < len(path.Links) && cur != hop.node {
+			cost += int(path.Links[pos].IGPMetric)
+			cur = path.Links[pos].RemoteNode
+			pos++
+		}
+		if cur != hop.node || cost != dist[hop.node] {
+			return false
+		}
+	}
+	return pos == len(path.Links)
+}
+
+// eroHop is a node or a link an explicit path goes through
+type eroHop struct {
+	node string
+	link *Link
+}
+
+// eroHops resolves the NAIs of the SR-ERO into nodes and links of the
+// topology, SIDs alone can not be resolved as labels depend on the SRGB
+func (t *TopoView) eroHops(ero []pcep.SREROSub) ([]eroHop, error) {
+	hops := make([]eroHop, 0, len(ero))
+	for i, sub := range ero {
+		switch sub.NT {
+		case 1, 2:
+			addr := sub.IPv4NodeID
+			if sub.NT == 2 {
+				addr = sub.IPv6NodeID
+			}
+			node, ok := t.getIGPRouterIDByAddr(addr)
+			if !ok {
+				return nil, fmt.Errorf("no node found for ERO subobject %d: %s", i, addr)
+			}
+			hops = append(hops, eroHop{node: node})
+		case 3, 4, 5:
+			link := t.getLinkBySREROSub(&sub)
+			if link == nil {
+				return nil, fmt.Errorf("no link found for ERO subobject %d", i)
+			}
+			hops = append(hops, eroHop{link: link})
+		default:
+			return nil, fmt.Errorf("ERO subobject %d has no NAI to find it in the topology", i)
+		}
+	}
+	return hops, nil
+}
+
+// getLinkBySREROSub returns the link of the adjacency NAI
+func (t *TopoView) getLinkBySREROSub(sub *pcep.SREROSub) *Link {
+	defer t.RUnlock()
+	t.RLock()
+	for _, link := range t.LinksByIGPRouteID {
+		switch sub.NT {
+		case 3:
+			if len(sub.IPv4Adjacency) == 2 && link.IntIP == sub.IPv4Adjacency[0] && link.NeighbourIP == sub.IPv4Adjacency[1] {
+				return link
+			}
+		case 4:
+			if len(sub.IPv6Adjacency) == 2 && link.IntIPv6 == sub.IPv6Adjacency[0] && link.NeighbourIPv6 == sub.IPv6Adjacency[1] {
+				return link
+			}
+		case 5:
+			local, ok := t.NodesByIGPRouteID[link.LocalNode]
+			if ok && local.RouterID == sub.UnnuV4Adj.LocalNodeID && link.LocalLinkID == sub.UnnuV4Adj.LocalInterfaceID {
+				return link
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package controller
+
+import (
+	"fmt"
+	"gopcep/pcep"
+	"testing"
+)
+
+// test topology, IGP metrics in brackets
+//
+//	A --(10)-- B --(10)-- D
+//	 \         |         /
+//	 (15)    (10)     (15)
+//	   \       |       /
+//	    `----- C -----'
+const (
+	nodeA = "0000.0000.0001"
+	nodeB = "0000.0000.0002"
+	nodeC = "0000.0000.0003"
+	nodeD = "0000.0000.0004"
+)
+
+// testLink returns both directions of a link between the nodes, n is
+// used for the interface addresses and adjacency SIDs
+func testLink(local, remote string, n int, metric uint32) (*Link, *Link) {
+	localIP := fmt.Sprintf("192.168.%d.1", n)
+	remoteIP := fmt.Sprintf("192.168.%d.2", n)
+	return &Link{
+		LocalNode:      local,
+		RemoteNode:     remote,
+		IntIP:          localIP,
+		NeighbourIP:    remoteIP,
+		IGPMetric:      metric,
+		UnreservedBW:   100,
+		SRAdjacencySID: uint32(24000 + n*10 + 1),
+	}, &Link{
+		LocalNode:      remote,
+		RemoteNode:     local,
+		IntIP:          remoteIP,
+		NeighbourIP:    localIP,
+		IGPMetric:      metric,
+		UnreservedBW:   100,
+		SRAdjacencySID: uint32(24000 + n*10 + 2),
+	}
+}
+
+// testPath returns the path over the links
+func testPath(links ...*Link) *Path {
+	path := &Path{
+		Src:   links[0].LocalNode,
+		Dst:   links[len(links)-1].RemoteNode,
+		Links: links,
+	}
+	for _, link := range links {
+		path.Cost += int(link.IGPMetric)
+	}
+	return path
+}
+
+type testTopo struct {
+	*TopoView
+	ab, ba, bd, db, ac, ca, cd, dc, bc, cb *Link
+	// all paths from A to D
+	abd, acd, abcd, acbd *Path
+}
+
+// newTestTopo builds the test topology, node N has the loopback 10.0.0.N
+// with the node SID index N and the SRGB starting at 16000
+func newTestTopo() *testTopo {
+	tv := NewTopoView()
+	for i, id := range []string{nodeA, nodeB, nodeC, nodeD} {
+		tv.NodesByIGPRouteID[id] = &Node{
+			IGPRouteID: id,
+			RouterID:   fmt.Sprintf("10.0.0.%d", i+1),
+			SRGB:       []*SRRange{{Start: 16000, Size: 8000}},
+		}
+		tv.PrefixByIGPRouteID[id] = &Prefix{
+			Prefix:      fmt.Sprintf("10.0.0.%d/32", i+1),
+			SRPrefixSID: uint32(i + 1),
+			LocalNode:   id,
+		}
+	}
+	topo := &testTopo{TopoView: tv}
+	topo.ab, topo.ba = testLink(nodeA, nodeB, 1, 10)
+	topo.bd, topo.db = testLink(nodeB, nodeD, 2, 10)
+	topo.ac, topo.ca = testLink(nodeA, nodeC, 3, 15)
+	topo.cd, topo.dc = testLink(nodeC, nodeD, 4, 15)
+	topo.bc, topo.cb = testLink(nodeB, nodeC, 5, 10)
+	tv.LinksByIGPRouteID = []*Link{
+		topo.ab, topo.ba, topo.bd, topo.db, topo.ac,
+		topo.ca, topo.cd, topo.dc, topo.bc, topo.cb,
+	}
+
+	topo.abd = testPath(topo.ab, topo.bd)
+	topo.acd = testPath(topo.ac, topo.cd)
+	topo.abcd = testPath(topo.ab, topo.bc, topo.cd)
+	topo.acbd = testPath(topo.ac, topo.cb, topo.bd)
+	tv.StorePath(nodeA+":"+nodeD, []*Path{topo.abd, topo.acd, topo.abcd, topo.acbd})
+	return topo
+}
+
+func TestPathDisjoint(t *testing.T) {
+	topo := newTestTopo()
+	// a parallel link between B and D and a node E between A and B
+	bd2, _ := testLink(nodeB, nodeD, 6, 10)
+	ae, _ := testLink(nodeA, "0000.0000.0005", 7, 5)
+	eb, _ := testLink("0000.0000.0005", nodeB, 8, 5)
+
+	for _, c := range []struct {
+		name         string
+		p, other     *Path
+		nodeDisjoint bool
+		disjoint     bool
+	}{
+		{name: "no shared links", p: topo.abd, other: topo.acd, disjoint: true},
+		{name: "no shared nodes", p: topo.abd, other: topo.acd, nodeDisjoint: true, disjoint: true},
+		{name: "shared link", p: topo.abd, other: topo.abcd},
+		{name: "link shared in the other direction", p: topo.abcd, other: topo.acbd},
+		{name: "shared transit node only", p: topo.abd, other: testPath(ae, eb, bd2), disjoint: true},
+		{name: "shared transit node", p: topo.abd, other: testPath(ae, eb, bd2), nodeDisjoint: true},
+	} {
+		if c.p.disjoint(c.other, c.nodeDisjoint) != c.disjoint {
+			t.Errorf("%s: expected disjoint %t", c.name, c.disjoint)
+		}
+	}
+}
+
+func TestFindBestDisjointPath(t *testing.T) {
+	topo := newTestTopo()
+	for _, c := range []struct {
+		name         string
+		bw           int
+		primaries    []*Path
+		nodeDisjoint bool
+		msd          uint8
+		expected     *Path
+	}{
+		{name: "no primary", primaries: []*Path{}},
+		{name: "link disjoint", primaries: []*Path{topo.abd}, expected: topo.acd},
+		{name: "node disjoint", primaries: []*Path{topo.abd}, nodeDisjoint: true, expected: topo.acd},
+		{name: "lowest cost", primaries: []*Path{topo.acd}, expected: topo.abd},
+		{name: "every path shares a link", primaries: []*Path{topo.abcd}},
+		{name: "disjoint from all primaries", primaries: []*Path{topo.abd, topo.acd}},
+		{name: "not enough bandwidth", bw: 100, primaries: []*Path{topo.abd}},
+		// C has to be pinned as A reaches D over B
+		{name: "deeper than MSD", primaries: []*Path{topo.abd}, msd: 1},
+		{name: "within MSD", primaries: []*Path{topo.abd}, msd: 2, expected: topo.acd},
+	} {
+		path := topo.findBestDisjointPath(c.bw, c.primaries, c.nodeDisjoint, c.msd)
+		if path != c.expected {
+			t.Errorf("%s: expected path %v got %v", c.name, c.expected, path)
+		}
+	}
+}
+
+func TestFollowsERO(t *testing.T) {
+	topo := newTestTopo()
+	for _, c := range []struct {
+		name     string
+		path     *Path
+		hops     []eroHop
+		expected bool
+	}{
+		{name: "shortest path to destination", path: topo.abd, hops: []eroHop{{node: nodeD}}, expected: true},
+		{name: "longer path to destination", path: topo.acd, hops: []eroHop{{node: nodeD}}},
+		{name: "over node", path: topo.acd, hops: []eroHop{{node: nodeC}, {node: nodeD}}, expected: true},
+		{name: "over node to destination by IGP", path: topo.acd, hops: []eroHop{{node: nodeC}}, expected: true},
+		{name: "longer path to node", path: topo.abcd, hops: []eroHop{{node: nodeC}, {node: nodeD}}},
+		{name: "source node", path: topo.abd, hops: []eroHop{{node: nodeA}, {node: nodeD}}, expected: true},
+		{name: "adjacency", path: topo.abcd, hops: []eroHop{{link: topo.ab}, {link: topo.bc}, {node: nodeD}}, expected: true},
+		{name: "adjacency then IGP", path: topo.abd, hops: []eroHop{{link: topo.ab}, {node: nodeD}}, expected: true},
+		{name: "adjacency then longer path", path: topo.abcd, hops: []eroHop{{link: topo.ab}, {node: nodeD}}},
+		{name: "adjacency in the other direction", path: topo.abd, hops: []eroHop{{link: topo.ba}, {node: nodeD}}},
+		{name: "adjacency not next", path: topo.abd, hops: []eroHop{{link: topo.bd}}},
+		{name: "nodes out of order", path: topo.abcd, hops: []eroHop{{node: nodeC}, {node: nodeB}, {node: nodeD}}},
+	} {
+		if topo.followsERO(c.path, c.hops) != c.expected {
+			t.Errorf("%s: expected follows %t", c.name, c.expected)
+		}
+	}
+}
+
+func TestComputeProtectingSRPath(t *testing.T) {
+	topo := newTestTopo()
+	node := func(addr string) pcep.SREROSub {
+		return pcep.SREROSub{NT: 1, IPv4NodeID: addr}
+	}
+	for _, c := range []struct {
+		name     string
+		primary  []pcep.SREROSub
+		expected []string
+	}{
+		{
+			name:     "node SID of the destination",
+			primary:  []pcep.SREROSub{node("10.0.0.4")},
+			expected: []string{"10.0.0.3", "10.0.0.4"},
+		},
+		{
+			name:     "node SIDs over C",
+			primary:  []pcep.SREROSub{node("10.0.0.3"), node("10.0.0.4")},
+			expected: []string{"10.0.0.4"},
+		},
+		{
+			name: "adjacency SID",
+			primary: []pcep.SREROSub{
+				{NT: 3, IPv4Adjacency: []string{"192.168.1.1", "192.168.1.2"}},
+				node("10.0.0.4"),
+			},
+			expected: []string{"10.0.0.3", "10.0.0.4"},
+		},
+		{
+			name:    "no disjoint path",
+			primary: []pcep.SREROSub{node("10.0.0.2"), node("10.0.0.3"), node("10.0.0.4")},
+		},
+		{
+			name:    "unknown node",
+			primary: []pcep.SREROSub{node("10.0.0.9"), node("10.0.0.4")},
+		},
+		{
+			name:    "unknown adjacency",
+			primary: []pcep.SREROSub{{NT: 3, IPv4Adjacency: []string{"192.168.9.1", "192.168.9.2"}}},
+		},
+		{
+			name:    "SID without NAI",
+			primary: []pcep.SREROSub{{NT: 0, SID: 16004}},
+		},
+	} {
+		ero, err := topo.computeProtectingSRPath(0, "10.0.0.1", "10.0.0.4", c.primary, false, 0)
+		if c.expected == nil {
+			if err == nil {
+				t.Errorf("%s: expected error got ERO %v", c.name, ero)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: must not see any errors, instead got: %s", c.name, err.Error())
+			continue
+		}
+		if len(ero) != len(c.expected) {
+			t.Errorf("%s: expected ERO over %v got %v", c.name, c.expected, ero)
+			continue
+		}
+		for i, addr := range c.expected {
+			if ero[i].IPv4NodeID != addr {
+				t.Errorf("%s: expected ERO over %v got %v", c.name, c.expected, ero)
+			}
+		}
+	}
+}
+
+func TestUpdateProtectionExplicitPrimary(t *testing.T) {
+	c := newTestController(t)
+	c.TopoView = newTestTopo().TopoView
+
+	primary := []pcep.SREROSub{{NT: 1, IPv4NodeID: "10.0.0.4", SID: 16004, MBit: true}}
+	lsp := &pcep.SRLSP{
+		Name:      "lsp1",
+		Src:       "10.0.0.1",
+		Dst:       "10.0.0.4",
+		Protected: true,
+		EROList:   primary,
+	}
+	err := c.CreateUpdSRLSP(lsp)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if len(lsp.EROList) != 1 || lsp.EROList[0].IPv4NodeID != "10.0.0.4" {
+		t.Errorf("expected explicit primary ERO to be kept got %v", lsp.EROList)
+	}
+	secondary, ok := c.GetLSP(secondaryLSPName("lsp1"))
+	if !ok {
+		t.Fatal("expected protecting LSP to be stored")
+	}
+	if len(secondary.EROList) != 2 || secondary.EROList[0].IPv4NodeID != "10.0.0.3" {
+		t.Errorf("expected protecting ERO over C got %v", secondary.EROList)
+	}
+	if len(lsp.Associations) != 1 || len(secondary.Associations) != 1 || lsp.Associations[0].ID != secondary.Associations[0].ID {
+		t.Errorf("expected both LSPs in the same path protection association got %v and %v", lsp.Associations, secondary.Associations)
+	}
+}
@@ -1,6 +1,10 @@
 package controller
 
-import "sync"
+import (
+	"fmt"
+	"gopcep/pcep"
+	"sync"
+)
 
 type Router struct {
 	Name              string
@@ -12,6 +16,33 @@ type Router struct {
 	BGPLSPeerCfg      BGPLSPeer
 	IncludeInFullMesh bool
 	PCEPSessionSrcIP  string
+	// overrides of the PCEP session characteristics from config
+	PCEPOpenParams *pcep.OpenParams
+	// PCEPS policy, empty means the one from config is used
+	PCEPTLSPolicy pcep.TLSPolicy
+	// TCP-MD5 or TCP-AO key for the PCEP session
+	PCEPAuth *pcep.AuthKey
+}
+
+// ValidateRouter rejects router settings which can not be applied
+func ValidateRouter(router *Router) error {
+	if router.PCEPTLSPolicy != "" && !router.PCEPTLSPolicy.Valid() {
+		return fmt.Errorf("unknown PCEPS policy %q must be one of %s, %s or %s",
+			router.PCEPTLSPolicy, pcep.TLSDisable, pcep.TLSPrefer, pcep.TLSRequire)
+	}
+	return nil
+}
+
+// Redacted returns a copy of the router which is safe to hand out over the APIs,
+// the TCP auth key secret is only kept in storage and on the socket
+func (router *Router) Redacted() *Router {
+	r := *router
+	if r.PCEPAuth != nil {
+		auth := *r.PCEPAuth
+		auth.Key = ""
+		r.PCEPAuth = &auth
+	}
+	return &r
 }
 
 type BGPLSPeer struct {
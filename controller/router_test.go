@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"testing"
+
+	"gopcep/pcep"
+)
+
+// expectAuthUpdate checks the PCEP listener is told to resync the keys
+func expectAuthUpdate(t *testing.T, c *Controller) {
+	t.Helper()
+	select {
+	case <-c.PeerAuthUpdates():
+	default:
+		t.Error("expected tcp auth keys update")
+	}
+}
+
+func TestRouterPeerAuth(t *testing.T) {
+	c := newTestController(t)
+
+	// router added
+	r := &Router{
+		Name:             "pcc1",
+		PCEPSessionSrcIP: "10.0.0.1",
+		PCEPAuth:         &pcep.AuthKey{Type: pcep.AuthMD5, Key: "secret"},
+	}
+	err := c.CreateUpdRouter(r)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	expectAuthUpdate(t, c)
+	keys := c.GetPeerAuth()
+	if k, ok := keys["10.0.0.1"]; !ok || k.Key != "secret" {
+		t.Errorf("expected md5 key for 10.0.0.1 got %v", keys)
+	}
+
+	// router without a key or session address has no key installed
+	err = c.CreateUpdRouter(&Router{Name: "pcc2", PCEPSessionSrcIP: "10.0.0.2"})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	expectAuthUpdate(t, c)
+	if len(c.GetPeerAuth()) != 1 {
+		t.Errorf("expected a single key got %v", c.GetPeerAuth())
+	}
+
+	// router updated to TCP-AO
+	r = &Router{
+		Name:             "pcc1",
+		ID:               r.ID,
+		PCEPSessionSrcIP: "10.0.0.1",
+		PCEPAuth:         &pcep.AuthKey{Type: pcep.AuthAO, Key: "new secret", SendID: 1, RecvID: 2},
+	}
+	err = c.CreateUpdRouter(r)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	expectAuthUpdate(t, c)
+	k := c.GetPeerAuth()["10.0.0.1"]
+	if k == nil || k.Type != pcep.AuthAO || k.Key != "new secret" {
+		t.Errorf("expected ao key for 10.0.0.1 got %v", k)
+	}
+
+	// router deleted
+	err = c.DeleteRouter(r.ID)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	expectAuthUpdate(t, c)
+	if _, ok := c.GetPeerAuth()["10.0.0.1"]; ok {
+		t.Error("expected key for 10.0.0.1 to be removed")
+	}
+}
+
+func TestRouterRedacted(t *testing.T) {
+	r := &Router{
+		Name:     "pcc1",
+		PCEPAuth: &pcep.AuthKey{Type: pcep.AuthAO, Key: "secret", SendID: 1},
+	}
+	redacted := r.Redacted()
+	if redacted.PCEPAuth.Key != "" {
+		t.Errorf("expected the key to be redacted got %q", redacted.PCEPAuth.Key)
+	}
+	if redacted.PCEPAuth.Type != pcep.AuthAO || redacted.PCEPAuth.SendID != 1 {
+		t.Errorf("expected the rest of the auth settings to be kept got %+v", redacted.PCEPAuth)
+	}
+	if r.PCEPAuth.Key != "secret" {
+		t.Errorf("the stored router must keep its key got %q", r.PCEPAuth.Key)
+	}
+	if (&Router{Name: "pcc2"}).Redacted().PCEPAuth != nil {
+		t.Error("expected no auth settings for router without a key")
+	}
+}
+
+func TestValidateRouter(t *testing.T) {
+	for _, c := range []struct {
+		policy pcep.TLSPolicy
+		valid  bool
+	}{
+		{policy: "", valid: true},
+		{policy: pcep.TLSDisable, valid: true},
+		{policy: pcep.TLSPrefer, valid: true},
+		{policy: pcep.TLSRequire, valid: true},
+		{policy: "required", valid: false},
+	} {
+		err := ValidateRouter(&Router{PCEPTLSPolicy: c.policy})
+		if (err == nil) != c.valid {
+			t.Errorf("policy %q must be valid: %t got %v", c.policy, c.valid, err)
+		}
+	}
+
+	ctr := newTestController(t)
+	err := ctr.CreateUpdRouter(&Router{Name: "pcc1", PCEPTLSPolicy: "always"})
+	if err == nil {
+		t.Error("expected router with unknown PCEPS policy to be rejected")
+	}
+	if len(ctr.GetClients()) != 0 {
+		t.Errorf("expected no router to be stored got %v", ctr.GetClients())
+	}
+}
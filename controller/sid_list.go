@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"fmt"
+	"gopcep/pcep"
+	"math"
+	"strings"
+)
+
+// spf is the result of SPF run from a node
+type spf struct {
+	dist  map[string]int
+	count map[string]int
+}
+
+// igpShortestPaths returns the distance to every node along with the number of
+// equal cost paths to it, the results are cached per node until the links
+// change and must not be modified, the caller holds the lock
+func (t *TopoView) igpShortestPaths(src string) (map[string]int, map[string]int) {
+	if cached, ok := t.spfCache.Load(src); ok {
+		r := cached.(*spf)
+		return r.dist, r.count
+	}
+	dist, count := t.runSPF(src)
+	t.spfCache.Store(src, &spf{dist: dist, count: count})
+	return dist, count
+}
+
+// resetSPFCache drops the SPF results once the links change,
+// the caller holds the write lock
+func (t *TopoView) resetSPFCache() {
+	t.spfCache.Range(func(key, value interface{}) bool {
+		t.spfCache.Delete(key)
+		return true
+	})
+}
+
+// runSPF runs SPF from the node over IGP metrics, parallel
+// links count as separate paths
+func (t *TopoView) runSPF(src string) (map[string]int, map[string]int) {
+	dist := map[string]int{src: 0}
+	count := map[string]int{src: 1}
+	done := make(map[string]bool)
+	for {
+		node := ""
+		best := math.MaxInt32
+		for n, d := range dist {
+			if !done[n] && d < best {
+				node, best = n, d
+			}
+		}
+		if node == "" {
+			return dist, count
+		}
+		done[node] = true
+		for _, link := range t.LinksByIGPRouteID {
+			if link.LocalNode != node || done[link.RemoteNode] {
+				continue
+			}
+			d := best + int(link.IGPMetric)
+			old, ok := dist[link.RemoteNode]
+			switch {
+			case !ok || d < old:
+				dist[link.RemoteNode] = d
+				count[link.RemoteNode] = count[node]
+			case d == old:
+				count[link.RemoteNode] += count[node]
+			}
+		}
+	}
+}
+
+// compressSRPath returns the shortest SID list steering traffic over the path
+// https://tools.ietf.org/html/rfc8402#section-3.1
+// a run of hops is replaced by the node SID of its last node when it is the
+// only IGP shortest path to it, hops which the IGP would not take or which
+// have ECMP alternatives are pinned with adjacency SIDs, the caller holds the lock
+func (t *TopoView) compressSRPath(path *Path, v6 bool) ([]pcep.SREROSub, error) {
+	if len(path.Links) == 0 {
+		return nil, fmt.Errorf("no links found in path")
+	}
+	nodes := []string{path.Src}
+	costs := []int{0}
+	for i, link := range path.Links {
+		nodes = append(nodes, link.RemoteNode)
+		costs = append(costs, costs[i]+int(link.IGPMetric))
+	}
+
+	eroList := make([]pcep.SREROSub, 0)
+	for i := 0; i < len(path.Links); {
+		dist, count := t.igpShortestPaths(nodes[i])
+		// the farthest node the IGP reaches over this very path and nothing else
+		j := i
+		for k := i + 1; k < len(nodes); k++ {
+			d, ok := dist[nodes[k]]
+			if ok && d == costs[k]-costs[i] && count[nodes[k]] == 1 {
+				j = k
+			}
+		}
+		if j == i {
+			ero, err := t.adjSRERO(path.Links[i], v6)
+			if err != nil {
+				return nil, err
+			}
+			eroList = append(eroList, *ero)
+			i++
+			continue
+		}
+		// the label comes from the SRGB of the node where the segment starts
+		ero, err := t.nodeSRERO(nodes[i], nodes[j], v6)
+		if err != nil {
+			return nil, err
+		}
+		eroList = append(eroList, *ero)
+		i = j
+	}
+	return eroList, nil
+}
+
+// nodeSRERO is the node SID subobject of the node with
+// the label taken from the SRGB of the upstream node
+func (t *TopoView) nodeSRERO(upstream, routerID string, v6 bool) (*pcep.SREROSub, error) {
+	SID, err := t.getSIDByIGPRouterID(upstream, routerID, v6)
+	if err != nil {
+		return nil, err
+	}
+	nodePrefix, ok := t.getNodePrefix(routerID, v6)
+	if !ok {
+		return nil, fmt.Errorf("node prefix not found for IGPID %s", routerID)
+	}
+	if v6 {
+		return &pcep.SREROSub{
+			MBit:       true,
+			NT:         2,
+			IPv6NodeID: strings.Split(nodePrefix.Prefix, "/")[0],
+			SID:        SID,
+		}, nil
+	}
+	return &pcep.SREROSub{
+		MBit:       true,
+		NT:         1,
+		IPv4NodeID: strings.Split(nodePrefix.Prefix, "/")[0],
+		SID:        SID,
+	}, nil
+}
+
+// adjSRERO is the adjacency SID subobject of the link
+func (t *TopoView) adjSRERO(link *Link, v6 bool) (*pcep.SREROSub, error) {
+	if link.SRAdjacencySID == 0 && !link.SRAdjacencyIndex {
+		return nil, fmt.Errorf("no adjacency SID found for link from %s to %s", link.LocalNode, link.RemoteNode)
+	}
+	label, err := t.adjLabel(link)
+	if err != nil {
+		return nil, err
+	}
+	if link.unnumbered() {
+		adj, err := t.getUnnuAdj(link)
+		if err != nil {
+			return nil, err
+		}
+		return &pcep.SREROSub{
+			MBit:      true,
+			NT:        5,
+			SID:       label,
+			UnnuV4Adj: *adj,
+		}, nil
+	}
+	if v6 {
+		return &pcep.SREROSub{
+			MBit: true,
+			NT:   4,
+			SID:  label,
+			IPv6Adjacency: []string{
+				0: link.IntIPv6,
+				1: link.NeighbourIPv6,
+			},
+		}, nil
+	}
+	return &pcep.SREROSub{
+		MBit: true,
+		NT:   3,
+		SID:  label,
+		IPv4Adjacency: []string{
+			0: link.IntIP,
+			1: link.NeighbourIP,
+		},
+	}, nil
+}
+
+// adjLabel is the label of the adjacency SID, an index is taken
+// from the SRLB of the node the link belongs to
+// https://www.rfc-editor.org/rfc/rfc9085#section-2.2.1
+func (t *TopoView) adjLabel(link *Link) (uint32, error) {
+	if !link.SRAdjacencyIndex {
+		return link.SRAdjacencySID, nil
+	}
+	node, ok := t.NodesByIGPRouteID[link.LocalNode]
+	if !ok {
+		return 0, fmt.Errorf("no node found for id: %s", link.LocalNode)
+	}
+	label, err := srLabel(node.SRLB, link.SRAdjacencySID)
+	if err != nil {
+		return 0, fmt.Errorf("no label for adjacency from %s to %s in SRLB: %s", link.LocalNode, link.RemoteNode, err.Error())
+	}
+	return label, nil
+}
+
+// fitsMSD is true when the compressed SID list of the path
+// is not deeper than the MSD, 0 MSD means no limit
+func (t *TopoView) fitsMSD(path *Path, msd uint8) bool {
+	defer t.RUnlock()
+	t.RLock()
+	_, v4 := t.PrefixByIGPRouteID[path.Src]
+	eroList, err := t.compressSRPath(path, !v4)
+	if err != nil {
+		return false
+	}
+	return msd == 0 || len(eroList) <= int(msd)
+}
+
+// findBestSRPath returns the lowest cost path with enough bandwidth
+// on all of its links which can be signalled within the MSD
+func (t *TopoView) findBestSRPath(bwNeeded int, src, dst string, msd uint8) *Path {
+	var bestPath *Path
+	paths, _ := t.GetPath(src + ":" + dst)
+	for _, path := range paths {
+		if bestPath != nil && path.Cost >= bestPath.Cost {
+			continue
+		}
+		bwAvailiable := true
+		for _, link := range path.Links {
+			if link.UnreservedBW <= float32(bwNeeded) {
+				bwAvailiable = false
+				break
+			}
+		}
+		if bwAvailiable && t.fitsMSD(path, msd) {
+			bestPath = path
+		}
+	}
+	return bestPath
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"errors"
+	"gopcep/pcep"
+	"testing"
+)
+
+// sid is the type and label of an SR-ERO subobject
+type sid struct {
+	nt    uint8
+	label uint32
+}
+
+func TestCompressSRPath(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		// changes the test topology before the path is compressed
+		setup    func(topo *testTopo)
+		path     func(topo *testTopo) *Path
+		expected []sid
+	}{
+		{
+			name:     "shortest path is a single node SID",
+			path:     func(topo *testTopo) *Path { return topo.abd },
+			expected: []sid{{1, 16004}},
+		},
+		{
+			name:     "node SID of the node the IGP would not reach the destination over",
+			path:     func(topo *testTopo) *Path { return topo.acd },
+			expected: []sid{{1, 16003}, {1, 16004}},
+		},
+		{
+			name:     "node SID per segment",
+			path:     func(topo *testTopo) *Path { return topo.abcd },
+			expected: []sid{{1, 16002}, {1, 16003}, {1, 16004}},
+		},
+		{
+			name: "ECMP to the destination",
+			setup: func(topo *testTopo) {
+				// A-C-D costs as much as A-B-D
+				topo.cd.IGPMetric, topo.dc.IGPMetric = 5, 5
+			},
+			path:     func(topo *testTopo) *Path { return topo.abd },
+			expected: []sid{{1, 16002}, {1, 16004}},
+		},
+		{
+			name: "link the IGP would not take",
+			setup: func(topo *testTopo) {
+				// C is closer over B
+				topo.ac.IGPMetric, topo.ca.IGPMetric = 25, 25
+			},
+			path: func(topo *testTopo) *Path { return topo.acd },
+			// adjacency SID of A to C
+			expected: []sid{{3, 24031}, {1, 16004}},
+		},
+		{
+			name: "parallel links",
+			setup: func(topo *testTopo) {
+				bd2, db2 := testLink(nodeB, nodeD, 6, 10)
+				topo.LinksByIGPRouteID = append(topo.LinksByIGPRouteID, bd2, db2)
+			},
+			path: func(topo *testTopo) *Path { return topo.abd },
+			// adjacency SID of B to D
+			expected: []sid{{1, 16002}, {3, 24021}},
+		},
+	} {
+		topo := newTestTopo()
+		if c.setup != nil {
+			c.setup(topo)
+		}
+		ero, err := topo.compressSRPath(c.path(topo), false)
+		if err != nil {
+			t.Errorf("%s: must not see any errors, instead got: %s", c.name, err.Error())
+			continue
+		}
+		got := make([]sid, 0, len(ero))
+		for _, sub := range ero {
+			got = append(got, sid{sub.NT, sub.SID})
+		}
+		if len(got) != len(c.expected) {
+			t.Errorf("%s: expected SID list %v got %v", c.name, c.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.expected[i] {
+				t.Errorf("%s: expected SID list %v got %v", c.name, c.expected, got)
+				break
+			}
+		}
+	}
+
+	// pinning a link requires its adjacency SID
+	topo := newTestTopo()
+	topo.ac.IGPMetric, topo.ca.IGPMetric = 25, 25
+	topo.ac.SRAdjacencySID = 0
+	_, err := topo.compressSRPath(topo.acd, false)
+	if err == nil {
+		t.Error("expected error for a link without adjacency SID")
+	}
+}
+
+func TestFindBestSRPathMSD(t *testing.T) {
+	ecmp := func(topo *testTopo) {
+		topo.cd.IGPMetric, topo.dc.IGPMetric = 5, 5
+	}
+	for _, c := range []struct {
+		name  string
+		setup func(topo *testTopo)
+		bw    int
+		msd   uint8
+		// the SID list of the shortest path fits into the MSD
+		fits     bool
+		expected func(topo *testTopo) *Path
+	}{
+		{
+			name:     "no MSD limit",
+			fits:     true,
+			expected: func(topo *testTopo) *Path { return topo.abd },
+		},
+		{
+			name:     "single SID fits",
+			msd:      1,
+			fits:     true,
+			expected: func(topo *testTopo) *Path { return topo.abd },
+		},
+		{
+			name: "no path within MSD",
+			msd:  1,
+			// every path needs at least two SIDs
+			setup:    ecmp,
+			expected: func(topo *testTopo) *Path { return nil },
+		},
+		{
+			name:     "path within MSD",
+			msd:      2,
+			setup:    ecmp,
+			fits:     true,
+			expected: func(topo *testTopo) *Path { return topo.abd },
+		},
+		{
+			name:     "not enough bandwidth",
+			bw:       100,
+			fits:     true,
+			expected: func(topo *testTopo) *Path { return nil },
+		},
+	} {
+		topo := newTestTopo()
+		if c.setup != nil {
+			c.setup(topo)
+		}
+		if topo.fitsMSD(topo.abd, c.msd) != c.fits {
+			t.Errorf("%s: expected shortest path fitting into MSD %d: %t", c.name, c.msd, c.fits)
+		}
+		path := topo.findBestSRPath(c.bw, nodeA, nodeD, c.msd)
+		if path != c.expected(topo) {
+			t.Errorf("%s: expected path %v got %v", c.name, c.expected(topo), path)
+		}
+	}
+}
+
+func TestComputeSRPathNoPath(t *testing.T) {
+	topo := newTestTopo()
+	topo.cd.IGPMetric, topo.dc.IGPMetric = 5, 5
+	_, err := topo.computeSRPath(0, "10.0.0.1", "10.0.0.4", 1)
+	var noPath *pcep.NoPathError
+	if !errors.As(err, &noPath) {
+		t.Errorf("expected no path error got %v", err)
+	}
+
+	reply, err := topo.computeSRPath(100, "10.0.0.1", "10.0.0.4", 0)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if len(reply.EROList) != 0 || !reply.BWUnsatisfied {
+		t.Errorf("expected NO-PATH with unsatisfied bandwidth got %v", reply)
+	}
+
+	reply, err = topo.computeSRPath(0, "10.0.0.1", "10.0.0.9", 0)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if reply.NoPathVector != pcep.NoPathUnknownDst {
+		t.Errorf("expected unknown destination got %v", reply)
+	}
+}
+
+func TestIGPShortestPathsCache(t *testing.T) {
+	topo := newTestTopo()
+	dist, count := topo.igpShortestPaths(nodeA)
+	if dist[nodeD] != 20 || count[nodeD] != 1 {
+		t.Fatalf("expected single path to D at 20 got %d over %d", dist[nodeD], count[nodeD])
+	}
+	// results are kept until the links change
+	topo.bd.IGPMetric = 30
+	dist, _ = topo.igpShortestPaths(nodeA)
+	if dist[nodeD] != 20 {
+		t.Errorf("expected cached distance 20 got %d", dist[nodeD])
+	}
+	topo.resetSPFCache()
+	dist, count = topo.igpShortestPaths(nodeA)
+	if dist[nodeD] != 30 || count[nodeD] != 1 {
+		t.Errorf("expected single path to D at 30 got %d over %d", dist[nodeD], count[nodeD])
+	}
+}
@@ -0,0 +1,304 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopcep/pcep"
+	"net"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+//CandidatePath of an SR Policy, it is signalled as an SR LSP and the
+// PCC picks the valid one with the highest preference
+type CandidatePath struct {
+	Name          string
+	Preference    uint32
+	Discriminator uint32
+	BW            uint32
+	// when empty the path is computed over the TopoView
+	EROList []pcep.SREROSub
+}
+
+//SRPolicy identified by its head-end, color and endpoint
+// https://tools.ietf.org/html/draft-ietf-pce-segment-routing-policy-cp
+type SRPolicy struct {
+	Name     string
+	HeadEnd  string
+	Color    uint32
+	Endpoint string
+	// SR Policy association ID shared by all candidate paths
+	AssociationID  uint16
+	CandidatePaths []*CandidatePath
+}
+
+// lspName is the name of the SR LSP which signals the candidate path
+func (p *SRPolicy) lspName(cp *CandidatePath) string {
+	return p.Name + "-" + cp.Name
+}
+
+type SRPolicies struct {
+	sync.Map
+}
+
+func (p *SRPolicies) StoreSRPolicy(key string, value *SRPolicy) {
+	p.Store(key, value)
+}
+
+func (p *SRPolicies) GetSRPolicy(key string) (*SRPolicy, bool) {
+	v, ok := p.Load(key)
+	if ok {
+		return v.(*SRPolicy), ok
+	}
+	return nil, ok
+}
+
+func (p *SRPolicies) DelSRPolicy(key string) {
+	p.Delete(key)
+}
+
+func (p *SRPolicies) RangeSRPolicies(f func(key interface{}, value interface{}) bool) {
+	p.Range(f)
+}
+
+// LoadSRPolicies retrive all SR Policies stored in Bolt DB
+func (c *Controller) LoadSRPolicies() error {
+	return c.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("srpolicies"))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var p SRPolicy
+			err := json.Unmarshal(v, &p)
+			if err != nil {
+				return err
+			}
+			c.StoreSRPolicy(string(k), &p)
+			return nil
+		})
+	})
+}
+
+// GetSRPolicies returns all SR Policies
+func (c *Controller) GetSRPolicies() []*SRPolicy {
+	policies := make([]*SRPolicy, 0)
+	c.RangeSRPolicies(func(key, value interface{}) bool {
+		policies = append(policies, value.(*SRPolicy))
+		return true
+	})
+	return policies
+}
+
+func validateSRPolicy(p *SRPolicy) error {
+	if p.Name == "" {
+		return errors.New("SR Policy name must not be empty")
+	}
+	headEnd := net.ParseIP(p.HeadEnd)
+	if headEnd == nil {
+		return fmt.Errorf("invalid SR Policy head-end: %s", p.HeadEnd)
+	}
+	endpoint := net.ParseIP(p.Endpoint)
+	if endpoint == nil {
+		return fmt.Errorf("invalid SR Policy endpoint: %s", p.Endpoint)
+	}
+	if (headEnd.To4() == nil) != (endpoint.To4() == nil) {
+		return errors.New("SR Policy head-end and endpoint must be of the same address family")
+	}
+	if len(p.CandidatePaths) == 0 {
+		return errors.New("SR Policy must have at least one candidate path")
+	}
+	names := make(map[string]bool)
+	for _, cp := range p.CandidatePaths {
+		if cp.Name == "" {
+			return errors.New("candidate path name must not be empty")
+		}
+		if names[cp.Name] {
+			return fmt.Errorf("duplicate candidate path name: %s", cp.Name)
+		}
+		names[cp.Name] = true
+	}
+	return nil
+}
+
+// CreateUpdSRPolicy stores the SR Policy and signals each candidate path
+// as an SR LSP carrying the SR Policy association, candidate paths
+// removed from the policy are deleted from the router
+func (c *Controller) CreateUpdSRPolicy(p *SRPolicy) error {
+	err := validateSRPolicy(p)
+	if err != nil {
+		return err
+	}
+	old, err := c.reserveSRPolicy(p)
+	if err != nil {
+		return err
+	}
+	// a new policy gives its association ID back when it can not be stored
+	release := func() {
+		if old == nil {
+			c.SRPolicies.DelSRPolicy(p.Name)
+		}
+	}
+
+	lsps := make([]*pcep.SRLSP, 0, len(p.CandidatePaths))
+	for _, cp := range p.CandidatePaths {
+		lsp, err := c.newCandidatePathLSP(p, cp)
+		if err != nil {
+			release()
+			return err
+		}
+		lsps = append(lsps, lsp)
+	}
+
+	err = c.storeSRPolicy(p)
+	if err != nil {
+		release()
+		return err
+	}
+
+	for _, lsp := range lsps {
+		err = c.CreateUpdSRLSP(lsp)
+		if err != nil {
+			return err
+		}
+	}
+	if old != nil {
+		current := make(map[string]bool)
+		for _, cp := range p.CandidatePaths {
+			current[cp.Name] = true
+		}
+		for _, cp := range old.CandidatePaths {
+			if current[cp.Name] {
+				continue
+			}
+			err = c.removeSRLSP(old.lspName(cp))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reserveSRPolicy checks the policy identity and sets its association ID, a
+// new policy is stored in memory without candidate paths while holding the
+// lock so concurrent requests can not pick the same ID, the policy stored
+// before is returned
+func (c *Controller) reserveSRPolicy(p *SRPolicy) (*SRPolicy, error) {
+	defer c.Unlock()
+
+	c.Lock()
+	// the policy identity can not change once candidate paths were signalled
+	old, ok := c.GetSRPolicy(p.Name)
+	if ok {
+		if !net.ParseIP(p.HeadEnd).Equal(net.ParseIP(old.HeadEnd)) ||
+			p.Color != old.Color || !net.ParseIP(p.Endpoint).Equal(net.ParseIP(old.Endpoint)) {
+			return nil, fmt.Errorf("head-end, color and endpoint of SR Policy %s can not change, delete and create it again", p.Name)
+		}
+		p.HeadEnd = old.HeadEnd
+		p.Endpoint = old.Endpoint
+		p.AssociationID = old.AssociationID
+		return old, nil
+	}
+	id, err := c.nextAssociationID(&pcep.Association{Type: pcep.AssocTypeSRPolicy, Source: p.HeadEnd})
+	if err != nil {
+		return nil, err
+	}
+	p.AssociationID = id
+	reserved := *p
+	reserved.CandidatePaths = nil
+	c.StoreSRPolicy(p.Name, &reserved)
+	return nil, nil
+}
+
+// DelSRPolicy deletes all candidate paths of the SR Policy and the policy itself
+func (c *Controller) DelSRPolicy(name string) error {
+	p, ok := c.GetSRPolicy(name)
+	if !ok {
+		return fmt.Errorf("no SR Policy named: %s found", name)
+	}
+	for _, cp := range p.CandidatePaths {
+		err := c.removeSRLSP(p.lspName(cp))
+		if err != nil {
+			return err
+		}
+	}
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("srpolicies"))
+		if err != nil {
+			return err
+		}
+		return b.Delete([]byte(name))
+	})
+	if err != nil {
+		return err
+	}
+	c.SRPolicies.DelSRPolicy(name)
+	return nil
+}
+
+func (c *Controller) storeSRPolicy(p *SRPolicy) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte("srpolicies"))
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(p.Name), data)
+	})
+	if err != nil {
+		return err
+	}
+	c.StoreSRPolicy(p.Name, p)
+	return nil
+}
+
+// newCandidatePathLSP builds the SR LSP signalling the candidate path,
+// the path is computed from the head-end to the endpoint if it has no SIDs
+func (c *Controller) newCandidatePathLSP(p *SRPolicy, cp *CandidatePath) (*pcep.SRLSP, error) {
+	eroList := cp.EROList
+	if len(eroList) == 0 {
+		reply, err := c.TopoView.computeSRPath(float32(cp.BW), p.HeadEnd, p.Endpoint, c.headEndMSD(p.HeadEnd))
+		if err != nil {
+			return nil, err
+		}
+		if len(reply.EROList) == 0 {
+			return nil, fmt.Errorf("no path found for candidate path %s of SR Policy %s", cp.Name, p.Name)
+		}
+		eroList = reply.EROList
+	}
+	return &pcep.SRLSP{
+		Delegate:  true,
+		Admin:     true,
+		Name:      p.lspName(cp),
+		Src:       p.HeadEnd,
+		Dst:       p.Endpoint,
+		SetupPrio: 7,
+		HoldPrio:  7,
+		BW:        cp.BW,
+		EROList:   eroList,
+		Associations: []*pcep.Association{
+			{
+				Type:   pcep.AssocTypeSRPolicy,
+				ID:     p.AssociationID,
+				Source: p.HeadEnd,
+				SRPolicy: &pcep.SRPolicyAssoc{
+					Color:      p.Color,
+					Endpoint:   p.Endpoint,
+					PolicyName: p.Name,
+					CPathID: &pcep.SRPolicyCPathID{
+						ProtoOrigin:   pcep.SRPolicyOriginPCEP,
+						Discriminator: cp.Discriminator,
+					},
+					CPathName:  cp.Name,
+					Preference: cp.Preference,
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"gopcep/pcep"
+)
+
+func TestCreateUpdSRPolicyIdentity(t *testing.T) {
+	c := newTestController(t)
+	newPolicy := func() *SRPolicy {
+		return &SRPolicy{
+			Name:     "policy1",
+			HeadEnd:  "10.0.0.1",
+			Color:    100,
+			Endpoint: "10.0.0.9",
+			CandidatePaths: []*CandidatePath{
+				{
+					Name:       "cp1",
+					Preference: 100,
+					EROList:    []pcep.SREROSub{{SID: 16009}},
+				},
+			},
+		}
+	}
+	err := c.CreateUpdSRPolicy(newPolicy())
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+
+	for _, tc := range []struct {
+		name   string
+		change func(p *SRPolicy)
+	}{
+		{name: "head-end", change: func(p *SRPolicy) { p.HeadEnd = "10.0.0.2" }},
+		{name: "color", change: func(p *SRPolicy) { p.Color = 200 }},
+		{name: "endpoint", change: func(p *SRPolicy) { p.Endpoint = "10.0.0.8" }},
+	} {
+		p := newPolicy()
+		tc.change(p)
+		err = c.CreateUpdSRPolicy(p)
+		if err == nil {
+			t.Errorf("expected error when %s changes", tc.name)
+		}
+	}
+	stored, _ := c.GetSRPolicy("policy1")
+	if stored.HeadEnd != "10.0.0.1" || stored.Color != 100 || stored.Endpoint != "10.0.0.9" {
+		t.Errorf("expected the stored policy to be unchanged got %+v", stored)
+	}
+
+	// candidate paths can still change
+	p := newPolicy()
+	p.CandidatePaths[0].Preference = 200
+	err = c.CreateUpdSRPolicy(p)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if p.AssociationID != stored.AssociationID {
+		t.Errorf("expected association ID %d to be kept got %d", stored.AssociationID, p.AssociationID)
+	}
+}
+
+func TestCreateUpdSRPolicyConcurrentIDs(t *testing.T) {
+	c := newTestController(t)
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- c.CreateUpdSRPolicy(&SRPolicy{
+				Name:     fmt.Sprintf("policy%d", i),
+				HeadEnd:  "10.0.0.1",
+				Color:    uint32(i),
+				Endpoint: "10.0.0.9",
+				CandidatePaths: []*CandidatePath{
+					{Name: "cp1", Preference: 100, EROList: []pcep.SREROSub{{SID: 16009}}},
+				},
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+	}
+	ids := make(map[uint16]string)
+	for _, p := range c.GetSRPolicies() {
+		if other, ok := ids[p.AssociationID]; ok {
+			t.Errorf("ID %d is used by %s and %s", p.AssociationID, other, p.Name)
+		}
+		ids[p.AssociationID] = p.Name
+	}
+	if len(ids) != 20 {
+		t.Errorf("expected 20 policies got %d", len(ids))
+	}
+
+	// a policy which can not be stored gives its ID back
+	err := c.CreateUpdSRPolicy(&SRPolicy{
+		Name:     "nopath",
+		HeadEnd:  "10.0.0.1",
+		Color:    100,
+		Endpoint: "10.0.0.9",
+		// the endpoint is not in the topology
+		CandidatePaths: []*CandidatePath{{Name: "cp1", Preference: 100}},
+	})
+	if err == nil {
+		t.Fatal("expected error for a candidate path without path")
+	}
+	if _, ok := c.GetSRPolicy("nopath"); ok {
+		t.Error("expected the policy not to be stored")
+	}
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	api "github.com/osrg/gobgp/api"
+)
+
+// https://www.rfc-editor.org/rfc/rfc9085#section-2.2.1
+const lsTLVAdjacencySID = 1099
+
+//SRRange is a label range of SRGB or SRLB https://tools.ietf.org/html/rfc8667#section-3.1
+type SRRange struct {
+	Start uint32
+	Size  uint32
+}
+
+// newSRRanges converts BGP-LS label ranges keeping their order,
+// GoBGP reports the end of a range as its start plus its size
+func newSRRanges(ranges []*api.LsSrRange) []*SRRange {
+	srRanges := make([]*SRRange, 0, len(ranges))
+	for _, r := range ranges {
+		srRanges = append(srRanges, &SRRange{
+			Start: r.Begin,
+			Size:  r.End - r.Begin,
+		})
+	}
+	return srRanges
+}
+
+// srLabel maps the SID index to a label of the block made of the ranges
+// in the advertised order https://tools.ietf.org/html/rfc8402#section-2
+func srLabel(ranges []*SRRange, index uint32) (uint32, error) {
+	offset := index
+	for _, r := range ranges {
+		if offset < r.Size {
+			return r.Start + offset, nil
+		}
+		offset -= r.Size
+	}
+	return 0, fmt.Errorf("SID index %d is out of the label block", index)
+}
+
+// adjSIDIsIndex is true when the Adjacency SID TLV of the raw BGP-LS attribute
+// carries a 4 byte index into the SRLB rather than a 3 byte label, GoBGP
+// decodes both into the same field, the last TLV wins as it does in GoBGP
+func adjSIDIsIndex(data []byte) bool {
+	var index bool
+	for len(data) >= 4 {
+		t := binary.BigEndian.Uint16(data[:2])
+		l := int(binary.BigEndian.Uint16(data[2:4]))
+		if 4+l > len(data) {
+			return false
+		}
+		if t == lsTLVAdjacencySID {
+			// flags, weight and reserved go before the SID
+			index = l == 8
+		}
+		data = data[4+l:]
+	}
+	return index
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"testing"
+
+	api "github.com/osrg/gobgp/api"
+)
+
+func TestNewSRRanges(t *testing.T) {
+	ranges := newSRRanges([]*api.LsSrRange{
+		{Begin: 16000, End: 24000},
+		{Begin: 100000, End: 100100},
+	})
+	if len(ranges) != 2 {
+		t.Fatalf("expected 2 ranges got %d", len(ranges))
+	}
+	// the advertised order is kept as it defines the index to label mapping
+	if *ranges[0] != (SRRange{Start: 16000, Size: 8000}) || *ranges[1] != (SRRange{Start: 100000, Size: 100}) {
+		t.Errorf("unexpected ranges %v %v", ranges[0], ranges[1])
+	}
+}
+
+func TestSRLabel(t *testing.T) {
+	multi := []*SRRange{
+		{Start: 16000, Size: 100},
+		{Start: 100000, Size: 50},
+		{Start: 20000, Size: 10},
+	}
+	for _, c := range []struct {
+		name   string
+		ranges []*SRRange
+		index  uint32
+		label  uint32
+		err    bool
+	}{
+		{name: "first label", ranges: multi, index: 0, label: 16000},
+		{name: "last label of first range", ranges: multi, index: 99, label: 16099},
+		{name: "first label of second range", ranges: multi, index: 100, label: 100000},
+		{name: "second range", ranges: multi, index: 120, label: 100020},
+		{name: "range lower than the one before", ranges: multi, index: 155, label: 20005},
+		{name: "last label", ranges: multi, index: 159, label: 20009},
+		{name: "out of the block", ranges: multi, index: 160, err: true},
+		{name: "no ranges", ranges: nil, index: 0, err: true},
+	} {
+		label, err := srLabel(c.ranges, c.index)
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: expected error got label %d", c.name, label)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: must not see any errors, instead got: %s", c.name, err.Error())
+			continue
+		}
+		if label != c.label {
+			t.Errorf("%s: expected label %d got %d", c.name, c.label, label)
+		}
+	}
+}
+
+func TestNodeSIDFromUpstreamSRGB(t *testing.T) {
+	topo := newTestTopo()
+	// B has an SRGB made of two ranges and C has a different one
+	topo.NodesByIGPRouteID[nodeB].SRGB = []*SRRange{
+		{Start: 16000, Size: 3},
+		{Start: 900000, Size: 1000},
+	}
+	topo.NodesByIGPRouteID[nodeC].SRGB = []*SRRange{{Start: 500000, Size: 1000}}
+
+	for _, c := range []struct {
+		upstream string
+		label    uint32
+	}{
+		{upstream: nodeA, label: 16004},
+		{upstream: nodeB, label: 900001},
+		{upstream: nodeC, label: 500004},
+	} {
+		label, err := topo.getSIDByIGPRouterID(c.upstream, nodeD, false)
+		if err != nil {
+			t.Errorf("%s: must not see any errors, instead got: %s", c.upstream, err.Error())
+			continue
+		}
+		if label != c.label {
+			t.Errorf("%s: expected label %d got %d", c.upstream, c.label, label)
+		}
+	}
+
+	// the index of D does not fit into the SRGB of C
+	topo.NodesByIGPRouteID[nodeC].SRGB = []*SRRange{{Start: 500000, Size: 4}}
+	_, err := topo.getSIDByIGPRouterID(nodeC, nodeD, false)
+	if err == nil {
+		t.Error("expected error for an index out of the SRGB")
+	}
+
+	// labels along the SID list come from the node processing them
+	topo.NodesByIGPRouteID[nodeC].SRGB = []*SRRange{{Start: 500000, Size: 1000}}
+	ero, err := topo.compressSRPath(topo.abcd, false)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	expected := []uint32{16002, 900000, 500004}
+	if len(ero) != len(expected) {
+		t.Fatalf("expected labels %v got %v", expected, ero)
+	}
+	for i, label := range expected {
+		if ero[i].SID != label {
+			t.Errorf("expected labels %v got %v", expected, ero)
+		}
+	}
+}
+
+func TestAdjSIDFromSRLB(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		attr  []byte
+		index bool
+	}{
+		{
+			name: "3 byte label",
+			attr: []byte{0x04, 0x4b, 0, 7, 0x30, 0, 0, 0, 0x05, 0xdc, 0x10},
+		},
+		{
+			name:  "4 byte index",
+			attr:  []byte{0x04, 0x4b, 0, 8, 0, 0, 0, 0, 0, 0, 0, 5},
+			index: true,
+		},
+		{
+			name: "last TLV wins",
+			attr: []byte{
+				0x04, 0x4b, 0, 8, 0, 0, 0, 0, 0, 0, 0, 5,
+				0x04, 0x4b, 0, 7, 0x30, 0, 0, 0, 0x05, 0xdc, 0x10,
+			},
+		},
+		{
+			name: "other TLVs only",
+			attr: []byte{0x04, 0x47, 0, 4, 0, 0, 0, 10},
+		},
+		{
+			name: "truncated TLV",
+			attr: []byte{0x04, 0x4b, 0, 8, 0, 0, 0, 0},
+		},
+	} {
+		if adjSIDIsIndex(c.attr) != c.index {
+			t.Errorf("%s: expected index %t", c.name, c.index)
+		}
+	}
+
+	topo := newTestTopo()
+	topo.NodesByIGPRouteID[nodeA].SRLB = []*SRRange{
+		{Start: 15000, Size: 2},
+		{Start: 30000, Size: 100},
+	}
+	topo.ab.SRAdjacencySID, topo.ab.SRAdjacencyIndex = 5, true
+	ero, err := topo.adjSRERO(topo.ab, false)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if ero.SID != 30003 {
+		t.Errorf("expected label 30003 got %d", ero.SID)
+	}
+	// index 0 is a valid adjacency SID
+	topo.ab.SRAdjacencySID = 0
+	ero, err = topo.adjSRERO(topo.ab, false)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if ero.SID != 15000 {
+		t.Errorf("expected label 15000 got %d", ero.SID)
+	}
+	topo.ab.SRAdjacencySID = 102
+	_, err = topo.adjSRERO(topo.ab, false)
+	if err == nil {
+		t.Error("expected error for an index out of the SRLB")
+	}
+	// labels are used as advertised
+	ero, err = topo.adjSRERO(topo.ba, false)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if ero.SID != topo.ba.SRAdjacencySID {
+		t.Errorf("expected label %d got %d", topo.ba.SRAdjacencySID, ero.SID)
+	}
+}
@@ -0,0 +1,361 @@
+package controller
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"gopcep/pcep"
+	"net"
+	"strings"
+
+	api "github.com/osrg/gobgp/api"
+	"github.com/sirupsen/logrus"
+)
+
+// BGP-LS SRv6 extensions https://www.rfc-editor.org/rfc/rfc9514.html
+const (
+	lsNLRITypeSRv6SID     = 6
+	lsTLVLocalNodeDesc    = 256
+	lsTLVIGPRouterID      = 515
+	lsTLVSRv6SIDInfo      = 518
+	lsTLVSRv6EndXSID      = 1106
+	lsTLVSRv6Locator      = 1162
+	lsTLVSRv6EndpointBeh  = 1250
+	lsTLVSRv6SIDStructure = 1252
+	bgpAttrTypeLS         = 29
+)
+
+// SRv6Locator is advertised with the locator prefix https://www.rfc-editor.org/rfc/rfc9514.html#section-5.1
+type SRv6Locator struct {
+	Prefix    string
+	Algorithm uint8
+	Metric    uint32
+}
+
+// SRv6SID is an End SID of a node or an End.X SID of a link
+type SRv6SID struct {
+	SID       string
+	Behavior  uint16
+	Algorithm uint8
+	Structure *pcep.SRv6SIDStructure
+}
+
+// lsTLVs walks BGP-LS TLVs calling f for each of them
+func lsTLVs(data []byte, f func(t uint16, v []byte) error) error {
+	for len(data) >= 4 {
+		t := binary.BigEndian.Uint16(data[:2])
+		l := int(binary.BigEndian.Uint16(data[2:4]))
+		if 4+l > len(data) {
+			return fmt.Errorf("malformed BGP-LS TLV type %d", t)
+		}
+		err := f(t, data[4:4+l])
+		if err != nil {
+			return err
+		}
+		data = data[4+l:]
+	}
+	return nil
+}
+
+// formatIGPRouterID matches the format used by gobgp so SRv6 data
+// can be merged with nodes learnt from the decoded NLRI
+func formatIGPRouterID(id []byte) string {
+	switch len(id) {
+	case 4:
+		return net.IP(id).String()
+	case 6:
+		return fmt.Sprintf("%0.2x%0.2x.%0.2x%0.2x.%0.2x%0.2x", id[0], id[1], id[2], id[3], id[4], id[5])
+	case 7:
+		return fmt.Sprintf("%0.2x%0.2x.%0.2x%0.2x.%0.2x%0.2x-%0.2x", id[0], id[1], id[2], id[3], id[4], id[5], id[6])
+	case 8:
+		return fmt.Sprintf("%v:%v", net.IP(id[:4]).String(), net.IP(id[4:]).String())
+	default:
+		return fmt.Sprintf("%v", id)
+	}
+}
+
+// https://www.rfc-editor.org/rfc/rfc9514.html#section-8
+func parseSRv6SIDStructure(v []byte) (*pcep.SRv6SIDStructure, error) {
+	if len(v) < 4 {
+		return nil, fmt.Errorf("SRv6 SID structure len is %d but should be 4", len(v))
+	}
+	return &pcep.SRv6SIDStructure{
+		LBLength:  v[0],
+		LNLength:  v[1],
+		FunLength: v[2],
+		ArgLength: v[3],
+	}, nil
+}
+
+// lsAttr returns the BGP-LS attribute out of raw path attributes
+func lsAttr(pattrs [][]byte) []byte {
+	for _, attr := range pattrs {
+		if len(attr) < 3 || attr[1] != bgpAttrTypeLS {
+			continue
+		}
+		// extended length flag
+		if attr[0]&0x10 != 0 {
+			if len(attr) < 4 {
+				return nil
+			}
+			return attr[4:]
+		}
+		return attr[3:]
+	}
+	return nil
+}
+
+// srv6LsAttr is the SRv6 part of a BGP-LS attribute
+type srv6LsAttr struct {
+	locator   *SRv6Locator
+	endXSIDs  []*SRv6SID
+	behavior  uint16
+	algorithm uint8
+	structure *pcep.SRv6SIDStructure
+}
+
+func parseSRv6LsAttr(data []byte) (*srv6LsAttr, error) {
+	attr := &srv6LsAttr{}
+	err := lsTLVs(data, func(t uint16, v []byte) error {
+		switch t {
+		// https://www.rfc-editor.org/rfc/rfc9514.html#section-5.1
+		case lsTLVSRv6Locator:
+			if len(v) < 8 {
+				return fmt.Errorf("SRv6 locator TLV len is %d but should be at least 8", len(v))
+			}
+			attr.locator = &SRv6Locator{
+				Algorithm: v[1],
+				Metric:    binary.BigEndian.Uint32(v[4:8]),
+			}
+		// https://www.rfc-editor.org/rfc/rfc9514.html#section-4.1
+		case lsTLVSRv6EndXSID:
+			if len(v) < 22 {
+				return fmt.Errorf("SRv6 End.X SID TLV len is %d but should be at least 22", len(v))
+			}
+			sid := &SRv6SID{
+				Behavior:  binary.BigEndian.Uint16(v[:2]),
+				Algorithm: v[3],
+				SID:       net.IP(append([]byte{}, v[6:22]...)).String(),
+			}
+			err := lsTLVs(v[22:], func(t uint16, v []byte) error {
+				if t != lsTLVSRv6SIDStructure {
+					return nil
+				}
+				var err error
+				sid.Structure, err = parseSRv6SIDStructure(v)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			attr.endXSIDs = append(attr.endXSIDs, sid)
+		// https://www.rfc-editor.org/rfc/rfc9514.html#section-7.1
+		case lsTLVSRv6EndpointBeh:
+			if len(v) < 4 {
+				return fmt.Errorf("SRv6 endpoint behavior TLV len is %d but should be 4", len(v))
+			}
+			attr.behavior = binary.BigEndian.Uint16(v[:2])
+			attr.algorithm = v[3]
+		case lsTLVSRv6SIDStructure:
+			var err error
+			attr.structure, err = parseSRv6SIDStructure(v)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attr, nil
+}
+
+// parseSRv6SIDNLRI returns the IGP router ID of the node and its SID
+// https://www.rfc-editor.org/rfc/rfc9514.html#section-6
+func parseSRv6SIDNLRI(data []byte) (string, string, error) {
+	// NLRI type, length, Protocol-ID and Identifier
+	if len(data) < 13 {
+		return "", "", fmt.Errorf("SRv6 SID NLRI len is %d but should be at least 13", len(data))
+	}
+	var routerID, sid string
+	err := lsTLVs(data[13:], func(t uint16, v []byte) error {
+		switch t {
+		case lsTLVLocalNodeDesc:
+			return lsTLVs(v, func(t uint16, v []byte) error {
+				if t == lsTLVIGPRouterID {
+					routerID = formatIGPRouterID(v)
+				}
+				return nil
+			})
+		case lsTLVSRv6SIDInfo:
+			if len(v) < 16 {
+				return fmt.Errorf("SRv6 SID information TLV len is %d but should be 16", len(v))
+			}
+			sid = net.IP(append([]byte{}, v[:16]...)).String()
+		}
+		return nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if routerID == "" || sid == "" {
+		return "", "", errors.New("SRv6 SID NLRI without IGP router ID or SID")
+	}
+	return routerID, sid, nil
+}
+
+// HandleSRv6SIDNLRI stores End SIDs of nodes, the NLRI is only available
+// in binary form as it is not decoded by the BGP library
+func (t *TopoView) HandleSRv6SIDNLRI(p *api.Path) {
+	routerID, sid, err := parseSRv6SIDNLRI(p.NlriBinary)
+	if err != nil {
+		logrus.Println(err)
+		return
+	}
+	attr, err := parseSRv6LsAttr(lsAttr(p.PattrsBinary))
+	if err != nil {
+		logrus.Println(err)
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	node, ok := t.NodesByIGPRouteID[routerID]
+	if !ok {
+		node = &Node{IGPRouteID: routerID}
+		t.NodesByIGPRouteID[routerID] = node
+	}
+	for _, s := range node.SRv6EndSIDs {
+		if s.SID == sid {
+			s.Behavior, s.Algorithm, s.Structure = attr.behavior, attr.algorithm, attr.structure
+			return
+		}
+	}
+	node.SRv6EndSIDs = append(node.SRv6EndSIDs, &SRv6SID{
+		SID:       sid,
+		Behavior:  attr.behavior,
+		Algorithm: attr.algorithm,
+		Structure: attr.structure,
+	})
+}
+
+// isSRv6SIDNLRI checks the NLRI type of a raw BGP-LS NLRI
+func isSRv6SIDNLRI(p *api.Path) bool {
+	return len(p.NlriBinary) >= 2 && binary.BigEndian.Uint16(p.NlriBinary[:2]) == lsNLRITypeSRv6SID
+}
+
+// getSRv6EndSID returns End SID of a node using a flavor of End behavior
+func (t *TopoView) getSRv6EndSID(routerID string) (*SRv6SID, error) {
+	node, ok := t.NodesByIGPRouteID[routerID]
+	if !ok {
+		return nil, fmt.Errorf("no node found for id: %s", routerID)
+	}
+	for _, sid := range node.SRv6EndSIDs {
+		// End, End with PSP, USP and PSP&USP
+		if sid.Behavior >= pcep.SRv6BehaviorEnd && sid.Behavior <= 4 {
+			return sid, nil
+		}
+	}
+	return nil, fmt.Errorf("no SRv6 End SID found for id: %s", routerID)
+}
+
+// pathHasSRv6 checks that SIDs needed by createSRv6LSP are known
+func (t *TopoView) pathHasSRv6(path *Path) bool {
+	defer t.RUnlock()
+
+	t.RLock()
+	for i, link := range path.Links {
+		if i == 0 && len(link.SRv6EndXSIDs) == 0 {
+			return false
+		}
+		if i == 0 {
+			continue
+		}
+		node, ok := t.NodesByIGPRouteID[link.LocalNode]
+		if !ok || len(node.SRv6EndSIDs) == 0 {
+			return false
+		}
+	}
+	return len(path.Links) > 0
+}
+
+// createSRv6LSP builds SRv6 policy for the path using End.X SID
+// of the first link followed by End SIDs of transit nodes
+func (t *TopoView) createSRv6LSP(bw uint32, path *Path) (*pcep.SRLSP, error) {
+	defer t.Unlock()
+
+	if len(path.Links) == 0 {
+		return nil, fmt.Errorf("no links found in path")
+	}
+
+	t.Lock()
+	srcPrefix, ok := t.getNodePrefix(path.Src, true)
+	if !ok {
+		return nil, fmt.Errorf("src prefix not found for IGPID %s", path.Src)
+	}
+	dstPrefix, ok := t.getNodePrefix(path.Dst, true)
+	if !ok {
+		return nil, fmt.Errorf("dst prefix not found for IGPID %s", path.Dst)
+	}
+
+	lspSrc := strings.Split(srcPrefix.Prefix, "/")[0]
+	lspDst := strings.Split(dstPrefix.Prefix, "/")[0]
+
+	lsp := &pcep.SRLSP{
+		Delegate:     true,
+		Sync:         false,
+		Remove:       false,
+		Admin:        true,
+		Name:         "LSP-SRv6-" + lspSrc + "-" + lspDst,
+		Src:          lspSrc,
+		Dst:          lspDst,
+		SetupPrio:    7,
+		HoldPrio:     7,
+		LocalProtect: false,
+		BW:           bw,
+		PST:          pcep.PSTSRv6,
+		SRv6EROList:  make([]pcep.SRv6EROSub, 0),
+	}
+
+	for i, link := range path.Links {
+		if i == 0 {
+			if len(link.SRv6EndXSIDs) == 0 {
+				return nil, fmt.Errorf("no SRv6 End.X SID found for link %s-%s", link.LocalNode, link.RemoteNode)
+			}
+			sid := link.SRv6EndXSIDs[0]
+			sub := pcep.SRv6EROSub{
+				Behavior:     sid.Behavior,
+				SID:          sid.SID,
+				SIDStructure: sid.Structure,
+				NoNAI:        true,
+			}
+			if link.IntIPv6 != "" && link.NeighbourIPv6 != "" {
+				sub.NoNAI = false
+				sub.NT = 4
+				sub.IPv6Adjacency = []string{
+					0: link.IntIPv6,
+					1: link.NeighbourIPv6,
+				}
+			}
+			lsp.SRv6EROList = append(lsp.SRv6EROList, sub)
+			continue
+		}
+		sid, err := t.getSRv6EndSID(link.LocalNode)
+		if err != nil {
+			return nil, err
+		}
+		sub := pcep.SRv6EROSub{
+			Behavior:     sid.Behavior,
+			SID:          sid.SID,
+			SIDStructure: sid.Structure,
+			NoNAI:        true,
+		}
+		nodePrefix, ok := t.getNodePrefix(link.LocalNode, true)
+		if ok {
+			sub.NoNAI = false
+			sub.NT = 2
+			sub.IPv6NodeID = strings.Split(nodePrefix.Prefix, "/")[0]
+		}
+		lsp.SRv6EROList = append(lsp.SRv6EROList, sub)
+	}
+
+	return lsp, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"gopcep/certs"
 	"gopcep/controller"
+	"gopcep/pcep"
 	pb "gopcep/proto"
 	"net"
 	"sync"
@@ -32,15 +33,33 @@ func (g *GRPCAPI) GetSessions(ctx context.Context, in *pb.SessionsRequest) (*pb.
 			Address:   addr,
 			ID:        string(session.ID),
 			MsgCount:  session.MsgCount,
-			State:     int32(session.State),
+			State:     pb.SessionState(session.GetState()),
 			Keepalive: uint32(session.Keepalive),
 			DeadTimer: uint32(session.DeadTimer),
+			TLS:       session.TLS,
 		})
 	}
 	g.RUnlock()
 	return &pb.SessionsReply{Sessions: pbSessions}, nil
 }
 
+// GetAuthStats returns TCP authentication failures of the whole host
+func (g *GRPCAPI) GetAuthStats(ctx context.Context, in *pb.AuthStatsRequest) (*pb.AuthStatsReply, error) {
+	stats, err := g.ctr.GetAuthStats()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AuthStatsReply{
+		Scope:         stats.Scope,
+		MD5NotFound:   stats.MD5NotFound,
+		MD5Unexpected: stats.MD5Unexpected,
+		MD5Failure:    stats.MD5Failure,
+		AORequired:    stats.AORequired,
+		AOBad:         stats.AOBad,
+		AOKeyNotFound: stats.AOKeyNotFound,
+	}, nil
+}
+
 func (g *GRPCAPI) StopBGP(ctx context.Context, in *pb.StopBGPRequest) (*pb.StopBGPReplay, error) {
 
 	select {
@@ -71,6 +90,15 @@ func (g *GRPCAPI) GetLSPs(ctx context.Context, in *pb.LSPRequest) (*pb.LSPReply,
 	session := g.ctr.PCEPSessions[in.PccName]
 
 	for _, lsp := range session.LSPs {
+		metrics := make([]*pb.Metric, 0, len(lsp.Metrics))
+		for _, m := range lsp.Metrics {
+			metrics = append(metrics, &pb.Metric{
+				Type:     uint32(m.Type),
+				Bound:    m.BFlag,
+				Computed: m.CFlag,
+				Value:    m.Metric,
+			})
+		}
 		pbLSPs = append(pbLSPs, &pb.LSP{
 			Delegate:     lsp.Delegate,
 			Sync:         lsp.Sync,
@@ -90,12 +118,131 @@ func (g *GRPCAPI) GetLSPs(ctx context.Context, in *pb.LSPRequest) (*pb.LSPReply,
 			ExcludeAny:   lsp.ExcludeAny,
 			IncludeAny:   lsp.IncludeAny,
 			IncludeAll:   lsp.IncludeAll,
+			Metrics:      metrics,
 		})
 	}
 	g.RUnlock()
 	return &pb.LSPReply{LSPs: pbLSPs}, nil
 }
 
+// GetSRLSPs returns the SR LSPs stored in the controller
+func (g *GRPCAPI) GetSRLSPs(ctx context.Context, in *pb.SRLSPRequest) (*pb.SRLSPReply, error) {
+	pbLSPs := make([]*pb.SRLSP, 0)
+	for _, lsp := range g.ctr.GetSRLSPs() {
+		hops := make([]*pb.SRHop, 0, len(lsp.EROList))
+		for _, ero := range lsp.EROList {
+			hops = append(hops, &pb.SRHop{
+				SID:        ero.SID,
+				IPv4NodeID: ero.IPv4NodeID,
+				IPv6NodeID: ero.IPv6NodeID,
+			})
+		}
+		pbLSPs = append(pbLSPs, &pb.SRLSP{
+			Name:       lsp.Name,
+			Src:        lsp.Src,
+			Dst:        lsp.Dst,
+			PST:        uint32(lsp.PST),
+			BW:         lsp.BW,
+			Delegate:   lsp.Delegate,
+			Admin:      lsp.Admin,
+			Hops:       hops,
+			BindingSID: lsp.BindingSID,
+			SkipReason: lsp.SkipReason,
+		})
+	}
+	return &pb.SRLSPReply{SRLSPs: pbLSPs}, nil
+}
+
+// GetSRPolicies returns all SR Policies
+func (g *GRPCAPI) GetSRPolicies(ctx context.Context, in *pb.SRPoliciesRequest) (*pb.SRPoliciesReply, error) {
+	pbPolicies := make([]*pb.SRPolicy, 0)
+	for _, p := range g.ctr.GetSRPolicies() {
+		pbPolicies = append(pbPolicies, srPolicyToPB(p))
+	}
+	return &pb.SRPoliciesReply{SRPolicies: pbPolicies}, nil
+}
+
+// CreateUpdSRPolicy creates or updates an SR Policy and its candidate paths
+func (g *GRPCAPI) CreateUpdSRPolicy(ctx context.Context, in *pb.SRPolicy) (*pb.SRPolicy, error) {
+	p := &controller.SRPolicy{
+		Name:           in.Name,
+		HeadEnd:        in.HeadEnd,
+		Color:          in.Color,
+		Endpoint:       in.Endpoint,
+		CandidatePaths: make([]*controller.CandidatePath, 0, len(in.CandidatePaths)),
+	}
+	for _, cp := range in.CandidatePaths {
+		eroList := make([]pcep.SREROSub, 0, len(cp.Hops))
+		for _, hop := range cp.Hops {
+			ero := pcep.SREROSub{
+				MBit: true,
+				SID:  hop.SID,
+			}
+			switch {
+			case hop.IPv4NodeID != "":
+				ero.NT = 1
+				ero.IPv4NodeID = hop.IPv4NodeID
+			case hop.IPv6NodeID != "":
+				ero.NT = 2
+				ero.IPv6NodeID = hop.IPv6NodeID
+			default:
+				ero.NoNAI = true
+			}
+			eroList = append(eroList, ero)
+		}
+		p.CandidatePaths = append(p.CandidatePaths, &controller.CandidatePath{
+			Name:          cp.Name,
+			Preference:    cp.Preference,
+			Discriminator: cp.Discriminator,
+			BW:            cp.BW,
+			EROList:       eroList,
+		})
+	}
+	err := g.ctr.CreateUpdSRPolicy(p)
+	if err != nil {
+		return nil, err
+	}
+	return srPolicyToPB(p), nil
+}
+
+// DelSRPolicy deletes an SR Policy and its candidate paths
+func (g *GRPCAPI) DelSRPolicy(ctx context.Context, in *pb.DelSRPolicyRequest) (*pb.DelSRPolicyReply, error) {
+	err := g.ctr.DelSRPolicy(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DelSRPolicyReply{}, nil
+}
+
+func srPolicyToPB(p *controller.SRPolicy) *pb.SRPolicy {
+	pbPolicy := &pb.SRPolicy{
+		Name:           p.Name,
+		HeadEnd:        p.HeadEnd,
+		Color:          p.Color,
+		Endpoint:       p.Endpoint,
+		AssociationID:  uint32(p.AssociationID),
+		CandidatePaths: make([]*pb.CandidatePath, 0, len(p.CandidatePaths)),
+	}
+	for _, cp := range p.CandidatePaths {
+		hops := make([]*pb.SRHop, 0, len(cp.EROList))
+		for _, ero := range cp.EROList {
+			hops = append(hops, &pb.SRHop{
+				SID:        ero.SID,
+				IPv4NodeID: ero.IPv4NodeID,
+				IPv6NodeID: ero.IPv6NodeID,
+			})
+		}
+		pbPolicy.CandidatePaths = append(pbPolicy.CandidatePaths, &pb.CandidatePath{
+			Name:          cp.Name,
+			Preference:    cp.Preference,
+			Discriminator: cp.Discriminator,
+			BW:            cp.BW,
+			Hops:          hops,
+		})
+	}
+	return pbPolicy
+}
+
 // Config represents GRPC Config
 type Config struct {
 	ListenAddr string
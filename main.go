@@ -27,6 +27,7 @@ type cfg struct {
 	pcep    pcep.Cfg
 	logCfg  logCfg
 	bgpls   controller.BGPGlobalCfg
+	bsid    controller.BSIDRange
 }
 
 func appCfg(cfgPath string) *cfg {
@@ -34,6 +35,12 @@ func appCfg(cfgPath string) *cfg {
 	viper.AddConfigPath(cfgPath)
 	viper.SetConfigType("toml")
 
+	// capabilities advertised before they became configurable
+	viper.SetDefault("pcep.capabilities.lsp_update", true)
+	viper.SetDefault("pcep.capabilities.lsp_init", true)
+	viper.SetDefault("pcep.capabilities.sr", true)
+	viper.SetDefault("pcep.capabilities.msd", 5)
+
 	err := viper.ReadInConfig()
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -48,6 +55,28 @@ func appCfg(cfgPath string) *cfg {
 			ListenAddr: viper.GetString("pcep.listen_addr"),
 			ListenPort: viper.GetString("pcep.listen_port"),
 			Keepalive:  uint8(viper.GetUint32("pcep.keepalive")),
+			DeadTimer:  uint8(viper.GetUint32("pcep.deadtimer")),
+
+			MinKeepalive: uint8(viper.GetUint32("pcep.min_keepalive")),
+			MaxKeepalive: uint8(viper.GetUint32("pcep.max_keepalive")),
+			MinDeadTimer: uint8(viper.GetUint32("pcep.min_deadtimer")),
+			MaxDeadTimer: uint8(viper.GetUint32("pcep.max_deadtimer")),
+			Capabilities: pcep.Capabilities{
+				LSPUpdate: viper.GetBool("pcep.capabilities.lsp_update"),
+				LSPInit:   viper.GetBool("pcep.capabilities.lsp_init"),
+				SR:        viper.GetBool("pcep.capabilities.sr"),
+				MSD:       uint8(viper.GetUint32("pcep.capabilities.msd")),
+				SRv6:      viper.GetBool("pcep.capabilities.srv6"),
+
+				DBVersion:            viper.GetBool("pcep.capabilities.include_db_version"),
+				TriggeredResync:      viper.GetBool("pcep.capabilities.triggered_resync"),
+				DeltaSync:            viper.GetBool("pcep.capabilities.delta_sync"),
+				TriggeredInitialSync: viper.GetBool("pcep.capabilities.triggered_initial_sync"),
+			},
+			TLSPolicy:   pcep.TLSPolicy(viper.GetString("pcep.tls.policy")),
+			TLSCertFile: viper.GetString("pcep.tls.cert_file"),
+			TLSKeyFile:  viper.GetString("pcep.tls.key_file"),
+			TLSCAFile:   viper.GetString("pcep.tls.ca_file"),
 		},
 		grpcapi: grpcapi.Config{
 			ListenAddr: viper.GetString("grpcapi.listen_addr"),
@@ -58,6 +87,10 @@ func appCfg(cfgPath string) *cfg {
 			AS:       uint32(viper.GetUint32("bgpls.as")),
 			RouterId: viper.GetString("bgpls.router_id"),
 		},
+		bsid: controller.BSIDRange{
+			Start: viper.GetUint32("bsid.range_start"),
+			End:   viper.GetUint32("bsid.range_end"),
+		},
 		restapi: restapi.Config{
 			Address:  viper.GetString("restapi.listen_addr"),
 			Port:     viper.GetString("restapi.listen_port"),
@@ -121,7 +154,7 @@ func startController(c *cli.Context) error {
 		}
 	}()
 
-	controller := controller.Start(db, &cfg.bgpls)
+	controller := controller.Start(db, &cfg.bgpls, &cfg.bsid)
 
 	err = grpcapi.Start(&cfg.grpcapi, controller)
 	if err != nil {
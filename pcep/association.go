@@ -0,0 +1,212 @@
+package pcep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+)
+
+// https://tools.ietf.org/html/rfc8697#section-7.2
+// ASSOCIATION Type Field
+const (
+	AssocTypePathProtection   uint16 = 1
+	AssocTypeDisjoint         uint16 = 2
+	AssocTypePolicy           uint16 = 3
+	AssocTypeSingleSidedBiDir uint16 = 4
+	AssocTypeDoubleSidedBiDir uint16 = 5
+	AssocTypeSRPolicy         uint16 = 6
+)
+
+//    ASSOCIATION Object-Class is 40.
+//    ASSOCIATION Object-Type is 1 for IPv4 and its format is shown below
+//     0                   1                   2                   3
+//     0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |         Reserved              |            Flags            |R|
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |       Association Type        |       Association ID          |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |              IPv4 Association Source                          |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    //                   Optional TLVs                             //
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    ASSOCIATION Object-Type is 2 for IPv6 with a 16 bytes source
+
+//Association https://tools.ietf.org/html/rfc8697#section-6.1
+type Association struct {
+	Remove bool
+	Type   uint16
+	ID     uint16
+	Source string
+	// GLOBAL-ASSOCIATION-SOURCE TLV, zero when absent
+	GlobalSource uint32
+	// EXTENDED-ASSOCIATION-ID TLV, nil when absent
+	ExtendedID []byte
+	// only used with the SR Policy association type
+	SRPolicy *SRPolicyAssoc
+	// only used with the path protection association type
+	PathProtection *PathProtection
+}
+
+// Key identifies the association group https://tools.ietf.org/html/rfc8697#section-6.1.4
+func (a *Association) Key() string {
+	ext := a.ExtendedID
+	if ext == nil && a.SRPolicy != nil {
+		ext, _ = a.SRPolicy.extendedID()
+	}
+	return fmt.Sprintf("%d/%d/%s/%d/%s", a.Type, a.ID, a.Source, a.GlobalSource, hex.EncodeToString(ext))
+}
+
+// https://tools.ietf.org/html/rfc8697#section-6.1
+func parseAssociationObj(objType uint8, data []byte) (*Association, error) {
+	srcLen := 4
+	if objType == 2 {
+		srcLen = 16
+	}
+	if len(data) < 8+srcLen {
+		return nil, fmt.Errorf("association obj len is %d but should be at least %d", len(data), 8+srcLen)
+	}
+	a := &Association{
+		Type:   binary.BigEndian.Uint16(data[4:6]),
+		ID:     binary.BigEndian.Uint16(data[6:8]),
+		Source: bytesToIP(data[8 : 8+srcLen]),
+	}
+	var err error
+	a.Remove, err = uintToBool(readBits(data[3], 0))
+	if err != nil {
+		return nil, err
+	}
+	offset := 8 + srcLen
+	// TLVs which are specific to the association type
+	typeTLVs := make(map[uint16][]byte)
+	for (len(data) - offset) >= 4 {
+		tlvType := binary.BigEndian.Uint16(data[offset : offset+2])
+		length := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		if offset+4+length > len(data) {
+			return nil, fmt.Errorf("malformed TLV type %d in association obj", tlvType)
+		}
+		switch tlvType {
+		// https://tools.ietf.org/html/rfc8697#section-6.1.3
+		case 30:
+			if length != 4 {
+				return nil, fmt.Errorf("GLOBAL-ASSOCIATION-SOURCE TLV len is %d but should be 4", length)
+			}
+			a.GlobalSource = binary.BigEndian.Uint32(data[offset+4 : offset+8])
+		case 31:
+			a.ExtendedID = append([]byte{}, data[offset+4:offset+4+length]...)
+		default:
+			typeTLVs[tlvType] = data[offset+4 : offset+4+length]
+		}
+		// TLVs are padded to 4-byte alignment
+		offset = offset + 4 + ((length + 3) &^ 3)
+	}
+	if a.Type == AssocTypeSRPolicy {
+		err = a.srPolicyAssoc(typeTLVs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// https://tools.ietf.org/html/rfc8745#section-3.2
+	if tlv, ok := typeTLVs[38]; ok && a.Type == AssocTypePathProtection {
+		a.PathProtection, err = parsePathProtectionTLV(tlv)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// https://tools.ietf.org/html/rfc8697#section-6.1
+func newAssociationObj(a *Association) ([]byte, error) {
+	ip := net.ParseIP(a.Source)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid association source: %s", a.Source)
+	}
+	objType := uint8(2)
+	src := ip.To16()
+	if ip4 := ip.To4(); ip4 != nil {
+		objType = 1
+		src = ip4
+	}
+	var flags uint32
+	if a.Remove {
+		flags |= (1 << 0)
+	}
+	buf := new(bytes.Buffer)
+	for _, v := range []interface{}{flags, a.Type, a.ID, src} {
+		err := binary.Write(buf, binary.BigEndian, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if a.GlobalSource != 0 {
+		for _, v := range []interface{}{uint16(30), uint16(4), a.GlobalSource} {
+			err := binary.Write(buf, binary.BigEndian, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	body := buf.Bytes()
+	extID := a.ExtendedID
+	if extID == nil && a.SRPolicy != nil {
+		var err error
+		extID, err = a.SRPolicy.extendedID()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(extID) > 0 {
+		ext, err := newExtendedAssocID(extID)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, ext...)
+	}
+	if a.SRPolicy != nil {
+		tlvs, err := newSRPolicyTLVs(a.SRPolicy)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, tlvs...)
+	}
+	if a.PathProtection != nil {
+		body = append(body, newPathProtectionTLV(a.PathProtection)...)
+	}
+	return newCommonObjHeader(40, objType, false, body)
+}
+
+// https://tools.ietf.org/html/rfc8697#section-6.1.4
+// EXTENDED-ASSOCIATION-ID TLV type is 31 and the ID has variable length
+func newExtendedAssocID(id []byte) ([]byte, error) {
+	if len(id) > math.MaxUint16-4 {
+		return nil, errors.New("extended association ID is too long")
+	}
+	buf := new(bytes.Buffer)
+	for _, v := range []interface{}{uint16(31), uint16(len(id)), id} {
+		err := binary.Write(buf, binary.BigEndian, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// TLVs are padded to 4-byte alignment
+	return append(buf.Bytes(), make([]byte, (4-len(id)%4)%4)...), nil
+}
+
+// newAssociationList encodes the association-list of the attribute-list
+// https://tools.ietf.org/html/rfc8697#section-6.3
+func newAssociationList(as []*Association) ([]byte, error) {
+	list := make([]byte, 0)
+	for _, a := range as {
+		obj, err := newAssociationObj(a)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, obj...)
+	}
+	return list, nil
+}
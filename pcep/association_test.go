@@ -0,0 +1,87 @@
+package pcep
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssociationObj(t *testing.T) {
+	for _, a := range []*Association{
+		{Type: AssocTypePathProtection, ID: 10, Source: "10.0.0.1"},
+		{Type: AssocTypePathProtection, ID: 11, Source: "10.0.0.1", PathProtection: &PathProtection{ProtectionType: ProtectionType1toN, Protecting: true}},
+		{Type: AssocTypePathProtection, ID: 12, Source: "10.0.0.1", PathProtection: &PathProtection{ProtectionType: ProtectionType1plus1Bidirectional, Secondary: true}},
+		{Remove: true, Type: AssocTypeDisjoint, ID: 1, Source: "2001:db8::1", GlobalSource: 65001, ExtendedID: []byte{0, 0, 0, 7, 1}},
+	} {
+		obj, err := newAssociationObj(a)
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		coh, err := parseCommonObjectHeader(obj[:4])
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		if int(coh.ObjectLength) != len(obj) || len(obj)%4 != 0 {
+			t.Fatalf("wrong object length %d for %d bytes", coh.ObjectLength, len(obj))
+		}
+		parsed, err := parseAssociationObj(coh.ObjectType, obj[4:])
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		if !reflect.DeepEqual(parsed, a) {
+			t.Errorf("expected %+v got %+v", a, parsed)
+		}
+	}
+}
+
+func TestSRPolicyAssociation(t *testing.T) {
+	a := &Association{
+		Type:   AssocTypeSRPolicy,
+		ID:     1,
+		Source: "10.0.0.1",
+		SRPolicy: &SRPolicyAssoc{
+			Color:      100,
+			Endpoint:   "10.0.0.2",
+			PolicyName: "gold",
+			CPathID: &SRPolicyCPathID{
+				ProtoOrigin:    SRPolicyOriginPCEP,
+				OriginatorASN:  65001,
+				OriginatorAddr: "10.0.0.10",
+				Discriminator:  2,
+			},
+			CPathName:  "primary",
+			Preference: 200,
+		},
+	}
+	obj, err := newAssociationObj(a)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	coh, err := parseCommonObjectHeader(obj[:4])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if int(coh.ObjectLength) != len(obj) || len(obj)%4 != 0 {
+		t.Fatalf("wrong object length %d for %d bytes", coh.ObjectLength, len(obj))
+	}
+	parsed, err := parseAssociationObj(coh.ObjectType, obj[4:])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if !reflect.DeepEqual(parsed.SRPolicy, a.SRPolicy) {
+		t.Errorf("expected %+v got %+v", a.SRPolicy, parsed.SRPolicy)
+	}
+	if parsed.Key() != a.Key() {
+		t.Errorf("expected key %s got %s", a.Key(), parsed.Key())
+	}
+
+	// color and endpoint are mandatory
+	a.SRPolicy = nil
+	obj, err = newAssociationObj(a)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	_, err = parseAssociationObj(1, obj[4:])
+	if err == nil {
+		t.Error("expected an error for SR Policy association without color and endpoint")
+	}
+}
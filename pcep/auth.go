@@ -0,0 +1,143 @@
+package pcep
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AuthType is TCP authentication used with a peer https://tools.ietf.org/html/rfc5440#section-10.2
+type AuthType string
+
+// TCP-MD5 https://tools.ietf.org/html/rfc2385
+// TCP-AO https://tools.ietf.org/html/rfc5925
+const (
+	AuthMD5 AuthType = "md5"
+	AuthAO  AuthType = "ao"
+)
+
+// both TCP-MD5 and TCP-AO keys are limited to 80 bytes by Linux
+const maxAuthKeyLen = 80
+
+// AuthKey is a per peer TCP authentication key
+type AuthKey struct {
+	Type AuthType
+	Key  string
+	// TCP-AO only, Algorithm defaults to hmac(sha1) which is HMAC-SHA-1-96
+	// https://tools.ietf.org/html/rfc5926#section-3.1
+	Algorithm string
+	SendID    uint8
+	RecvID    uint8
+}
+
+func (k *AuthKey) validate() error {
+	if k.Type != AuthMD5 && k.Type != AuthAO {
+		return fmt.Errorf("unknown auth type %q", k.Type)
+	}
+	if len(k.Key) == 0 || len(k.Key) > maxAuthKeyLen {
+		return fmt.Errorf("key len is %d but should be between 1 and %d", len(k.Key), maxAuthKeyLen)
+	}
+	return nil
+}
+
+// AuthStatsScopeHost means the counters are the host wide TcpExt ones
+const AuthStatsScopeHost = "host"
+
+// AuthStats are counters of TCP segments dropped by the kernel because
+// of failed authentication, they are not per peer as the kernel drops
+// segments before any connection is established, so they cover all TCP
+// sockets of the host and not only PCEP peers as told by Scope
+type AuthStats struct {
+	Scope         string
+	MD5NotFound   uint64
+	MD5Unexpected uint64
+	MD5Failure    uint64
+	AORequired    uint64
+	AOBad         uint64
+	AOKeyNotFound uint64
+}
+
+// listenerAuth keeps track of keys installed on the listening socket
+// so keys of removed or changed routers can be deleted, peers whose key
+// could not be installed are kept in failed so their connections are refused
+type listenerAuth struct {
+	sync.Mutex
+	ln        net.Listener
+	installed map[string]AuthKey
+	failed    map[string]error
+}
+
+func newListenerAuth(ln net.Listener) *listenerAuth {
+	return &listenerAuth{
+		ln:        ln,
+		installed: make(map[string]AuthKey),
+		failed:    make(map[string]error),
+	}
+}
+
+// refuse returns the reason a connection from peer must not be accepted,
+// as without its key the session would run unauthenticated
+func (l *listenerAuth) refuse(peer string) error {
+	defer l.Unlock()
+	l.Lock()
+	err, ok := l.failed[peer]
+	if !ok {
+		return nil
+	}
+	return fmt.Errorf("tcp auth key for %s is not installed: %w", peer, err)
+}
+
+// sync installs the keys configured for peers and removes the stale ones
+func (l *listenerAuth) sync(keys map[string]*AuthKey) {
+	defer l.Unlock()
+	l.Lock()
+	for peer := range l.failed {
+		if _, ok := keys[peer]; !ok {
+			delete(l.failed, peer)
+		}
+	}
+	for peer, key := range l.installed {
+		newKey, ok := keys[peer]
+		if ok && reflect.DeepEqual(*newKey, key) {
+			continue
+		}
+		err := delPeerAuth(l.ln, peer, &key)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"type": "err",
+				"func": "delPeerAuth",
+				"peer": peer,
+			}).Error(err)
+		}
+		delete(l.installed, peer)
+	}
+	for peer, key := range keys {
+		if _, ok := l.installed[peer]; ok {
+			continue
+		}
+		err := key.validate()
+		if err == nil {
+			err = setPeerAuth(l.ln, peer, key)
+		}
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"type":      "err",
+				"func":      "setPeerAuth",
+				"peer":      peer,
+				"auth_type": key.Type,
+			}).Error(err)
+			l.failed[peer] = err
+			continue
+		}
+		logrus.WithFields(logrus.Fields{
+			"type":      "auth",
+			"peer":      peer,
+			"auth_type": key.Type,
+		}).Info("installed tcp auth key")
+		l.installed[peer] = *key
+		delete(l.failed, peer)
+	}
+}
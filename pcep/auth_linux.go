@@ -0,0 +1,225 @@
+//go:build linux
+// +build linux
+
+package pcep
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// TCP-AO socket options https://docs.kernel.org/networking/tcp_ao.html
+// available since Linux 6.7
+const (
+	tcpAOAddKey = 38
+	tcpAODelKey = 39
+)
+
+// struct tcp_ao_add from linux/tcp.h
+type tcpAOAdd struct {
+	Addr      unix.SockaddrStorage
+	AlgName   [64]byte
+	Ifindex   int32
+	Flags     uint32
+	Reserved2 uint16
+	Prefix    uint8
+	SndID     uint8
+	RcvID     uint8
+	Maclen    uint8
+	Keyflags  uint8
+	Keylen    uint8
+	Key       [maxAuthKeyLen]byte
+}
+
+// struct tcp_ao_del from linux/tcp.h
+type tcpAODel struct {
+	Addr       unix.SockaddrStorage
+	Ifindex    int32
+	Flags      uint32
+	Reserved2  uint16
+	Prefix     uint8
+	SndID      uint8
+	RcvID      uint8
+	CurrentKey uint8
+	Rnext      uint8
+	Keyflags   uint8
+}
+
+// peerSockaddr fills in the peer address using the family of the listening
+// socket, IPv4 peers are IPv4-mapped when listening on a dual stack socket
+func peerSockaddr(fd int, peer string, sa *unix.SockaddrStorage) (uint8, error) {
+	ip := net.ParseIP(peer)
+	if ip == nil {
+		return 0, fmt.Errorf("invalid peer address %s", peer)
+	}
+	local, err := unix.Getsockname(fd)
+	if err != nil {
+		return 0, err
+	}
+	switch local.(type) {
+	case *unix.SockaddrInet4:
+		ip4 := ip.To4()
+		if ip4 == nil {
+			return 0, fmt.Errorf("IPv6 peer %s on IPv4 listener", peer)
+		}
+		sa4 := (*unix.RawSockaddrInet4)(unsafe.Pointer(sa))
+		sa4.Family = unix.AF_INET
+		copy(sa4.Addr[:], ip4)
+		return 32, nil
+	case *unix.SockaddrInet6:
+		sa6 := (*unix.RawSockaddrInet6)(unsafe.Pointer(sa))
+		sa6.Family = unix.AF_INET6
+		copy(sa6.Addr[:], ip.To16())
+		return 128, nil
+	default:
+		return 0, errors.New("listener is not an IP socket")
+	}
+}
+
+// setsockopt runs f on the file descriptor of the listening socket
+func setsockopt(ln net.Listener, f func(fd int) error) error {
+	sc, ok := ln.(syscall.Conn)
+	if !ok {
+		return errors.New("listener does not expose the socket")
+	}
+	rc, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var opErr error
+	err = rc.Control(func(fd uintptr) {
+		opErr = f(int(fd))
+	})
+	if err != nil {
+		return err
+	}
+	return opErr
+}
+
+func setMD5Sig(fd int, peer string, key string) error {
+	var sig unix.TCPMD5Sig
+	_, err := peerSockaddr(fd, peer, &sig.Addr)
+	if err != nil {
+		return err
+	}
+	// zero key length removes the key
+	sig.Keylen = uint16(len(key))
+	copy(sig.Key[:], key)
+	b := (*[unsafe.Sizeof(sig)]byte)(unsafe.Pointer(&sig))[:]
+	return unix.SetsockoptString(fd, unix.IPPROTO_TCP, unix.TCP_MD5SIG, string(b))
+}
+
+func setAOKey(fd int, peer string, key *AuthKey) error {
+	var add tcpAOAdd
+	prefix, err := peerSockaddr(fd, peer, &add.Addr)
+	if err != nil {
+		return err
+	}
+	alg := key.Algorithm
+	if alg == "" {
+		alg = "hmac(sha1)"
+	}
+	if len(alg) >= len(add.AlgName) {
+		return fmt.Errorf("algorithm name %s is too long", alg)
+	}
+	copy(add.AlgName[:], alg)
+	add.Prefix = prefix
+	add.SndID = key.SendID
+	add.RcvID = key.RecvID
+	add.Keylen = uint8(len(key.Key))
+	copy(add.Key[:], key.Key)
+	b := (*[unsafe.Sizeof(add)]byte)(unsafe.Pointer(&add))[:]
+	err = unix.SetsockoptString(fd, unix.IPPROTO_TCP, tcpAOAddKey, string(b))
+	if errors.Is(err, unix.ENOPROTOOPT) {
+		return errors.New("TCP-AO is not supported by the kernel")
+	}
+	return err
+}
+
+func delAOKey(fd int, peer string, key *AuthKey) error {
+	var del tcpAODel
+	prefix, err := peerSockaddr(fd, peer, &del.Addr)
+	if err != nil {
+		return err
+	}
+	del.Prefix = prefix
+	del.SndID = key.SendID
+	del.RcvID = key.RecvID
+	b := (*[unsafe.Sizeof(del)]byte)(unsafe.Pointer(&del))[:]
+	return unix.SetsockoptString(fd, unix.IPPROTO_TCP, tcpAODelKey, string(b))
+}
+
+func setPeerAuth(ln net.Listener, peer string, key *AuthKey) error {
+	return setsockopt(ln, func(fd int) error {
+		if key.Type == AuthAO {
+			return setAOKey(fd, peer, key)
+		}
+		return setMD5Sig(fd, peer, key.Key)
+	})
+}
+
+func delPeerAuth(ln net.Listener, peer string, key *AuthKey) error {
+	return setsockopt(ln, func(fd int) error {
+		if key.Type == AuthAO {
+			return delAOKey(fd, peer, key)
+		}
+		return setMD5Sig(fd, peer, "")
+	})
+}
+
+// GetAuthStats reads TCP authentication failures from the host wide TcpExt counters
+func GetAuthStats() (*AuthStats, error) {
+	f, err := os.Open("/proc/net/netstat")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// every section is a line with names followed by a line with values
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "TcpExt:" {
+			continue
+		}
+		if names == nil {
+			names = fields[1:]
+			continue
+		}
+		counters := make(map[string]uint64, len(names))
+		for i, v := range fields[1:] {
+			if i >= len(names) {
+				break
+			}
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			counters[names[i]] = n
+		}
+		// counters missing on older kernels stay zero
+		return &AuthStats{
+			Scope:         AuthStatsScopeHost,
+			MD5NotFound:   counters["TCPMD5NotFound"],
+			MD5Unexpected: counters["TCPMD5Unexpected"],
+			MD5Failure:    counters["TCPMD5Failure"],
+			AORequired:    counters["TCPAORequired"],
+			AOBad:         counters["TCPAOBad"],
+			AOKeyNotFound: counters["TCPAOKeyNotFound"],
+		}, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("TcpExt counters not found")
+}
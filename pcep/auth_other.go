@@ -0,0 +1,24 @@
+//go:build !linux
+// +build !linux
+
+package pcep
+
+import (
+	"errors"
+	"net"
+)
+
+var errAuthNotSupported = errors.New("TCP authentication is only supported on Linux")
+
+func setPeerAuth(ln net.Listener, peer string, key *AuthKey) error {
+	return errAuthNotSupported
+}
+
+func delPeerAuth(ln net.Listener, peer string, key *AuthKey) error {
+	return errAuthNotSupported
+}
+
+// GetAuthStats reads TCP authentication failures from the host wide TcpExt counters
+func GetAuthStats() (*AuthStats, error) {
+	return nil, errAuthNotSupported
+}
@@ -0,0 +1,82 @@
+package pcep
+
+import (
+	"net"
+	"testing"
+)
+
+func TestListenerAuthSync(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	defer ln.Close()
+	auth := newListenerAuth(ln)
+
+	md5 := &AuthKey{Type: AuthMD5, Key: "secret"}
+	if setPeerAuth(ln, "127.0.0.2", md5) != nil {
+		t.Skip("TCP-MD5 is not supported here")
+	}
+
+	// router added
+	auth.sync(map[string]*AuthKey{"127.0.0.2": md5})
+	if _, ok := auth.installed["127.0.0.2"]; !ok {
+		t.Error("expected key for 127.0.0.2 to be installed")
+	}
+	if err := auth.refuse("127.0.0.2"); err != nil {
+		t.Errorf("expected 127.0.0.2 to be accepted got %s", err.Error())
+	}
+
+	// router updated with a key which can not be installed
+	auth.sync(map[string]*AuthKey{"127.0.0.2": {Type: AuthMD5}})
+	if _, ok := auth.installed["127.0.0.2"]; ok {
+		t.Error("expected the old key for 127.0.0.2 to be removed")
+	}
+	if auth.refuse("127.0.0.2") == nil {
+		t.Error("expected 127.0.0.2 to be refused without its key")
+	}
+
+	// router updated again with a valid key
+	newKey := &AuthKey{Type: AuthMD5, Key: "new secret"}
+	auth.sync(map[string]*AuthKey{"127.0.0.2": newKey})
+	if k := auth.installed["127.0.0.2"]; k.Key != newKey.Key {
+		t.Errorf("expected key %q got %q", newKey.Key, k.Key)
+	}
+	if err := auth.refuse("127.0.0.2"); err != nil {
+		t.Errorf("expected 127.0.0.2 to be accepted got %s", err.Error())
+	}
+
+	// router deleted
+	auth.sync(map[string]*AuthKey{})
+	if len(auth.installed) != 0 {
+		t.Errorf("expected no keys got %v", auth.installed)
+	}
+	if err := auth.refuse("127.0.0.2"); err != nil {
+		t.Errorf("expected 127.0.0.2 to be accepted got %s", err.Error())
+	}
+}
+
+func TestListenerAuthFailsClosed(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	defer ln.Close()
+	auth := newListenerAuth(ln)
+
+	for peer, key := range map[string]*AuthKey{
+		// IPv6 peer on IPv4 listener
+		"2001:db8::1": {Type: AuthMD5, Key: "secret"},
+		"10.0.0.1":    {Type: "sha", Key: "secret"},
+		"10.0.0.2":    {Type: AuthAO},
+	} {
+		auth.sync(map[string]*AuthKey{peer: key})
+		if auth.refuse(peer) == nil {
+			t.Errorf("expected %s to be refused", peer)
+		}
+	}
+	// peers without keys are not affected
+	if err := auth.refuse("10.0.0.3"); err != nil {
+		t.Errorf("expected 10.0.0.3 to be accepted got %s", err.Error())
+	}
+}
@@ -80,6 +80,41 @@ func ipToUnit32(ipStr string) (uint32, error) {
 	return res, nil
 }
 
+// ipToBytes returns 4 bytes for IPv4 and 16 bytes for IPv6 addresses
+func ipToBytes(ipStr string) ([]byte, error) {
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return nil, fmt.Errorf("not a valid address %s", ipStr)
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return ipv4, nil
+	}
+	return ip, nil
+}
+
+func ipv6ToBytes(ipStr string) ([]byte, error) {
+	ip := net.ParseIP(ipStr)
+	if ip == nil || ip.To4() != nil {
+		return nil, fmt.Errorf("not a valid IPv6 address %s", ipStr)
+	}
+	return ip, nil
+}
+
+func bytesToIP(b []byte) string {
+	ip := make(net.IP, len(b))
+	copy(ip, b)
+	return ip.String()
+}
+
+// remoteIP strips the port from the peer address, IPv6 addresses are in brackets
+func remoteIP(conn net.Conn) string {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+	return host
+}
+
 func printCommonObjHdr(coh *CommonObjectHeader, msg string) {
 	logrus.WithFields(logrus.Fields{
 		"type": coh.ObjectType,
@@ -0,0 +1,139 @@
+package pcep
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// https://www.rfc-editor.org/rfc/rfc9604#section-4
+// Binding Type of the TE-PATH-BINDING TLV
+const (
+	BindingTypeMPLSLabel    uint8 = 0
+	BindingTypeMPLSLSE      uint8 = 1
+	BindingTypeSRv6SID      uint8 = 2
+	BindingTypeSRv6Behavior uint8 = 3
+)
+
+//    TE-PATH-BINDING TLV type is 55
+//     0                   1                   2                   3
+//     0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |             Type              |             Length            |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |      BT       |    Flags      |            Reserved           |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    ~            Binding Value (variable length)                    ~
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+//BindingSID TE-PATH-BINDING TLV https://www.rfc-editor.org/rfc/rfc9604#section-4
+type BindingSID struct {
+	Type uint8
+	// R flag the binding value is removed
+	Remove bool
+	// MPLS label for binding types 0 and 1
+	Label uint32
+	// SRv6 SID for binding types 2 and 3
+	SRv6SID      string
+	Behavior     uint16
+	SIDStructure *SRv6SIDStructure
+}
+
+// https://www.rfc-editor.org/rfc/rfc9604#section-4
+func newTEPathBindingTLV(b *BindingSID) ([]byte, error) {
+	var value []byte
+	switch b.Type {
+	case BindingTypeMPLSLabel:
+		if b.Label > 1048575 {
+			return nil, fmt.Errorf("binding SID label %d does not fit into 20 bits", b.Label)
+		}
+		// 20-bit label followed by 4 bits which must be zero
+		value = []byte{uint8(b.Label >> 12), uint8(b.Label >> 4), uint8(b.Label << 4)}
+	case BindingTypeMPLSLSE:
+		if b.Label > 1048575 {
+			return nil, fmt.Errorf("binding SID label %d does not fit into 20 bits", b.Label)
+		}
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, b.Label<<12)
+	case BindingTypeSRv6SID, BindingTypeSRv6Behavior:
+		sid, err := ipv6ToBytes(b.SRv6SID)
+		if err != nil {
+			return nil, err
+		}
+		value = sid
+		if b.Type == BindingTypeSRv6Behavior {
+			if b.SIDStructure == nil {
+				return nil, errors.New("SID structure is required for binding type 3")
+			}
+			value = append(value,
+				0, 0, uint8(b.Behavior>>8), uint8(b.Behavior),
+				b.SIDStructure.LBLength,
+				b.SIDStructure.LNLength,
+				b.SIDStructure.FunLength,
+				b.SIDStructure.ArgLength,
+			)
+		}
+	default:
+		return nil, fmt.Errorf("unknown binding type %d", b.Type)
+	}
+	var flags uint8
+	if b.Remove {
+		flags |= (1 << 7)
+	}
+	tlv := make([]byte, 8, 8+len(value)+3)
+	binary.BigEndian.PutUint16(tlv[0:2], 55)
+	binary.BigEndian.PutUint16(tlv[2:4], uint16(4+len(value)))
+	tlv[4] = b.Type
+	tlv[5] = flags
+	tlv = append(tlv, value...)
+	// TLVs are padded to 4-byte alignment
+	return append(tlv, make([]byte, (4-len(value)%4)%4)...), nil
+}
+
+// https://www.rfc-editor.org/rfc/rfc9604#section-4
+// value is the TLV without type and length
+func parseTEPathBindingTLV(value []byte) (*BindingSID, error) {
+	if len(value) < 4 {
+		return nil, fmt.Errorf("TE-PATH-BINDING TLV len is %d but should be at least 4", len(value))
+	}
+	b := &BindingSID{
+		Type: value[0],
+	}
+	var err error
+	b.Remove, err = uintToBool(readBits(value[1], 7))
+	if err != nil {
+		return nil, err
+	}
+	bv := value[4:]
+	expected := map[uint8]int{
+		BindingTypeMPLSLabel:    3,
+		BindingTypeMPLSLSE:      4,
+		BindingTypeSRv6SID:      16,
+		BindingTypeSRv6Behavior: 24,
+	}
+	l, ok := expected[b.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown binding type %d", b.Type)
+	}
+	if len(bv) != l {
+		return nil, fmt.Errorf("binding value len is %d but should be %d for binding type %d", len(bv), l, b.Type)
+	}
+	switch b.Type {
+	case BindingTypeMPLSLabel:
+		b.Label = uint32(bv[0])<<12 | uint32(bv[1])<<4 | uint32(bv[2])>>4
+	case BindingTypeMPLSLSE:
+		b.Label = binary.BigEndian.Uint32(bv) >> 12
+	case BindingTypeSRv6SID:
+		b.SRv6SID = bytesToIP(bv)
+	case BindingTypeSRv6Behavior:
+		b.SRv6SID = bytesToIP(bv[:16])
+		b.Behavior = binary.BigEndian.Uint16(bv[18:20])
+		b.SIDStructure = &SRv6SIDStructure{
+			LBLength:  bv[20],
+			LNLength:  bv[21],
+			FunLength: bv[22],
+			ArgLength: bv[23],
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,66 @@
+package pcep
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestTEPathBindingTLV(t *testing.T) {
+	for _, b := range []*BindingSID{
+		{Type: BindingTypeMPLSLabel, Label: 1000001},
+		{Type: BindingTypeMPLSLSE, Label: 24000, Remove: true},
+		{Type: BindingTypeSRv6SID, SRv6SID: "2001:db8::100"},
+		{
+			Type:         BindingTypeSRv6Behavior,
+			SRv6SID:      "2001:db8::100",
+			Behavior:     SRv6BehaviorEnd,
+			SIDStructure: &SRv6SIDStructure{LBLength: 32, LNLength: 16, FunLength: 16},
+		},
+	} {
+		tlv, err := newTEPathBindingTLV(b)
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		if len(tlv)%4 != 0 {
+			t.Fatalf("TLV must be padded to 4-byte alignment got %d bytes", len(tlv))
+		}
+		l := &LSP{}
+		err = l.parseLSPSubObj(tlv)
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		if len(l.BindingSIDs) != 1 || !reflect.DeepEqual(l.BindingSIDs[0], b) {
+			t.Errorf("expected %+v got %+v", b, l.BindingSIDs)
+		}
+	}
+	_, err := newTEPathBindingTLV(&BindingSID{Type: BindingTypeMPLSLabel, Label: 1 << 20})
+	if err == nil {
+		t.Errorf("labels longer than 20 bits must not be accepted")
+	}
+}
+
+func TestSRLSPBindingSID(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	s := NewSession(conn)
+	l := &SRLSP{Name: "lsp1", BindingSID: 15000}
+	bsid, err := l.newBindingSIDTLVs()
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	obj, err := s.newLSPObj(true, false, false, true, l.Name, 3, bsid...)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	var lsp LSP
+	err = lsp.parseLSPObj(obj[4:])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if lsp.Name != "lsp1" || len(lsp.BindingSIDs) != 1 || lsp.BindingSIDs[0].Label != 15000 {
+		t.Errorf("expected LSP lsp1 with binding SID 15000 got %s %+v", lsp.Name, lsp.BindingSIDs)
+	}
+}
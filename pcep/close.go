@@ -3,6 +3,8 @@ package pcep
 import (
 	"bytes"
 	"encoding/binary"
+
+	"github.com/sirupsen/logrus"
 )
 
 // https://tools.ietf.org/html/rfc5440#section-7.17
@@ -11,6 +13,8 @@ func parseClose(data []byte) uint8 {
 }
 
 // https://tools.ietf.org/html/rfc5440#section-7.17
+// CLOSE Object-Class is 15.
+// CLOSE Object-Type is 1.
 func newCloseObj(reason uint8) ([]byte, error) {
 	var (
 		reserved uint16
@@ -30,7 +34,7 @@ func newCloseObj(reason uint8) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return buf.Bytes(), nil
+	return newCommonObjHeader(15, 1, false, buf.Bytes())
 }
 
 func newCloseMsg(reason uint8) ([]byte, error) {
@@ -45,3 +49,21 @@ func newCloseMsg(reason uint8) ([]byte, error) {
 	}
 	return append(ch, closeObj...), nil
 }
+
+func (s *Session) sendClose(reason uint8) {
+	msg, err := newCloseMsg(reason)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"topic": "close msg creation failure",
+			"peer":  s.Conn.RemoteAddr().String(),
+		}).Error(err)
+		return
+	}
+	_, err = s.Conn.Write(msg)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"topic": "tcp write failure",
+			"peer":  s.Conn.RemoteAddr().String(),
+		}).Error(err)
+	}
+}
@@ -0,0 +1,27 @@
+package pcep
+
+import "testing"
+
+func TestNewCloseMsg(t *testing.T) {
+	msg, err := newCloseMsg(2)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	ch, err := parseCommonHeader(msg[:4])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if ch.MessageType != 7 || int(ch.MessageLength) != len(msg) || len(msg) != 12 {
+		t.Fatalf("wrong common header %+v for msg len %d", ch, len(msg))
+	}
+	coh, err := parseCommonObjectHeader(msg[4:8])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if coh.ObjectClass != 15 || coh.ObjectType != 1 || coh.ObjectLength != 8 {
+		t.Errorf("expected CLOSE object header got %+v", coh)
+	}
+	if reason := parseClose(msg[8:12]); reason != 2 {
+		t.Errorf("expected reason 2 got %d", reason)
+	}
+}
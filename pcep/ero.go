@@ -17,14 +17,21 @@ func parseERO(data []byte) ([]*SREROSub, error) {
 			e   SREROSub
 			err error
 		)
+		length := int(data[offset+1])
+		if length < 4 || offset+length > len(data) {
+			return nil, newPCEPErr(10, 11, fmt.Errorf("malformed SR-ERO subobject with length %d", length))
+		}
 		e.LooseHop, err = uintToBool(uint(data[offset]) >> 7)
 		if err != nil {
 			return nil, err
 		}
-		// now set loose hop bit to zero so we can determine type
-		data[offset] |= (0 << 7)
-		if data[offset] != 36 {
-			return nil, fmt.Errorf("wrong ero type %d", uint8(data[offset]))
+		// loose hop bit is ignored to determine type
+		if data[offset]&0x7f != 36 {
+			if len(eros) > 0 {
+				return nil, newPCEPErr(10, 5, fmt.Errorf("wrong ero type %d", uint8(data[offset])))
+			}
+			// only SR paths are supported
+			return nil, newPCEPErr(21, 1, fmt.Errorf("wrong ero type %d", uint8(data[offset])))
 		}
 		e.NT = data[offset+2] >> 4
 		e.NoNAI, err = uintToBool(readBits(data[offset+3], 3))
@@ -43,22 +50,28 @@ func parseERO(data []byte) ([]*SREROSub, error) {
 		if err != nil {
 			return nil, err
 		}
-		if e.NoSID {
-			err = parseNAI(data[offset+4:], &e)
+		if e.NoSID && e.NoNAI {
+			return nil, newPCEPErr(10, 6, errors.New("both SID and NAI are absent in SR-ERO subobject"))
+		}
+		body := data[offset+4 : offset+length]
+		if !e.NoSID {
+			if len(body) < 4 {
+				return nil, newPCEPErr(10, 11, errors.New("SR-ERO subobject is too short to carry SID"))
+			}
+			e.SID = binary.BigEndian.Uint32(body[:4])
+			if e.MBit {
+				e.SID = e.SID >> 12
+			}
+			body = body[4:]
+		}
+		if !e.NoNAI {
+			err = parseNAI(body, &e)
 			if err != nil {
 				return nil, err
 			}
 		}
-		e.SID = binary.BigEndian.Uint32(data[offset+4 : offset+8])
-		if e.MBit {
-			e.SID = e.SID >> 12
-		}
-		err = parseNAI(data[offset+8:], &e)
-		if err != nil {
-			return nil, err
-		}
 		eros = append(eros, &e)
-		offset = offset + int(data[offset+1])
+		offset = offset + length
 	}
 	return eros, nil
 }
@@ -66,10 +79,16 @@ func parseERO(data []byte) ([]*SREROSub, error) {
 func parseNAI(data []byte, ero *SREROSub) error {
 	switch ero.NT {
 	case 1:
+		if len(data) < 4 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 4", len(data)))
+		}
 		ip := make(net.IP, 4)
 		binary.BigEndian.PutUint32(ip, binary.BigEndian.Uint32(data[:4]))
 		ero.IPv4NodeID = ip.String()
 	case 3:
+		if len(data) < 8 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 8", len(data)))
+		}
 		localIP := make(net.IP, 4)
 		binary.BigEndian.PutUint32(localIP, binary.BigEndian.Uint32(data[:4]))
 		ero.IPv4Adjacency = make([]string, 2)
@@ -77,8 +96,31 @@ func parseNAI(data []byte, ero *SREROSub) error {
 		remoteIP := make(net.IP, 4)
 		binary.BigEndian.PutUint32(remoteIP, binary.BigEndian.Uint32(data[4:8]))
 		ero.IPv4Adjacency[1] = remoteIP.String()
+	case 2:
+		if len(data) < 16 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 16", len(data)))
+		}
+		ero.IPv6NodeID = bytesToIP(data[:16])
+	case 4:
+		if len(data) < 32 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 32", len(data)))
+		}
+		ero.IPv6Adjacency = []string{
+			0: bytesToIP(data[:16]),
+			1: bytesToIP(data[16:32]),
+		}
+	case 5:
+		if len(data) < 16 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 16", len(data)))
+		}
+		ero.UnnuV4Adj = UnnuAdjIPv4NodeIDs{
+			LocalNodeID:       bytesToIP(data[:4]),
+			LocalInterfaceID:  binary.BigEndian.Uint32(data[4:8]),
+			RemoteNodeID:      bytesToIP(data[8:12]),
+			RemoteInterfaceID: binary.BigEndian.Uint32(data[12:16]),
+		}
 	default:
-		return errors.New("NAI type not implemented yet")
+		return newPCEPErr(10, 13, errors.New("NAI type not implemented yet"))
 	}
 	return nil
 }
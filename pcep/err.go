@@ -87,6 +87,15 @@ func parseErrObj(data []byte) (*ErrObj, error) {
 			9:  "Reception of an invalid object MSD exceeds the default for the PCEP session",
 			10: "Reception of an invalid object RRO mixes SR-RRO subobjects with other subobject types",
 			11: "Reception of an invalid object Malformed object",
+			12: "Reception of an invalid object Missing PCE-SR-capability sub-TLV",
+			13: "Reception of an invalid object Unsupported NAI Type in the SR-ERO/SR-RRO subobject",
+			34: "Reception of an invalid object Missing PCE-SRv6-CAPABILITY sub-TLV",
+			35: "Reception of an invalid object Both SID and NAI are absent in the SRv6-RRO subobject",
+			36: "Reception of an invalid object RRO mixes SRv6-RRO subobjects with other subobject types",
+			37: "Reception of an invalid object Invalid SRv6 SID Structure",
+		},
+		11: {
+			0: "Unrecognized EXRS subobject",
 		},
 		19: {
 			1:  "Invalid Operation Attempted LSP Update Request for a non-delegated  LSP.  The PCEP-ERROR object is followed by the LSP object that identifies the LSP.",
@@ -117,6 +126,26 @@ func parseErrObj(data []byte) (*ErrObj, error) {
 			2: "LSP instantiation error Internal error",
 			3: "LSP instantiation error Signaling error",
 		},
+		25: {
+			1: "PCEP StartTLS failure Reception of StartTLS after any PCEP exchange",
+			2: "PCEP StartTLS failure Reception of any other message apart from StartTLS, Open, or PCErr",
+			3: "PCEP StartTLS failure Failure, connection without TLS is not possible",
+			4: "PCEP StartTLS failure Failure, connection without TLS is possible",
+			5: "PCEP StartTLS failure No StartTLS message (nor PCErr/Open) before StartTLSWait timer expiry",
+		},
+		26: {
+			1:  "Association Error Association Type is not supported",
+			2:  "Association Error Too many LSPs in the association group",
+			3:  "Association Error Too many association groups",
+			4:  "Association Error Association unknown",
+			5:  "Association Error Operator-configured association information mismatch",
+			6:  "Association Error Association information mismatch",
+			7:  "Association Error Cannot join the association group",
+			8:  "Association Error Association ID not in range",
+			9:  "Association Error Tunnel ID or End points mismatch for Path Protection Association",
+			10: "Association Error Attempt to add another working/protection LSP for Path Protection Association",
+			11: "Association Error Protection type is not supported",
+		},
 		// pcep_obj_trace: ERROR object: type: 24, value: 1
 	}
 	return &ErrObj{
@@ -128,20 +157,144 @@ func parseErrObj(data []byte) (*ErrObj, error) {
 	}, nil
 }
 
+// https://tools.ietf.org/html/rfc5440#section-7.15
+//    PCEP-ERROR Object-Class is 13.
+//    PCEP-ERROR Object-Type is 1.
+func newErrObj(errType, errValue uint8) ([]byte, error) {
+	body := []byte{
+		0: 0,
+		1: 0,
+		2: errType,
+		3: errValue,
+	}
+	return newCommonObjHeader(13, 1, true, body)
+}
+
+// https://tools.ietf.org/html/rfc5440#section-6.7
+// https://tools.ietf.org/html/rfc8231#section-6.3
+// Message-Type is 6.
+//    <PCErr Message> ::= <Common Header>
+//                      ( <error-obj-list> [<Open>] ) | <error>
+//                      [<error-list>]
+//    <error>::=[<request-id-list> | <stateful-request-id-list>]
+//               <error-obj-list>
+// objs are the offending objects as received, RP and SRP objects
+// identify the request and go first, LSP and OPEN objects follow the error
+func newErrMsg(errType, errValue uint8, objs ...[]byte) ([]byte, error) {
+	errObj, err := newErrObj(errType, errValue)
+	if err != nil {
+		return nil, err
+	}
+	body := make([]byte, 0)
+	trailing := make([]byte, 0)
+	for _, obj := range objs {
+		if len(obj) < 4 {
+			return nil, fmt.Errorf("offending object len is %d but should be at least 4", len(obj))
+		}
+		switch obj[0] {
+		case 2, 33:
+			body = append(body, obj...)
+		default:
+			trailing = append(trailing, obj...)
+		}
+	}
+	body = append(body, errObj...)
+	body = append(body, trailing...)
+	ch, err := newCommonHeader(6, uint16(len(body)))
+	if err != nil {
+		return nil, err
+	}
+	return append(ch, body...), nil
+}
+
+//pcepErr is a failure to be reported to the peer using PCErr
+type pcepErr struct {
+	ErrType  uint8
+	ErrValue uint8
+	// offending objects as received
+	Objs [][]byte
+	err  error
+}
+
+func newPCEPErr(errType, errValue uint8, err error, objs ...[]byte) *pcepErr {
+	return &pcepErr{
+		ErrType:  errType,
+		ErrValue: errValue,
+		Objs:     objs,
+		err:      err,
+	}
+}
+
+// withPCEPErr attaches type and value to err unless it was done by the parser
+func withPCEPErr(err error, errType, errValue uint8, objs ...[]byte) error {
+	var pe *pcepErr
+	if errors.As(err, &pe) {
+		return newPCEPErr(pe.ErrType, pe.ErrValue, pe.err, append(objs, pe.Objs...)...)
+	}
+	return newPCEPErr(errType, errValue, err, objs...)
+}
+
+func (e *pcepErr) Error() string {
+	return fmt.Sprintf("error type %d value %d: %s", e.ErrType, e.ErrValue, e.err.Error())
+}
+
+func (e *pcepErr) Unwrap() error {
+	return e.err
+}
+
+// reportErr sends PCErr for a failure returned by a parser, failures
+// with no type and value attached are reported as malformed objects
+func (s *Session) reportErr(err error, objs ...[]byte) {
+	var pe *pcepErr
+	if !errors.As(err, &pe) {
+		pe = newPCEPErr(10, 11, err)
+	}
+	s.sendErr(pe.ErrType, pe.ErrValue, append(objs, pe.Objs...)...)
+}
+
+func (s *Session) sendErr(errType, errValue uint8, objs ...[]byte) {
+	msg, err := newErrMsg(errType, errValue, objs...)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "newErrMsg",
+		}).Error(err)
+		return
+	}
+	_, err = s.Conn.Write(msg)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"topic": "tcp write failure",
+			"peer":  s.Conn.RemoteAddr().String(),
+		}).Error(err)
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"type":     "err",
+		"peer":     s.Conn.RemoteAddr().String(),
+		"errtype":  errType,
+		"errvalue": errValue,
+	}).Info("sent err msg")
+}
+
 //https://tools.ietf.org/html/rfc5440#section-7.15
-func (s *Session) handleErrObj(data []byte) {
-	var offset uint16
-	for (len(data) - int(offset)) > 4 {
+func (s *Session) handleErrObj(data []byte) []*ErrObj {
+	var offset int
+	errObjs := make([]*ErrObj, 0)
+	for (len(data) - offset) > 4 {
 		coh, err := parseCommonObjectHeader(data[offset : offset+4])
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"type": "err",
 				"func": "parseErrObj",
 			}).Error(err)
-			return
+			return errObjs
+		}
+		if coh.ObjectLength < 4 || offset+int(coh.ObjectLength) > len(data) {
+			return errObjs
 		}
 		if coh.ObjectClass != 13 {
-			offset = coh.ObjectLength
+			offset = offset + int(coh.ObjectLength)
 			logrus.WithFields(logrus.Fields{
 				"type":          coh.ObjectType,
 				"peer":          s.Conn.RemoteAddr().String(),
@@ -153,7 +306,7 @@ func (s *Session) handleErrObj(data []byte) {
 			}).Info("found obj in err msg")
 			continue
 		}
-		errObj, err := parseErrObj(data[offset:])
+		errObj, err := parseErrObj(data[offset : offset+int(coh.ObjectLength)])
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"type": "err",
@@ -169,7 +322,9 @@ func (s *Session) handleErrObj(data []byte) {
 				"errvalue":    errObj.ErrValue,
 				"errvaluestr": errObj.ErrValueStr,
 			}).Error("new err msg")
+			errObjs = append(errObjs, errObj)
 		}
-		offset = offset + coh.ObjectLength
+		offset = offset + int(coh.ObjectLength)
 	}
+	return errObjs
 }
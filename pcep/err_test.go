@@ -22,3 +22,39 @@ func TestParseErrObj(t *testing.T) {
 		t.Errorf("ErrValueStr must not be empty")
 	}
 }
+
+func TestNewErrMsg(t *testing.T) {
+	rp, err := newRPObj(&RPObject{RequestID: 7})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	open, err := newOpenObj(&OpenObject{Keepalive: 30, DeadTimer: 120}, &Capabilities{})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	msg, err := newErrMsg(6, 3, open, rp)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	ch, err := parseCommonHeader(msg[:4])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if ch.MessageType != 6 || int(ch.MessageLength) != len(msg) {
+		t.Fatalf("wrong common header %+v for msg len %d", ch, len(msg))
+	}
+	// the RP identifies the request so it has to come before the error
+	if msg[4] != 2 {
+		t.Errorf("expected RP object first got class %d", msg[4])
+	}
+	e, err := parseErrObj(msg[4+len(rp):])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if e.ErrType != 6 || e.ErrValue != 3 {
+		t.Errorf("expected error type 6 value 3 got %d %d", e.ErrType, e.ErrValue)
+	}
+	if msg[4+len(rp)+8] != 1 {
+		t.Errorf("expected OPEN object after the error got class %d", msg[4+len(rp)+8])
+	}
+}
@@ -0,0 +1,321 @@
+package pcep
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+//SessionState represents PCEP FSM state https://tools.ietf.org/html/rfc5440#appendix-A
+type SessionState int
+
+// PCEP FSM states, a PCE only accepts connections
+// so TCPPending is never used but kept to follow the RFC
+// StartTLSWait is added by https://tools.ietf.org/html/rfc8253#section-3.3
+const (
+	StateIdle SessionState = iota
+	StateTCPPending
+	StateOpenWait
+	StateKeepWait
+	StateUp
+	StateStartTLSWait
+)
+
+// https://tools.ietf.org/html/rfc5440#appendix-A
+// OpenWait and KeepWait timers have a fixed value of 60 seconds
+// StartTLSWait must not be less than OpenWait https://tools.ietf.org/html/rfc8253#section-3.3
+// they are only changed by tests
+var (
+	openWaitTimer     = 60 * time.Second
+	keepWaitTimer     = 60 * time.Second
+	startTLSWaitTimer = 60 * time.Second
+)
+
+var sessionStateNames = map[SessionState]string{
+	StateIdle:         "Idle",
+	StateTCPPending:   "TCPPending",
+	StateOpenWait:     "OpenWait",
+	StateKeepWait:     "KeepWait",
+	StateUp:           "Up",
+	StateStartTLSWait: "StartTLSWait",
+}
+
+func (st SessionState) String() string {
+	name, ok := sessionStateNames[st]
+	if !ok {
+		return fmt.Sprintf("Unknown(%d)", int(st))
+	}
+	return name
+}
+
+// MarshalText makes the state readable when sessions are exported as JSON
+func (st SessionState) MarshalText() ([]byte, error) {
+	return []byte(st.String()), nil
+}
+
+// GetState returns the current FSM state
+func (s *Session) GetState() SessionState {
+	defer s.RUnlock()
+
+	s.RLock()
+
+	return s.State
+}
+
+// setState must be called with the session lock held
+func (s *Session) setState(st SessionState) {
+	logrus.WithFields(logrus.Fields{
+		"type": "fsm",
+		"peer": s.Conn.RemoteAddr().String(),
+		"from": s.State.String(),
+		"to":   st.String(),
+	}).Info("session state change")
+	s.State = st
+}
+
+// startFSMTimer arms OpenWait, KeepWait or StartTLSWait timer, if the session
+// is still in the same state when the timer expires PCErr with the given
+// type and value is sent and the session is closed. Must be called with the session lock held
+func (s *Session) startFSMTimer(d time.Duration, st SessionState, errType, errValue uint8) {
+	s.stopFSMTimer()
+	s.fsmTimer = time.AfterFunc(d, func() {
+		if s.GetState() != st {
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"type":  "fsm",
+			"peer":  s.Conn.RemoteAddr().String(),
+			"state": st.String(),
+		}).Error("timer expired")
+		s.sendErr(errType, errValue)
+		s.closeSession(1)
+	})
+}
+
+// stopFSMTimer must be called with the session lock held
+func (s *Session) stopFSMTimer() {
+	if s.fsmTimer != nil {
+		s.fsmTimer.Stop()
+		s.fsmTimer = nil
+	}
+}
+
+// startFSM is called once TCP connection is accepted
+// we send our Open straight away and wait for the one from the PCC
+func (s *Session) startFSM() {
+	s.SendSessionOpen()
+
+	s.Lock()
+	s.setState(StateOpenWait)
+	s.startFSMTimer(openWaitTimer, StateOpenWait, 1, 2)
+	s.Unlock()
+}
+
+// closeSession sends Close and tears down the TCP connection
+// which in turn stops the read loop and releases the session
+func (s *Session) closeSession(reason uint8) {
+	s.sendClose(reason)
+
+	s.Lock()
+	s.stopFSMTimer()
+	if s.overloadTimer != nil {
+		s.overloadTimer.Stop()
+	}
+	s.setState(StateIdle)
+	s.Unlock()
+
+	err := s.Conn.Close()
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"topic": "tcp conn close failure",
+			"peer":  s.Conn.RemoteAddr().String(),
+		}).Error(err)
+	}
+}
+
+// msgAllowed enforces the message order of the FSM
+func (s *Session) msgAllowed(msgType uint8) bool {
+	defer s.RUnlock()
+
+	s.RLock()
+
+	// Close is accepted in any state
+	if msgType == 7 {
+		return true
+	}
+	switch s.State {
+	case StateOpenWait:
+		// Keepalive acknowledging our Open can arrive
+		// while we wait for a second Open from the peer
+		if msgType == 2 {
+			return s.openRetry > 0 && !s.LocalOK
+		}
+		return msgType == 1
+	case StateKeepWait:
+		// a new Open is expected after we rejected the first one
+		if msgType == 1 {
+			return !s.RemoteOK
+		}
+		return msgType == 2 || msgType == 6
+	case StateUp:
+		// no second Open once the session is up
+		return msgType != 1
+	default:
+		return false
+	}
+}
+
+// handleOpen processes Open received in OpenWait or KeepWait state
+func (s *Session) handleOpen(data []byte) {
+	err := s.ProcessOpen(data)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "fsm",
+			"peer": s.Conn.RemoteAddr().String(),
+		}).Error(err)
+		// reception of an invalid Open message
+		s.sendErr(1, 1)
+		s.closeSession(1)
+		return
+	}
+
+	s.Lock()
+	proposal := s.cfg.negotiate(s.Open)
+	if proposal != nil {
+		s.openRetry++
+		retry := s.openRetry
+		ka, dt := s.Open.Keepalive, s.Open.DeadTimer
+		if retry == 1 {
+			// the peer gets one more chance to send an acceptable Open
+			s.setState(StateOpenWait)
+			s.startFSMTimer(openWaitTimer, StateOpenWait, 1, 2)
+		}
+		s.Unlock()
+		logrus.WithFields(logrus.Fields{
+			"type":      "fsm",
+			"peer":      s.Conn.RemoteAddr().String(),
+			"keepalive": ka,
+			"deadtimer": dt,
+			"retry":     retry,
+		}).Error("unacceptable session characteristics")
+		if retry > 1 {
+			// reception of a second Open message with still unacceptable session characteristics
+			s.sendErr(1, 5)
+			s.closeSession(1)
+			return
+		}
+		s.sendOpenErr(proposal)
+		return
+	}
+	startKA := !s.RemoteOK
+	s.RemoteOK = true
+	up := s.LocalOK
+	if up {
+		s.stopFSMTimer()
+		s.setState(StateUp)
+	} else {
+		s.setState(StateKeepWait)
+		s.startFSMTimer(keepWaitTimer, StateKeepWait, 1, 7)
+	}
+	s.Unlock()
+
+	// sending keepalive acknowledges the Open and starts the keepalive timer
+	if startKA {
+		go s.StartKeepAlive()
+		go s.HandleDeadTimer()
+	}
+	if up {
+		s.startSync()
+		s.SessionReady <- true
+	}
+}
+
+// handleKeepalive moves the session forward while
+// in KeepWait and feeds the dead timer
+func (s *Session) handleKeepalive() {
+	s.Lock()
+	remoteOK := s.RemoteOK
+	var up bool
+	if s.State == StateKeepWait {
+		s.LocalOK = true
+		if remoteOK {
+			s.stopFSMTimer()
+			s.setState(StateUp)
+			up = true
+		} else {
+			s.setState(StateOpenWait)
+			s.startFSMTimer(openWaitTimer, StateOpenWait, 1, 2)
+		}
+	} else if s.State == StateOpenWait {
+		// our Open was acknowledged before the second Open from the peer
+		s.LocalOK = true
+	}
+	s.Unlock()
+
+	// dead timer is only running after an Open has been accepted
+	if remoteOK {
+		s.RcvKA <- true
+	}
+	if up {
+		s.startSync()
+		s.SessionReady <- true
+	}
+}
+
+// handleOpenErr processes PCErr received while in KeepWait, if the peer
+// proposes acceptable timers in reply to our Open a second Open is sent
+// https://tools.ietf.org/html/rfc5440#section-6.2
+func (s *Session) handleOpenErr(data []byte) {
+	errObjs := s.handleErrObj(data)
+	if s.GetState() != StateKeepWait {
+		return
+	}
+	for _, e := range errObjs {
+		if e.ErrType != 1 {
+			continue
+		}
+		proposal := findOpenObj(data)
+
+		s.Lock()
+		s.localRetry++
+		ok := e.ErrValue == 4 && proposal != nil &&
+			s.localRetry == 1 && s.cfg.negotiate(proposal) == nil
+		if ok {
+			s.Keepalive = proposal.Keepalive
+			s.DeadTimer = proposal.DeadTimer
+			s.startFSMTimer(keepWaitTimer, StateKeepWait, 1, 7)
+		}
+		s.Unlock()
+
+		if !ok {
+			// reception of a PCErr message proposing unacceptable session characteristics
+			s.sendErr(1, 6)
+			s.closeSession(1)
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"type":      "fsm",
+			"peer":      s.Conn.RemoteAddr().String(),
+			"keepalive": proposal.Keepalive,
+			"deadtimer": proposal.DeadTimer,
+		}).Info("accepted session characteristics proposed by peer")
+		s.SendSessionOpen()
+		return
+	}
+}
+
+// sendOpenErr rejects the Open from the peer with PCErr type 1 value 4
+// carrying an OPEN object with the timers acceptable to us
+func (s *Session) sendOpenErr(proposal *OpenObject) {
+	// capabilities are not subject to negotiation only timers are proposed
+	openObj, err := newOpenObj(proposal, &Capabilities{})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "newOpenObj",
+		}).Error(err)
+		return
+	}
+	s.sendErr(1, 4, openObj)
+}
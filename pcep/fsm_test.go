@@ -0,0 +1,225 @@
+package pcep
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// newTestSession returns a session over a pipe together with
+// the messages it sends, the channel is closed once the session
+// closes the connection
+func newTestSession(t *testing.T) (*Session, <-chan []byte) {
+	conn, peer := net.Pipe()
+	t.Cleanup(func() {
+		conn.Close()
+		peer.Close()
+	})
+	cfg := &Cfg{MinKeepalive: 10, MaxKeepalive: 60}
+	cfg.setDefaults()
+	s := NewSession(conn)
+	s.cfg = cfg
+
+	msgs := make(chan []byte, 10)
+	go func() {
+		defer close(msgs)
+		r := newMsgReader(peer)
+		for {
+			msg, err := r.readMsg()
+			if err != nil {
+				return
+			}
+			msgs <- msg
+		}
+	}()
+	return s, msgs
+}
+
+// expectErr reads PCErr and checks its type and value
+func expectErr(t *testing.T, msgs <-chan []byte, errType, errValue uint8) {
+	t.Helper()
+	select {
+	case msg, ok := <-msgs:
+		if !ok {
+			t.Fatalf("expected PCErr %d/%d got connection closed", errType, errValue)
+		}
+		if msg[1] != 6 {
+			t.Fatalf("expected PCErr got msg type %d", msg[1])
+		}
+		e, err := parseErrObj(msg[4:])
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		if e.ErrType != errType || e.ErrValue != errValue {
+			t.Errorf("expected PCErr %d/%d got %d/%d", errType, errValue, e.ErrType, e.ErrValue)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected PCErr %d/%d got nothing", errType, errValue)
+	}
+}
+
+// expectClosed reads Close and waits for the connection to be closed
+func expectClosed(t *testing.T, s *Session, msgs <-chan []byte) {
+	t.Helper()
+	select {
+	case msg := <-msgs:
+		if msg == nil || msg[1] != 7 {
+			t.Fatalf("expected Close got %v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Close got nothing")
+	}
+	select {
+	case _, ok := <-msgs:
+		if ok {
+			t.Fatal("expected no msgs after Close")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the connection to be closed")
+	}
+	if s.GetState() != StateIdle {
+		t.Errorf("expected Idle state got %s", s.GetState())
+	}
+}
+
+func TestMsgAllowed(t *testing.T) {
+	for _, c := range []struct {
+		state     SessionState
+		openRetry uint8
+		localOK   bool
+		remoteOK  bool
+		msgType   uint8
+		allowed   bool
+	}{
+		{state: StateOpenWait, msgType: 1, allowed: true},
+		{state: StateOpenWait, msgType: 2, allowed: false},
+		{state: StateOpenWait, msgType: 2, openRetry: 1, allowed: true},
+		{state: StateOpenWait, msgType: 2, openRetry: 1, localOK: true, allowed: false},
+		{state: StateOpenWait, msgType: 10, allowed: false},
+		{state: StateKeepWait, msgType: 2, allowed: true},
+		{state: StateKeepWait, msgType: 6, allowed: true},
+		{state: StateKeepWait, msgType: 1, allowed: true},
+		{state: StateKeepWait, msgType: 1, remoteOK: true, allowed: false},
+		{state: StateKeepWait, msgType: 10, allowed: false},
+		{state: StateUp, msgType: 1, allowed: false},
+		{state: StateUp, msgType: 10, allowed: true},
+		{state: StateIdle, msgType: 2, allowed: false},
+		{state: StateIdle, msgType: 7, allowed: true},
+	} {
+		s := NewSession(nil)
+		s.State = c.state
+		s.openRetry = c.openRetry
+		s.LocalOK = c.localOK
+		s.RemoteOK = c.remoteOK
+		if s.msgAllowed(c.msgType) != c.allowed {
+			t.Errorf("msg type %d in %s with %+v must be allowed: %t", c.msgType, c.state, c, c.allowed)
+		}
+	}
+}
+
+func TestHandleKeepaliveTransitions(t *testing.T) {
+	for _, c := range []struct {
+		from SessionState
+		to   SessionState
+	}{
+		// our Open is acknowledged but the one from the peer is still to come
+		{from: StateKeepWait, to: StateOpenWait},
+		{from: StateOpenWait, to: StateOpenWait},
+	} {
+		s, _ := newTestSession(t)
+		s.State = c.from
+		s.handleKeepalive()
+		if s.GetState() != c.to || !s.LocalOK {
+			t.Errorf("keepalive in %s must move to %s with LocalOK got %s %t", c.from, c.to, s.GetState(), s.LocalOK)
+		}
+		s.Lock()
+		s.stopFSMTimer()
+		s.Unlock()
+	}
+}
+
+func TestFSMTimerExpiry(t *testing.T) {
+	for _, c := range []struct {
+		state    SessionState
+		errValue uint8
+	}{
+		{state: StateOpenWait, errValue: 2},
+		{state: StateKeepWait, errValue: 7},
+	} {
+		s, msgs := newTestSession(t)
+		s.Lock()
+		s.State = c.state
+		s.startFSMTimer(10*time.Millisecond, c.state, 1, c.errValue)
+		s.Unlock()
+		expectErr(t, msgs, 1, c.errValue)
+		expectClosed(t, s, msgs)
+	}
+
+	// the timer does nothing once the session moved on
+	s, msgs := newTestSession(t)
+	s.Lock()
+	s.State = StateOpenWait
+	s.startFSMTimer(10*time.Millisecond, StateOpenWait, 1, 2)
+	s.State = StateUp
+	s.Unlock()
+	select {
+	case msg := <-msgs:
+		t.Errorf("expected no msgs got %v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHandleOpenErrors(t *testing.T) {
+	// invalid Open closes the session
+	s, msgs := newTestSession(t)
+	s.State = StateOpenWait
+	go s.handleOpen([]byte{0, 0, 0, 4})
+	expectErr(t, msgs, 1, 1)
+	expectClosed(t, s, msgs)
+
+	// unacceptable timers are rejected with a proposal once
+	// and the session is closed when the second Open has them too
+	s, msgs = newTestSession(t)
+	s.State = StateOpenWait
+	open, err := newOpenObj(&OpenObject{Keepalive: 5, DeadTimer: 20}, &Capabilities{})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	go s.handleOpen(open)
+	expectErr(t, msgs, 1, 4)
+	if s.GetState() != StateOpenWait {
+		t.Errorf("expected OpenWait state got %s", s.GetState())
+	}
+	go s.handleOpen(open)
+	expectErr(t, msgs, 1, 5)
+	expectClosed(t, s, msgs)
+
+	// PCErr proposing unacceptable timers in KeepWait closes the session
+	s, msgs = newTestSession(t)
+	s.State = StateKeepWait
+	pcErr, err := newErrMsg(1, 4, open)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	go s.handleOpenErr(pcErr[4:])
+	expectErr(t, msgs, 1, 6)
+	expectClosed(t, s, msgs)
+}
+
+func TestHandleNewMsgMissingObject(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		msg  []byte
+	}{
+		{name: "PCErr without PCEP-ERROR object", msg: []byte{0x20, 6, 0, 4}},
+		{name: "Close without CLOSE object", msg: []byte{0x20, 7, 0, 4}},
+	} {
+		s, msgs := newTestSession(t)
+		s.State = StateUp
+		go s.HandleNewMsg(c.msg)
+		expectErr(t, msgs, 10, 11)
+		if s.GetState() != StateUp {
+			t.Errorf("%s: expected Up state got %s", c.name, s.GetState())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package pcep
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math"
@@ -11,7 +12,7 @@ import (
 
 // InitLSP aaaa
 func (s *Session) InitLSP(l *LSP) error {
-	sro, err := s.newSRPObject(false)
+	sro, err := s.newSRPObject(false, PSTRSVPTE)
 	if err != nil {
 		return err
 	}
@@ -32,7 +33,14 @@ func (s *Session) InitLSP(l *LSP) error {
 		"event": "create_ero",
 	}).Infof("ero %d bin string %08b \n", len(ero), ero)
 
-	lspa, err := newLSPAObject(l.SetupPrio, l.HoldPrio, l.LocalProtect)
+	lspa, err := newLSPAObject(&LSPAObject{
+		ExcludeAny:   l.ExcludeAny,
+		IncludeAny:   l.IncludeAny,
+		IncludeAll:   l.IncludeAll,
+		SetupPrio:    l.SetupPrio,
+		HoldPrio:     l.HoldPrio,
+		LocalProtect: l.LocalProtect,
+	})
 	if err != nil {
 		return err
 	}
@@ -92,6 +100,8 @@ type LSP struct {
 	EROList      []EROSub
 	SREROList    []*SREROSub
 	SRRROList    []*SRRROSub
+	SRv6EROList  []*SRv6EROSub
+	SRv6RROList  []*SRv6EROSub
 	SetupPrio    uint8
 	HoldPrio     uint8
 	LocalProtect bool
@@ -104,10 +114,17 @@ type LSP struct {
 	ExcludeAny   uint32
 	IncludeAny   uint32
 	IncludeAll   uint32
+	DBVersion    uint64
+	Metrics      []*LSPMetric
+	BindingSIDs  []*BindingSID
+	Associations []*Association
 }
 
 //https://tools.ietf.org/html/rfc8231#section-7.3
 func (l *LSP) parseLSPObj(data []byte) error {
+	if len(data) < 4 {
+		return fmt.Errorf("data len is %d but should be at least 4", len(data))
+	}
 	var err error
 	l.Delegate, err = uintToBool(readBits(data[3], 0))
 	if err != nil {
@@ -134,8 +151,7 @@ func (l *LSP) parseLSPObj(data []byte) error {
 	// if l.PLSPID == 0 {
 	// 	return fmt.Errorf("PLSPID has a 0 value which mus not be used")
 	// }
-	l.parseLSPSubObj(data[4:])
-	return nil
+	return l.parseLSPSubObj(data[4:])
 }
 
 func (l *LSP) parseLSPSubObj(data []byte) error {
@@ -147,6 +163,9 @@ func (l *LSP) parseLSPSubObj(data []byte) error {
 	// +4 is needed because obj header is not included into length
 	for (len(data) - int(offset)) > 4 {
 		counter++
+		if int(offset)+4+int(binary.BigEndian.Uint16(data[offset+2:offset+4])) > len(data) {
+			return fmt.Errorf("malformed TLV type %d in LSP object", binary.BigEndian.Uint16(data[offset:offset+2]))
+		}
 		switch binary.BigEndian.Uint16(data[offset : offset+2]) {
 		case 18:
 			l.IPv4ID, err = parseLSPIPv4Identifiers(data[offset:])
@@ -162,13 +181,35 @@ func (l *LSP) parseLSPSubObj(data []byte) error {
 			if err != nil {
 				return err
 			}
+			l.Src = bytesToIP(data[offset+4 : offset+20])
+			l.Dst = bytesToIP(data[offset+40 : offset+56])
 			offset = offset + binary.BigEndian.Uint16(data[offset+2:offset+4]) + 4
 			continue
+		// https://tools.ietf.org/html/rfc8232#section-3.3.1
+		case 23:
+			l.DBVersion, err = parseDBVersionTLV(data[offset:])
+			if err != nil {
+				return err
+			}
+			offset = offset + binary.BigEndian.Uint16(data[offset+2:offset+4]) + 4
+			continue
+		// https://www.rfc-editor.org/rfc/rfc9604#section-4
+		case 55:
+			length := binary.BigEndian.Uint16(data[offset+2 : offset+4])
+			bsid, err := parseTEPathBindingTLV(data[offset+4 : offset+4+length])
+			if err != nil {
+				return err
+			}
+			l.BindingSIDs = append(l.BindingSIDs, bsid)
+			// TLVs are padded to 4-byte alignment
+			offset = offset + ((length + 3) &^ 3) + 4
+			continue
 		// https://tools.ietf.org/html/rfc8231#section-7.3.2
 		case 17:
 			length := binary.BigEndian.Uint16(data[offset+2 : offset+4])
 			l.Name = string(data[offset+4 : offset+4+length])
-			offset = offset + (length + (4 - (length % 4))) + 4
+			// TLVs are padded to 4-byte alignment
+			offset = offset + ((length + 3) &^ 3) + 4
 			continue
 		default:
 			logrus.WithFields(logrus.Fields{
@@ -221,8 +262,9 @@ type LSPIPv6Identifiers struct {
 
 // https://tools.ietf.org/html/rfc8231#section-7.3.1
 func parseLSPIPv6Identifiers(data []byte) (*LSPIPv6Identifiers, error) {
-	if len(data) < 52 {
-		return nil, fmt.Errorf("data len is %d but should be 52", len(data))
+	// 4 bytes of TLV header and 52 bytes of value
+	if len(data) < 56 {
+		return nil, fmt.Errorf("data len is %d but should be 56", len(data))
 	}
 	return &LSPIPv6Identifiers{
 		Type:             binary.BigEndian.Uint16(data[:2]),
@@ -235,31 +277,101 @@ func parseLSPIPv6Identifiers(data []byte) (*LSPIPv6Identifiers, error) {
 	}, nil
 }
 
+//LSPAObject https://tools.ietf.org/html/rfc5440#section-7.11
+type LSPAObject struct {
+	ExcludeAny   uint32
+	IncludeAny   uint32
+	IncludeAll   uint32
+	SetupPrio    uint8
+	HoldPrio     uint8
+	LocalProtect bool
+}
+
 // https://tools.ietf.org/html/rfc5440#section-7.11
-func (l *LSP) parseLSPAObj(data []byte) error {
+func parseLSPAObject(data []byte) (*LSPAObject, error) {
 	if len(data) < 16 {
-		return fmt.Errorf("data len is %d but should be 16", len(data))
+		return nil, fmt.Errorf("data len is %d but should be 16", len(data))
 	}
-	l.ExcludeAny = binary.BigEndian.Uint32(data[:4])
-	l.IncludeAny = binary.BigEndian.Uint32(data[4:8])
-	l.IncludeAll = binary.BigEndian.Uint32(data[8:12])
-	l.SetupPrio = data[12]
-	l.HoldPrio = data[13]
-	var err error
-	l.LocalProtect, err = uintToBool(readBits(data[14], 0))
+	localProtect, err := uintToBool(readBits(data[14], 0))
+	if err != nil {
+		return nil, err
+	}
+	return &LSPAObject{
+		ExcludeAny:   binary.BigEndian.Uint32(data[:4]),
+		IncludeAny:   binary.BigEndian.Uint32(data[4:8]),
+		IncludeAll:   binary.BigEndian.Uint32(data[8:12]),
+		SetupPrio:    data[12],
+		HoldPrio:     data[13],
+		LocalProtect: localProtect,
+	}, nil
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.11
+func (l *LSP) parseLSPAObj(data []byte) error {
+	lspa, err := parseLSPAObject(data)
 	if err != nil {
 		return err
 	}
+	l.ExcludeAny = lspa.ExcludeAny
+	l.IncludeAny = lspa.IncludeAny
+	l.IncludeAll = lspa.IncludeAll
+	l.SetupPrio = lspa.SetupPrio
+	l.HoldPrio = lspa.HoldPrio
+	l.LocalProtect = lspa.LocalProtect
 	return nil
 }
 
-// LSPMetric s
+// https://www.iana.org/assignments/pcep/pcep.xhtml#metric-object-t-field
+// METRIC Object T field
+const (
+	MetricTypeIGP       uint8 = 1
+	MetricTypeTE        uint8 = 2
+	MetricTypeHopCount  uint8 = 3
+	MetricTypePathDelay uint8 = 12
+)
+
+//LSPMetric https://tools.ietf.org/html/rfc5440#section-7.8
 type LSPMetric struct {
-	CFlaf  bool
+	// C flag the metric value is the computed cost of the path
+	CFlag bool
+	// B flag the metric value is a bound the path must not exceed
 	BFlag  bool
+	Type   uint8
 	Metric float32
 }
 
+// https://tools.ietf.org/html/rfc5440#section-7.8
+//     0                   1                   2                   3
+//     0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |          Reserved             |    Flags  |C|B|       T       |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |                          metric-value                         |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// METRIC Object-Class is 6.
+// METRIC Object-Type is 1.
+func newMetricObj(m *LSPMetric) ([]byte, error) {
+	var flags uint8
+	if m.BFlag {
+		flags |= (1 << 0)
+	}
+	if m.CFlag {
+		flags |= (1 << 1)
+	}
+	body := []byte{
+		0: 0,
+		1: 0,
+		2: flags,
+		3: m.Type,
+	}
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.BigEndian, math.Float32bits(m.Metric))
+	if err != nil {
+		return nil, err
+	}
+	return newCommonObjHeader(6, 1, true, append(body, buf.Bytes()...))
+}
+
 func parseMetric(data []byte) (*LSPMetric, error) {
 	if len(data) < 8 {
 		return nil, fmt.Errorf("data len is %d but should be 8", len(data))
@@ -268,7 +380,7 @@ func parseMetric(data []byte) (*LSPMetric, error) {
 		m   LSPMetric
 		err error
 	)
-	m.CFlaf, err = uintToBool(readBits(data[2], 1))
+	m.CFlag, err = uintToBool(readBits(data[2], 1))
 	if err != nil {
 		return nil, err
 	}
@@ -276,6 +388,7 @@ func parseMetric(data []byte) (*LSPMetric, error) {
 	if err != nil {
 		return nil, err
 	}
+	m.Type = data[3]
 	u := binary.BigEndian.Uint32(data[4:8])
 	m.Metric = math.Float32frombits(u)
 	return &m, nil
@@ -0,0 +1,288 @@
+package pcep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+//LSPDB is the LSP state database of a PCC kept across sessions
+// to avoid state synchronization https://tools.ietf.org/html/rfc8232#section-3
+type LSPDB struct {
+	// SPEAKER-ENTITY-ID of the PCC or its address if it did not send one
+	SpeakerEntityID string
+	Addr            string
+	Version         uint64
+	LSPs            map[string]*LSP
+}
+
+// Key returns the key the LSP-DB is stored under
+func (db *LSPDB) Key() string {
+	if db.SpeakerEntityID != "" {
+		return db.SpeakerEntityID
+	}
+	return db.Addr
+}
+
+// https://tools.ietf.org/html/rfc8232#section-3.3.1
+// LSP-DB-VERSION TLV type is 23 and length is 8
+func newDBVersionTLV(version uint64) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	for _, v := range []interface{}{uint16(23), uint16(8), version} {
+		err := binary.Write(buf, binary.BigEndian, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// https://tools.ietf.org/html/rfc8232#section-3.3.1
+func parseDBVersionTLV(tlv []byte) (uint64, error) {
+	if len(tlv) < 12 {
+		return 0, fmt.Errorf("LSP-DB-VERSION TLV len is %d but should be 12", len(tlv))
+	}
+	return binary.BigEndian.Uint64(tlv[4:12]), nil
+}
+
+// https://tools.ietf.org/html/rfc8232#section-4.1.1
+// SPEAKER-ENTITY-ID TLV type is 24 and the ID has variable length
+func parseSpeakerEntityIDTLV(tlv []byte) (string, error) {
+	if len(tlv) < 5 {
+		return "", errors.New("SPEAKER-ENTITY-ID TLV must not be empty")
+	}
+	return string(tlv[4:]), nil
+}
+
+// dbVersionNegotiated is true when both sides set the S flag
+// must be called with the session lock held
+func (s *Session) dbVersionNegotiated() bool {
+	return s.LocalCaps.DBVersion && s.StatefulCap != nil && s.StatefulCap.IncludeDBVersion
+}
+
+// triggeredInitialSync is true when both sides set the F flag
+// must be called with the session lock held
+func (s *Session) triggeredInitialSync() bool {
+	return s.LocalCaps.TriggeredInitialSync && s.StatefulCap != nil && s.StatefulCap.TriggeredInitialSync
+}
+
+// deltaSyncNegotiated is true when both sides set the D flag
+// on top of the S flag, must be called with the session lock held
+func (s *Session) deltaSyncNegotiated() bool {
+	return s.dbVersionNegotiated() && s.LocalCaps.DeltaSync && s.StatefulCap.DeltaLSPSyncCap
+}
+
+// localDBVersion is the version of the LSP-DB we hold for
+// the PCC which is advertised in our Open, zero means none
+// must be called with the session lock held
+func (s *Session) localDBVersion() uint64 {
+	if !s.LocalCaps.DBVersion || s.storedDB == nil {
+		return 0
+	}
+	return s.storedDB.Version
+}
+
+// avoidSync restores the LSP-DB stored from the previous session when the
+// versions advertised by both sides match https://tools.ietf.org/html/rfc8232#section-3.2
+// must be called with the session lock held
+func (s *Session) avoidSync() bool {
+	if !s.dbVersionNegotiated() || !s.validStoredDB() {
+		return false
+	}
+	if s.storedDB.Version != s.LSPDBVersion {
+		return false
+	}
+	s.restoreLSPDB()
+	return true
+}
+
+// startDeltaSync restores the LSP-DB stored from the previous session
+// so the PCC only has to report the LSPs which changed since then
+// https://tools.ietf.org/html/rfc8232#section-5
+// must be called with the session lock held
+func (s *Session) startDeltaSync() bool {
+	if !s.deltaSyncNegotiated() || !s.validStoredDB() {
+		return false
+	}
+	// a PCC behind our version lost its LSP-DB and does full sync
+	if s.LSPDBVersion <= s.storedDB.Version {
+		return false
+	}
+	s.restoreLSPDB()
+	return true
+}
+
+// loadStoredDB looks up the LSP-DB by the SPEAKER-ENTITY-ID of the PCC
+// as the one found by its address may belong to another PCC or the PCC
+// may have connected from a different address before
+func (s *Session) loadStoredDB() {
+	s.RLock()
+	id := s.SpeakerEntityID
+	load := s.controller != nil && s.LocalCaps.DBVersion && id != "" &&
+		(s.storedDB == nil || s.storedDB.SpeakerEntityID != id)
+	s.RUnlock()
+	if !load {
+		return
+	}
+	db := s.controller.GetLSPDB(id)
+	s.Lock()
+	s.storedDB = db
+	s.Unlock()
+}
+
+// validStoredDB is true when the stored LSP-DB belongs to the PCC
+// must be called with the session lock held
+func (s *Session) validStoredDB() bool {
+	db := s.storedDB
+	return db != nil && db.Version != 0 && db.SpeakerEntityID == s.SpeakerEntityID
+}
+
+// must be called with the session lock held
+func (s *Session) restoreLSPDB() {
+	for name, lsp := range s.storedDB.LSPs {
+		s.LSPs[name] = lsp
+		s.PLSPIDToName[lsp.PLSPID] = name
+	}
+}
+
+// setDBVersion records the LSP-DB-VERSION reported by the PCC
+// and persists the LSP-DB once synchronization is over
+func (s *Session) setDBVersion(version uint64) {
+	s.Lock()
+	if version != 0 {
+		s.LSPDBVersion = version
+	}
+	save := s.SyncState == SyncStateSynced
+	s.Unlock()
+
+	if save {
+		s.saveLSPDB()
+	}
+}
+
+// saveLSPDB asks lspDBSaver to store the LSP-DB, reports keep coming
+// while it is written so only the latest state is stored once
+func (s *Session) saveLSPDB() {
+	select {
+	case s.dbSave <- struct{}{}:
+	default:
+	}
+}
+
+// lspDBSaver stores the LSP-DB outside of the session read loop
+// until the session is closed, a pending save is done before returning
+func (s *Session) lspDBSaver() {
+	for {
+		select {
+		case <-s.dbSave:
+			s.storeLSPDB()
+		case <-s.StopKA:
+			select {
+			case <-s.dbSave:
+				s.storeLSPDB()
+			default:
+			}
+			return
+		}
+	}
+}
+
+// storeLSPDB hands a copy of the LSP-DB to the controller to be stored
+func (s *Session) storeLSPDB() {
+	s.RLock()
+	if !s.dbVersionNegotiated() || s.LSPDBVersion == 0 || s.controller == nil {
+		s.RUnlock()
+		return
+	}
+	db := &LSPDB{
+		SpeakerEntityID: s.SpeakerEntityID,
+		Addr:            remoteIP(s.Conn),
+		Version:         s.LSPDBVersion,
+		LSPs:            make(map[string]*LSP, len(s.LSPs)),
+	}
+	for name, lsp := range s.LSPs {
+		db.LSPs[name] = lsp
+	}
+	s.RUnlock()
+
+	err := s.controller.StoreLSPDB(db)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type":    "err",
+			"func":    "StoreLSPDB",
+			"peer":    s.Conn.RemoteAddr().String(),
+			"version": db.Version,
+		}).Error(err)
+	}
+}
+
+// https://tools.ietf.org/html/rfc8232#section-4
+// TriggerResync asks the PCC to report the LSP with the given PLSP-ID
+// again or all of its LSPs when PLSP-ID is 0, the same PCUpd is used for
+// triggered initial synchronization https://tools.ietf.org/html/rfc8232#section-3.3
+func (s *Session) TriggerResync(plspID uint32) error {
+	s.Lock()
+	initial := s.SyncState != SyncStateSynced && s.triggeredInitialSync()
+	resync := s.LocalCaps.TriggeredResync && s.StatefulCap != nil && s.StatefulCap.TriggeredResync
+	if !initial && !resync {
+		s.Unlock()
+		return errors.New("triggered synchronization is not supported by both PCEP speakers")
+	}
+	msg, err := s.newResyncMsg(plspID)
+	if err != nil {
+		s.Unlock()
+		return err
+	}
+	// controller provisioning waits until the PCC reported all of its LSPs again
+	// and the ones it does not report are removed at the end
+	if plspID == 0 && s.SyncState == SyncStateSynced {
+		s.SyncState = SyncStatePending
+		s.deltaSync = false
+		s.syncedPLSPIDs = make(map[uint32]bool)
+	}
+	s.Unlock()
+
+	i, err := s.Conn.Write(msg)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "s.Conn.Write",
+		}).Error(err)
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"type":    "info",
+		"event":   "resync",
+		"peer":    s.Conn.RemoteAddr().String(),
+		"plsp_id": plspID,
+	}).Info(fmt.Sprintf("sent LSP resync request: %d byte", i))
+	return nil
+}
+
+// newResyncMsg is a PCUpd with the S flag set in the LSP object
+// and an empty ERO, must be called with the session lock held
+func (s *Session) newResyncMsg(plspID uint32) ([]byte, error) {
+	srp, err := s.newSRPObject(false, PSTSRMPLS)
+	if err != nil {
+		return nil, err
+	}
+	lsp, err := s.newLSPObj(false, true, false, false, "", plspID)
+	if err != nil {
+		return nil, err
+	}
+	ero, err := newERObj(nil)
+	if err != nil {
+		return nil, err
+	}
+	msg := append(srp, lsp...)
+	msg = append(msg, ero...)
+
+	ch, err := newCommonHeader(11, uint16(len(msg)))
+	if err != nil {
+		return nil, err
+	}
+	return append(ch, msg...), nil
+}
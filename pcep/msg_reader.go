@@ -0,0 +1,52 @@
+package pcep
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PCEP does not limit the message size other than by
+// the 16 bit length field https://tools.ietf.org/html/rfc5440#section-6.1
+const maxMsgLength = 65535
+
+var errMalformedMsg = errors.New("malformed PCEP message")
+
+// msgReader builds complete PCEP messages out of the TCP byte stream
+// using the Message-Length of the common header, so messages split
+// across TCP segments or several messages in one read are handled
+type msgReader struct {
+	r *bufio.Reader
+}
+
+func newMsgReader(r io.Reader) *msgReader {
+	return &msgReader{
+		r: bufio.NewReaderSize(r, maxMsgLength),
+	}
+}
+
+// readMsg blocks until a complete message is received and returns
+// it including the common header. Errors wrapping errMalformedMsg mean
+// the stream can not be framed anymore and the session has to be closed
+func (m *msgReader) readMsg() ([]byte, error) {
+	header := make([]byte, 4)
+	_, err := io.ReadFull(m.r, header)
+	if err != nil {
+		return nil, err
+	}
+	ch, err := parseCommonHeader(header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errMalformedMsg, err.Error())
+	}
+	if ch.MessageLength < 4 {
+		return nil, fmt.Errorf("%w: message length %d is shorter than the common header", errMalformedMsg, ch.MessageLength)
+	}
+	msg := make([]byte, ch.MessageLength)
+	copy(msg, header)
+	_, err = io.ReadFull(m.r, msg[4:])
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,82 @@
+package pcep
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadMsgSplitAcrossReads(t *testing.T) {
+	ka, err := newCommonHeader(2, 0)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	closeMsg, err := newCloseMsg(1)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	stream := append(append([]byte{}, closeMsg...), ka...)
+	// every read returns a single byte as if each one arrived in its own segment
+	r := newMsgReader(iotest.OneByteReader(bytes.NewReader(stream)))
+
+	msg, err := r.readMsg()
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if !bytes.Equal(msg, closeMsg) {
+		t.Errorf("expected close msg %v got %v", closeMsg, msg)
+	}
+	msg, err = r.readMsg()
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if !bytes.Equal(msg, ka) {
+		t.Errorf("expected keepalive msg %v got %v", ka, msg)
+	}
+	_, err = r.readMsg()
+	if err != io.EOF {
+		t.Errorf("expected EOF got %v", err)
+	}
+}
+
+func TestReadMsgRejectsBadLength(t *testing.T) {
+	for _, header := range [][]byte{
+		{32, 2, 0, 0},
+		{32, 2, 0, 3},
+	} {
+		r := newMsgReader(bytes.NewReader(header))
+		_, err := r.readMsg()
+		if !errors.Is(err, errMalformedMsg) {
+			t.Errorf("expected malformed msg error for header %v got %v", header, err)
+		}
+	}
+}
+
+func TestReadMsgMaxLength(t *testing.T) {
+	for _, length := range []int{maxMsgLength - 1, maxMsgLength} {
+		ch, err := newCommonHeader(10, uint16(length-4))
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		stream := append(ch, make([]byte, length-4)...)
+		// the next message must still be framed correctly
+		ka, err := newCommonHeader(2, 0)
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		r := newMsgReader(bytes.NewReader(append(stream, ka...)))
+		msg, err := r.readMsg()
+		if err != nil {
+			t.Fatalf("must not see any errors for %d bytes msg, instead got: %s", length, err.Error())
+		}
+		if len(msg) != length {
+			t.Errorf("expected %d bytes got %d", length, len(msg))
+		}
+		msg, err = r.readMsg()
+		if err != nil || !bytes.Equal(msg, ka) {
+			t.Errorf("expected keepalive msg after %d bytes msg got %v %v", length, msg, err)
+		}
+	}
+}
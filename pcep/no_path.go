@@ -0,0 +1,87 @@
+package pcep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// https://tools.ietf.org/html/rfc5440#section-7.5
+// NO-PATH-VECTOR TLV flags
+//       bit number 31 - PCE currently unavailable
+//       bit number 30 - Unknown destination
+//       bit number 29 - Unknown source
+const (
+	NoPathPCEUnavailable uint32 = 1 << 0
+	NoPathUnknownDst     uint32 = 1 << 1
+	NoPathUnknownSrc     uint32 = 1 << 2
+)
+
+//NoPathError is returned by path computation when no path satisfies the
+// constraints of the request, it is answered with a NO-PATH object
+// and not as the PCE being unavailable
+type NoPathError struct {
+	Src string
+	Dst string
+	// the constraint none of the paths meets
+	Constraint string
+}
+
+func (e *NoPathError) Error() string {
+	return fmt.Sprintf("no path from %s to %s within %s", e.Src, e.Dst, e.Constraint)
+}
+
+//NoPathObject https://tools.ietf.org/html/rfc5440#section-7.5
+type NoPathObject struct {
+	NatureOfIssue uint8
+	CFlag         bool
+	Vector        uint32
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.5
+// NO-PATH Object-Class is 3.
+// NO-PATH Object-Type is 1.
+func newNoPathObj(np *NoPathObject) ([]byte, error) {
+	var flags uint8
+	if np.CFlag {
+		// C flag is the most significant bit of the flags field
+		flags |= (1 << 7)
+	}
+	body := []byte{
+		0: np.NatureOfIssue,
+		1: flags,
+		2: 0,
+		3: 0,
+	}
+	if np.Vector != 0 {
+		// NO-PATH-VECTOR TLV type 1 with the fixed length of 4
+		buf := new(bytes.Buffer)
+		for _, v := range []interface{}{uint16(1), uint16(4), np.Vector} {
+			err := binary.Write(buf, binary.BigEndian, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		body = append(body, buf.Bytes()...)
+	}
+	return newCommonObjHeader(3, 1, true, body)
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.5
+func parseNoPathObj(data []byte) (*NoPathObject, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("data len is %d but should be at least 4", len(data))
+	}
+	cFlag, err := uintToBool(readBits(data[1], 7))
+	if err != nil {
+		return nil, err
+	}
+	np := &NoPathObject{
+		NatureOfIssue: data[0],
+		CFlag:         cFlag,
+	}
+	if len(data) >= 12 && binary.BigEndian.Uint16(data[4:6]) == 1 {
+		np.Vector = binary.BigEndian.Uint32(data[8:12])
+	}
+	return np, nil
+}
@@ -0,0 +1,311 @@
+package pcep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// https://tools.ietf.org/html/rfc5440#section-7.14
+// Notification types and values
+const (
+	// Pending Request cancelled
+	NTReqCancelled uint8 = 1
+	// PCC cancels a set of pending requests
+	NVPCCCancelled uint8 = 1
+	// PCE cancels a set of pending requests
+	NVPCECancelled uint8 = 2
+	// Overloaded PCE or PCC
+	NTOverload uint8 = 2
+	// PCE is currently overloaded
+	NVOverloaded uint8 = 1
+	// PCE is no longer overloaded
+	NVOverloadEnded uint8 = 2
+)
+
+//    NOTIFICATION Object-Class is 12.
+//    NOTIFICATION Object-Type is 1.
+//     0                   1                   2                   3
+//     0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |  Reserved     |     Flags     |      NT       |     NV        |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |                                                               |
+//    //                      Optional TLVs                          //
+//    |                                                               |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+//NotificationObj https://tools.ietf.org/html/rfc5440#section-7.14
+type NotificationObj struct {
+	Flags uint8
+	NT    uint8
+	NV    uint8
+	// seconds the sender expects to stay overloaded
+	// zero means the duration is not known
+	OverloadDuration uint32
+}
+
+//Notification is a <notify> of a PCNtf message, a message may carry several
+// of them each with its own request IDs
+type Notification struct {
+	// request IDs of the RP objects the notifications apply to
+	RequestIDs []uint32
+	Objs       []*NotificationObj
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.14
+func parseNotificationObj(data []byte) (*NotificationObj, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("data len is %d but should be at least 4", len(data))
+	}
+	n := &NotificationObj{
+		Flags: data[1],
+		NT:    data[2],
+		NV:    data[3],
+	}
+	offset := 4
+	for (len(data) - offset) >= 4 {
+		tlvType := binary.BigEndian.Uint16(data[offset : offset+2])
+		length := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		// OVERLOADED-DURATION TLV is only meaningful with NT 2 NV 1
+		if tlvType == 2 && length == 4 && len(data)-offset >= 8 {
+			n.OverloadDuration = binary.BigEndian.Uint32(data[offset+4 : offset+8])
+		}
+		offset = offset + 4 + length + ((4 - length%4) % 4)
+	}
+	return n, nil
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.14
+func newNotificationObj(n *NotificationObj) ([]byte, error) {
+	var reserved uint8
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.BigEndian, []uint8{reserved, n.Flags, n.NT, n.NV})
+	if err != nil {
+		return nil, err
+	}
+	if n.NT == NTOverload && n.NV == NVOverloaded && n.OverloadDuration > 0 {
+		err = binary.Write(buf, binary.BigEndian, []uint16{2, 4})
+		if err != nil {
+			return nil, err
+		}
+		err = binary.Write(buf, binary.BigEndian, n.OverloadDuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newCommonObjHeader(12, 1, false, buf.Bytes())
+}
+
+// https://tools.ietf.org/html/rfc5440#section-6.6
+//    <PCNtf Message>::=<Common Header>
+//                      <notify-list>
+//    <notify-list>::=<notify> [<notify-list>]
+//    <notify>::= [<request-id-list>]
+//                <notification-list>
+//    <request-id-list>::=<RP>[<request-id-list>]
+//    <notification-list>::=<NOTIFICATION>[<notification-list>]
+func parsePCNtf(data []byte) ([]*Notification, error) {
+	var (
+		offset    int
+		newOffset int
+		n         *Notification
+	)
+	notifies := make([]*Notification, 0)
+	for (len(data) - newOffset) >= 4 {
+		offset = newOffset
+		coh, err := parseCommonObjectHeader(data[newOffset : newOffset+4])
+		if err != nil {
+			return nil, err
+		}
+		if coh.ObjectLength < 4 || offset+int(coh.ObjectLength) > len(data) {
+			return nil, newPCEPErr(10, 11, fmt.Errorf("malformed object class %d with length %d", coh.ObjectClass, coh.ObjectLength))
+		}
+		newOffset = newOffset + int(coh.ObjectLength)
+		body := data[offset+4 : offset+int(coh.ObjectLength)]
+
+		switch coh.ObjectClass {
+		case 2:
+			rp, err := parseRPObj(body)
+			if err != nil {
+				return nil, withPCEPErr(err, 10, 11, data[offset:offset+int(coh.ObjectLength)])
+			}
+			// RP after NOTIFICATION starts the next <notify>
+			if n == nil || len(n.Objs) > 0 {
+				n = &Notification{}
+				notifies = append(notifies, n)
+			}
+			n.RequestIDs = append(n.RequestIDs, rp.RequestID)
+		case 12:
+			obj, err := parseNotificationObj(body)
+			if err != nil {
+				return nil, withPCEPErr(err, 10, 11)
+			}
+			if n == nil {
+				n = &Notification{}
+				notifies = append(notifies, n)
+			}
+			n.Objs = append(n.Objs, obj)
+		default:
+			printCommonObjHdr(coh, "ignoring obj in pcntf msg")
+		}
+	}
+	if len(notifies) == 0 {
+		return nil, errors.New("NOTIFICATION object missing")
+	}
+	// every <notify> needs its <notification-list>
+	for _, n := range notifies {
+		if len(n.Objs) == 0 {
+			return nil, fmt.Errorf("NOTIFICATION object missing for request IDs %v", n.RequestIDs)
+		}
+	}
+	return notifies, nil
+}
+
+// Message-Type is 5.
+func newPCNtfMsg(notifies ...*Notification) ([]byte, error) {
+	if len(notifies) == 0 {
+		return nil, errors.New("PCNtf requires at least one notify")
+	}
+	var msg []byte
+	for i, n := range notifies {
+		if len(n.Objs) == 0 {
+			return nil, errors.New("PCNtf requires at least one NOTIFICATION object")
+		}
+		// RP is what separates a <notify> from the previous one
+		if i > 0 && len(n.RequestIDs) == 0 {
+			return nil, errors.New("only the first notify can go without request IDs")
+		}
+		for _, id := range n.RequestIDs {
+			rp, err := newRPObj(&RPObject{RequestID: id})
+			if err != nil {
+				return nil, err
+			}
+			msg = append(msg, rp...)
+		}
+		for _, obj := range n.Objs {
+			no, err := newNotificationObj(obj)
+			if err != nil {
+				return nil, err
+			}
+			msg = append(msg, no...)
+		}
+	}
+	ch, err := newCommonHeader(5, uint16(len(msg)))
+	if err != nil {
+		return nil, err
+	}
+	return append(ch, msg...), nil
+}
+
+// SendNotification sends PCNtf to the PCC
+func (s *Session) SendNotification(n *Notification) error {
+	msg, err := newPCNtfMsg(n)
+	if err != nil {
+		return err
+	}
+	i, err := s.Conn.Write(msg)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "s.Conn.Write",
+		}).Error(err)
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"type":  "info",
+		"event": "notification",
+		"peer":  s.Conn.RemoteAddr().String(),
+	}).Info(fmt.Sprintf("sent notification: %d byte", i))
+	return nil
+}
+
+// Overloaded is true while the PCC told us it is overloaded
+// no new LSPs should be initiated to it during that time
+func (s *Session) Overloaded() bool {
+	defer s.RUnlock()
+	s.RLock()
+	return s.overloaded
+}
+
+func (s *Session) handlePCNtf(data []byte) {
+	notifies, err := parsePCNtf(data)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "parsePCNtf",
+			"peer": s.Conn.RemoteAddr().String(),
+		}).Error(err)
+		s.reportErr(err)
+		return
+	}
+	for _, n := range notifies {
+		for _, obj := range n.Objs {
+			logrus.WithFields(logrus.Fields{
+				"type":              "session",
+				"event":             "notification",
+				"peer":              s.Conn.RemoteAddr().String(),
+				"nt":                obj.NT,
+				"nv":                obj.NV,
+				"request_ids":       n.RequestIDs,
+				"overload_duration": obj.OverloadDuration,
+			}).Info("received new notification msg")
+
+			switch {
+			case obj.NT == NTReqCancelled && obj.NV == NVPCCCancelled:
+				// requests are answered as soon as they are computed
+				// so there is nothing pending we could drop
+			case obj.NT == NTOverload && obj.NV == NVOverloaded:
+				s.startOverload(obj.OverloadDuration)
+			case obj.NT == NTOverload && obj.NV == NVOverloadEnded:
+				s.endOverload()
+			}
+		}
+	}
+}
+
+// startOverload pauses LSP initiation until the PCC signals the
+// overload has ended or the OVERLOADED-DURATION has passed
+func (s *Session) startOverload(duration uint32) {
+	defer s.Unlock()
+	s.Lock()
+
+	s.overloaded = true
+	if s.overloadTimer != nil {
+		s.overloadTimer.Stop()
+		s.overloadTimer = nil
+	}
+	if duration > 0 {
+		s.overloadTimer = time.AfterFunc(time.Duration(duration)*time.Second, s.endOverload)
+	}
+}
+
+func (s *Session) endOverload() {
+	s.Lock()
+	if !s.overloaded {
+		s.Unlock()
+		return
+	}
+	s.overloaded = false
+	if s.overloadTimer != nil {
+		s.overloadTimer.Stop()
+		s.overloadTimer = nil
+	}
+	s.Unlock()
+
+	logrus.WithFields(logrus.Fields{
+		"type":  "session",
+		"event": "overload_ended",
+		"peer":  s.Conn.RemoteAddr().String(),
+	}).Info("peer is no longer overloaded")
+
+	// one pending signal is enough to resume provisioning
+	select {
+	case s.OverloadEnded <- true:
+	default:
+	}
+}
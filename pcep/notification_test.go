@@ -0,0 +1,176 @@
+package pcep
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPCNtf(t *testing.T) {
+	n := &Notification{
+		RequestIDs: []uint32{7, 8},
+		Objs: []*NotificationObj{
+			{NT: NTOverload, NV: NVOverloaded, OverloadDuration: 30},
+			{NT: NTReqCancelled, NV: NVPCECancelled},
+		},
+	}
+	msg, err := newPCNtfMsg(n)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	ch, err := parseCommonHeader(msg[:4])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if ch.MessageType != 5 || int(ch.MessageLength) != len(msg) {
+		t.Fatalf("wrong common header %+v for msg len %d", ch, len(msg))
+	}
+	parsed, err := parsePCNtf(msg[4:])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if !reflect.DeepEqual(parsed, []*Notification{n}) {
+		t.Errorf("expected %+v got %+v", n, parsed)
+	}
+}
+
+func TestPCNtfNotifyList(t *testing.T) {
+	notifies := []*Notification{
+		// not bound to any request, only the first one can go without RP
+		// as otherwise it is merged with the previous one
+		{
+			Objs: []*NotificationObj{{NT: NTOverload, NV: NVOverloadEnded}},
+		},
+		{
+			RequestIDs: []uint32{1, 2},
+			Objs:       []*NotificationObj{{NT: NTReqCancelled, NV: NVPCECancelled}},
+		},
+		{
+			RequestIDs: []uint32{3},
+			Objs: []*NotificationObj{
+				{NT: NTReqCancelled, NV: NVPCCCancelled},
+				{NT: NTReqCancelled, NV: NVPCECancelled},
+			},
+		},
+	}
+	msg, err := newPCNtfMsg(notifies...)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	parsed, err := parsePCNtf(msg[4:])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if len(parsed) != len(notifies) {
+		t.Fatalf("expected %d notify got %d", len(notifies), len(parsed))
+	}
+	for i := range notifies {
+		if !reflect.DeepEqual(parsed[i], notifies[i]) {
+			t.Errorf("notify %d expected %+v got %+v", i, notifies[i], parsed[i])
+		}
+	}
+}
+
+func TestPCNtfRPWithoutNotificationObj(t *testing.T) {
+	first, err := newPCNtfMsg(&Notification{
+		RequestIDs: []uint32{1},
+		Objs:       []*NotificationObj{{NT: NTReqCancelled, NV: NVPCECancelled}},
+	})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	rp, err := newRPObj(&RPObject{RequestID: 2})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	_, err = parsePCNtf(append(first[4:], rp...))
+	if err == nil {
+		t.Errorf("notify without NOTIFICATION object must be rejected")
+	}
+}
+
+func TestPCNtfOverload(t *testing.T) {
+	s, _ := newTestSession(t)
+	ntf := func(nv uint8) []byte {
+		msg, err := newPCNtfMsg(&Notification{Objs: []*NotificationObj{{NT: NTOverload, NV: nv}}})
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		return msg[4:]
+	}
+
+	// no dispatch while the PCC is overloaded
+	s.handlePCNtf(ntf(NVOverloaded))
+	if !s.Overloaded() {
+		t.Fatal("expected session to be overloaded")
+	}
+	select {
+	case <-s.OverloadEnded:
+		t.Fatal("expected no resume signal while overloaded")
+	default:
+	}
+
+	// dispatch resumes once the overload ended
+	s.handlePCNtf(ntf(NVOverloadEnded))
+	if s.Overloaded() {
+		t.Error("expected session to be no longer overloaded")
+	}
+	select {
+	case <-s.OverloadEnded:
+	default:
+		t.Error("expected resume signal once the overload ended")
+	}
+
+	// overload ended without overload is ignored
+	s.handlePCNtf(ntf(NVOverloadEnded))
+	select {
+	case <-s.OverloadEnded:
+		t.Error("expected no resume signal without overload")
+	default:
+	}
+
+	// OVERLOADED-DURATION ends the overload without NV 2
+	msg, err := newPCNtfMsg(&Notification{Objs: []*NotificationObj{{NT: NTOverload, NV: NVOverloaded, OverloadDuration: 1}}})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	s.handlePCNtf(msg[4:])
+	if !s.Overloaded() {
+		t.Fatal("expected session to be overloaded")
+	}
+	select {
+	case <-s.OverloadEnded:
+		if s.Overloaded() {
+			t.Error("expected session to be no longer overloaded")
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("expected resume signal once the overload duration passed")
+	}
+}
+
+func TestPCNtfNoNotificationObj(t *testing.T) {
+	rp, err := newRPObj(&RPObject{RequestID: 1})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	_, err = parsePCNtf(rp)
+	if err == nil {
+		t.Errorf("PCNtf without NOTIFICATION object must be rejected")
+	}
+}
+
+func TestParsePCNtfOversizedObject(t *testing.T) {
+	obj, err := newNotificationObj(&NotificationObj{NT: NTOverload, NV: NVOverloadEnded})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	// end of the second obj wraps around a 16 bit offset back into the first one
+	length := uint16(0x10000 - len(obj) + 2)
+	data := append(obj, 12, 0x10, uint8(length>>8), uint8(length), 0, 0, 0, 0)
+	_, err = parsePCNtf(data)
+	var pe *pcepErr
+	if !errors.As(err, &pe) || pe.ErrType != 10 || pe.ErrValue != 11 {
+		t.Errorf("expected PCEP error 10/11 got %v", err)
+	}
+}
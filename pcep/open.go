@@ -1,7 +1,9 @@
 package pcep
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math"
 )
 
 //OpenObject to store PCEP OPEN Object
@@ -11,6 +13,23 @@ type OpenObject struct {
 	Keepalive uint8
 	DeadTimer uint8
 	SID       uint8
+	// LSP-DB-VERSION and SPEAKER-ENTITY-ID TLVs https://tools.ietf.org/html/rfc8232#section-3.3.1
+	LSPDBVersion    uint64
+	SpeakerEntityID string
+	// TLVs advertised by the peer, nil when absent
+	StatefulCap *StatefulPCECapability
+	SRCap       *SRPCECap
+	PSTCap      *PSTCap
+	// ASSOC-Type-List TLV https://tools.ietf.org/html/rfc8697#section-5.1
+	AssocTypes []uint16
+	// TLVs we do not know about kept as they came
+	UnknownTLVs []OpenTLV
+}
+
+//OpenTLV is an Open object TLV which is not decoded
+type OpenTLV struct {
+	Type  uint16
+	Value []byte
 }
 
 // https://tools.ietf.org/html/rfc5440#section-7.3
@@ -30,3 +49,247 @@ func parseOpenObject(data []byte) (*OpenObject, error) {
 	}
 	return open, nil
 }
+
+//Capabilities advertised in our Open
+type Capabilities struct {
+	// STATEFUL-PCE-CAPABILITY U flag
+	LSPUpdate bool
+	// STATEFUL-PCE-CAPABILITY I flag
+	LSPInit bool
+	// STATEFUL-PCE-CAPABILITY S, T, D and F flags https://tools.ietf.org/html/rfc8232#section-6.1
+	DBVersion            bool
+	TriggeredResync      bool
+	DeltaSync            bool
+	TriggeredInitialSync bool
+	// SR-PCE-CAPABILITY is only sent when SR is set
+	SR  bool
+	MSD uint8
+	// SRv6 is advertised in PATH-SETUP-TYPE-CAPABILITY which then carries SR-PCE-CAPABILITY too
+	SRv6 bool
+}
+
+//OpenParams are per peer overrides of the session characteristics
+// from Cfg, zero timers and nil capabilities mean Cfg values are used
+type OpenParams struct {
+	Keepalive    uint8
+	DeadTimer    uint8
+	Capabilities *Capabilities
+}
+
+// parseOpenTLVs walks all TLVs of the Open object whatever order they come in
+// https://tools.ietf.org/html/rfc5440#section-7.3
+func (open *OpenObject) parseOpenTLVs(tlvs []byte) error {
+	var offset int
+	for len(tlvs)-offset >= 4 {
+		tlvType := binary.BigEndian.Uint16(tlvs[offset : offset+2])
+		length := int(binary.BigEndian.Uint16(tlvs[offset+2 : offset+4]))
+		if offset+4+length > len(tlvs) {
+			return fmt.Errorf("malformed TLV type %d in open obj", tlvType)
+		}
+		tlv := tlvs[offset : offset+4+length]
+		var err error
+		switch tlvType {
+		// https://tools.ietf.org/html/rfc8231#section-7.1.1
+		case 16:
+			open.StatefulCap, err = parseStatefulPCECap(tlv)
+		// https://tools.ietf.org/html/rfc8232#section-3.3.1
+		case 23:
+			open.LSPDBVersion, err = parseDBVersionTLV(tlv)
+		// https://tools.ietf.org/html/rfc8232#section-4.1.1
+		case 24:
+			open.SpeakerEntityID, err = parseSpeakerEntityIDTLV(tlv)
+		case 26:
+			open.SRCap, err = parseSRCap(tlv)
+		// https://tools.ietf.org/html/rfc8408#section-4
+		case 34:
+			open.PSTCap, err = parsePSTCap(tlv)
+		// https://tools.ietf.org/html/rfc8697#section-5.1
+		case 35:
+			open.AssocTypes, err = parseAssocTypeList(tlv[4:])
+		default:
+			open.UnknownTLVs = append(open.UnknownTLVs, OpenTLV{
+				Type:  tlvType,
+				Value: append([]byte{}, tlv[4:]...),
+			})
+		}
+		if err != nil {
+			return err
+		}
+		// TLVs are padded to 4-byte alignment
+		offset = offset + 4 + ((length + 3) &^ 3)
+	}
+	return nil
+}
+
+// https://tools.ietf.org/html/rfc8697#section-5.1
+// ASSOC-Type-List TLV type is 35 and carries 2 bytes per association type
+func parseAssocTypeList(value []byte) ([]uint16, error) {
+	if len(value)%2 != 0 {
+		return nil, fmt.Errorf("ASSOC-Type-List TLV len is %d but should be even", len(value))
+	}
+	types := make([]uint16, 0, len(value)/2)
+	for i := 0; i < len(value); i += 2 {
+		types = append(types, binary.BigEndian.Uint16(value[i:i+2]))
+	}
+	return types, nil
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.3
+// OPEN Object-Class is 1.
+// OPEN Object-Type is 1.
+func newOpenObj(open *OpenObject, caps *Capabilities) ([]byte, error) {
+	body := []byte{
+		// version 1 in the 3 most significant bits flags are all zeroes
+		0: 32,
+		1: open.Keepalive,
+		2: open.DeadTimer,
+		3: open.SID,
+	}
+	if caps.LSPUpdate || caps.LSPInit {
+		stCap, err := newStatefulPCECap(caps)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, stCap...)
+	}
+	if caps.DBVersion && open.LSPDBVersion != 0 {
+		dbv, err := newDBVersionTLV(open.LSPDBVersion)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, dbv...)
+	}
+	if caps.SRv6 {
+		body = append(body, newPSTCap(caps)...)
+	} else if caps.SR {
+		body = append(body, newSRCap(caps.MSD)...)
+	}
+	return newCommonObjHeader(1, 1, false, body)
+}
+
+// https://tools.ietf.org/html/rfc5440#section-6.2
+// Message-Type is 1.
+func newOpenMsg(open *OpenObject, caps *Capabilities) ([]byte, error) {
+	obj, err := newOpenObj(open, caps)
+	if err != nil {
+		return nil, err
+	}
+	ch, err := newCommonHeader(1, uint16(len(obj)))
+	if err != nil {
+		return nil, err
+	}
+	return append(ch, obj...), nil
+}
+
+// findOpenObj returns the OPEN object carried by a PCErr
+// proposing session characteristics or nil if there is none
+func findOpenObj(data []byte) *OpenObject {
+	var offset int
+	for (len(data) - offset) >= 4 {
+		coh, err := parseCommonObjectHeader(data[offset : offset+4])
+		if err != nil || coh.ObjectLength < 4 || offset+int(coh.ObjectLength) > len(data) {
+			return nil
+		}
+		if coh.ObjectClass == 1 && coh.ObjectType == 1 {
+			open, err := parseOpenObject(data[offset+4 : offset+int(coh.ObjectLength)])
+			if err != nil {
+				return nil
+			}
+			return open
+		}
+		offset = offset + int(coh.ObjectLength)
+	}
+	return nil
+}
+
+// setDefaults fills in the values missing from config
+// keepalive of zero falls back to 30 seconds as in the older versions
+func (c *Cfg) setDefaults() {
+	if c.Keepalive == 0 {
+		c.Keepalive = 30
+	}
+	if c.DeadTimer == 0 {
+		c.DeadTimer = recommendedDeadTimer(c.Keepalive)
+	}
+	if c.MaxKeepalive == 0 {
+		c.MaxKeepalive = math.MaxUint8
+	}
+	if c.MaxDeadTimer == 0 {
+		c.MaxDeadTimer = math.MaxUint8
+	}
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.3
+// A RECOMMENDED value for the DeadTimer is 4 times the value of the Keepalive.
+func recommendedDeadTimer(keepalive uint8) uint8 {
+	if keepalive > math.MaxUint8/4 {
+		return math.MaxUint8
+	}
+	return keepalive * 4
+}
+
+func clamp(v, min, max uint8) uint8 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// negotiate checks the timers proposed by a peer, if they are out of
+// the acceptable range an OPEN object with values we would accept is returned
+func (c *Cfg) negotiate(open *OpenObject) *OpenObject {
+	if c == nil {
+		return nil
+	}
+	ka := clamp(open.Keepalive, c.MinKeepalive, c.MaxKeepalive)
+	// DeadTimer is ignored when keepalives are disabled
+	if ka == 0 {
+		if open.Keepalive == 0 {
+			return nil
+		}
+		return &OpenObject{Version: 1, SID: open.SID}
+	}
+	dt := open.DeadTimer
+	if open.Keepalive == 0 || dt < ka {
+		dt = recommendedDeadTimer(ka)
+	}
+	dt = clamp(dt, c.MinDeadTimer, c.MaxDeadTimer)
+	if ka == open.Keepalive && dt == open.DeadTimer {
+		return nil
+	}
+	return &OpenObject{
+		Version:   1,
+		Keepalive: ka,
+		DeadTimer: dt,
+		SID:       open.SID,
+	}
+}
+
+// setOpenParams sets our session characteristics
+// from config applying per peer overrides if any
+func (s *Session) setOpenParams(cfg *Cfg, p *OpenParams) {
+	defer s.Unlock()
+
+	s.Lock()
+
+	s.cfg = cfg
+	s.Keepalive = cfg.Keepalive
+	s.DeadTimer = cfg.DeadTimer
+	s.LocalCaps = cfg.Capabilities
+	if p == nil {
+		return
+	}
+	if p.Keepalive != 0 {
+		s.Keepalive = p.Keepalive
+		s.DeadTimer = recommendedDeadTimer(p.Keepalive)
+	}
+	if p.DeadTimer != 0 {
+		s.DeadTimer = p.DeadTimer
+	}
+	if p.Capabilities != nil {
+		s.LocalCaps = *p.Capabilities
+	}
+}
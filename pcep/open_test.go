@@ -0,0 +1,171 @@
+package pcep
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewOpenMsg(t *testing.T) {
+	// what used to be sent as hardcoded bytes
+	expected := []byte{
+		32, 1, 0, 28,
+		1, 16, 0, 24,
+		32, 30, 120, 1,
+		0, 16, 0, 4, 0, 0, 0, 5,
+		0, 26, 0, 4, 0, 0, 0, 5,
+	}
+	msg, err := newOpenMsg(&OpenObject{
+		Keepalive: 30,
+		DeadTimer: 120,
+		SID:       1,
+	}, &Capabilities{
+		LSPUpdate: true,
+		LSPInit:   true,
+		SR:        true,
+		MSD:       5,
+	})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if !bytes.Equal(msg, expected) {
+		t.Errorf("expected %v got %v", expected, msg)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	cfg := &Cfg{
+		MinKeepalive: 10,
+		MaxKeepalive: 60,
+	}
+	cfg.setDefaults()
+
+	if p := cfg.negotiate(&OpenObject{Keepalive: 30, DeadTimer: 120}); p != nil {
+		t.Errorf("timers in range must be accepted, got proposal %+v", p)
+	}
+	p := cfg.negotiate(&OpenObject{Keepalive: 5, DeadTimer: 20})
+	if p == nil {
+		t.Fatal("keepalive below the range must be negotiated")
+	}
+	if p.Keepalive != 10 || p.DeadTimer != 20 {
+		t.Errorf("expected keepalive 10 deadtimer 20 got %+v", p)
+	}
+	p = cfg.negotiate(&OpenObject{Keepalive: 0})
+	if p == nil || p.Keepalive != 10 || p.DeadTimer != 40 {
+		t.Errorf("expected keepalive 10 deadtimer 40 got %+v", p)
+	}
+}
+
+func TestParseOpenTLVs(t *testing.T) {
+	// PST capability first, then an unknown TLV, the association type list,
+	// speaker entity ID with padding and the stateful capability last
+	tlvs := newPSTCap(&Capabilities{SR: true, MSD: 10})
+	tlvs = append(tlvs, 0, 99, 0, 3, 1, 2, 3, 0)
+	tlvs = append(tlvs, 0, 35, 0, 4, 0, 1, 0, 6)
+	tlvs = append(tlvs, 0, 24, 0, 3, 'p', 'e', '1', 0)
+	stateful, err := newStatefulPCECap(&Capabilities{LSPUpdate: true, LSPInit: true})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	tlvs = append(tlvs, stateful...)
+
+	open := &OpenObject{}
+	err = open.parseOpenTLVs(tlvs)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if open.StatefulCap == nil || !open.StatefulCap.UPDFlag || !open.StatefulCap.LSPInitCap {
+		t.Errorf("expected stateful capability with U and I flags got %+v", open.StatefulCap)
+	}
+	if open.PSTCap == nil || open.PSTCap.SR == nil || open.PSTCap.SR.MSD != 10 {
+		t.Errorf("expected PST capability with SR MSD 10 got %+v", open.PSTCap)
+	}
+	if open.SpeakerEntityID != "pe1" {
+		t.Errorf("expected speaker entity ID pe1 got %s", open.SpeakerEntityID)
+	}
+	if len(open.AssocTypes) != 2 || open.AssocTypes[0] != AssocTypePathProtection || open.AssocTypes[1] != AssocTypeSRPolicy {
+		t.Errorf("expected association types [1 6] got %v", open.AssocTypes)
+	}
+	if len(open.UnknownTLVs) != 1 || open.UnknownTLVs[0].Type != 99 || !bytes.Equal(open.UnknownTLVs[0].Value, []byte{1, 2, 3}) {
+		t.Errorf("expected unknown TLV 99 with value [1 2 3] got %+v", open.UnknownTLVs)
+	}
+
+	// TLV length running past the object
+	err = (&OpenObject{}).parseOpenTLVs([]byte{0, 16, 0, 8, 0, 0, 0, 5})
+	if err == nil {
+		t.Error("expected an error for a truncated TLV")
+	}
+}
+
+func TestSessionCapabilities(t *testing.T) {
+	s := NewSession(nil)
+	if s.CanInitiate() || s.CanUpdate() || s.SupportsPST(PSTSRMPLS) {
+		t.Errorf("session without capabilities must not allow initiate, update or SR-MPLS")
+	}
+	if !s.SupportsPST(PSTRSVPTE) || !s.SupportsAssocType(AssocTypeSRPolicy) {
+		t.Errorf("RSVP-TE and all association types must be supported by default")
+	}
+
+	s.StatefulCap = &StatefulPCECapability{Type: 16, UPDFlag: true}
+	s.SRCap = &SRPCECap{Type: 26, MSD: 3}
+	s.Open.AssocTypes = []uint16{AssocTypePathProtection}
+	if s.CanInitiate() || !s.CanUpdate() {
+		t.Errorf("expected update without initiate")
+	}
+	if !s.SupportsPST(PSTSRMPLS) || s.SupportsPST(PSTSRv6) {
+		t.Errorf("expected SR-MPLS without SRv6")
+	}
+	if s.SRMSD() != 3 {
+		t.Errorf("expected MSD 3 got %d", s.SRMSD())
+	}
+	if !s.SupportsAssocType(AssocTypePathProtection) || s.SupportsAssocType(AssocTypeSRPolicy) {
+		t.Errorf("expected only path protection association got %v", s.Open.AssocTypes)
+	}
+
+	// PATH-SETUP-TYPE-CAPABILITY lists every supported PST
+	s.Open.PSTCap = &PSTCap{PSTs: []uint8{PSTSRMPLS, PSTSRv6}}
+	if s.SupportsPST(PSTRSVPTE) || !s.SupportsPST(PSTSRv6) {
+		t.Errorf("expected SR-MPLS and SRv6 without RSVP-TE")
+	}
+	s.SRCap.NoMSDLimit = true
+	if s.SRMSD() != 0 {
+		t.Errorf("expected no MSD limit got %d", s.SRMSD())
+	}
+}
+
+func TestFindOpenObj(t *testing.T) {
+	open, err := newOpenObj(&OpenObject{Keepalive: 30, DeadTimer: 120}, &Capabilities{LSPUpdate: true})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	msg, err := newErrMsg(1, 4, open)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	pcErr := msg[4:]
+	proposal := findOpenObj(pcErr)
+	if proposal == nil || proposal.Keepalive != 30 || proposal.DeadTimer != 120 {
+		t.Fatalf("expected proposal with keepalive 30 and deadtimer 120 got %+v", proposal)
+	}
+
+	oversized := append([]byte{}, pcErr...)
+	// PCEP-ERROR object claiming to run past the end of the msg
+	oversized[2], oversized[3] = 0xff, 0xfc
+	// offset of the object after this one wraps around to the start of the msg
+	wrapping := append(append([]byte{}, pcErr[:8]...), 20, 0x10, 0xff, 0xf8, 0, 0, 0, 0)
+	// OPEN object header is there but the msg ends within its TLVs
+	truncated := append([]byte{}, pcErr[:len(pcErr)-len(open)+8]...)
+	for _, c := range []struct {
+		name string
+		data []byte
+	}{
+		{name: "oversized object", data: oversized},
+		{name: "object length wrapping around", data: wrapping},
+		{name: "truncated OPEN object", data: truncated},
+		{name: "object shorter than its header", data: []byte{13, 0x10, 0, 2, 0, 0, 0, 0}},
+		{name: "no OPEN object", data: pcErr[:8]},
+	} {
+		if proposal := findOpenObj(c.data); proposal != nil {
+			t.Errorf("%s: expected no proposal got %+v", c.name, proposal)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package pcep
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// https://tools.ietf.org/html/rfc4872#section-14.1
+// LSP Protection Type carried in the PT field
+const (
+	ProtectionTypeFullRerouting        uint8 = 0x01
+	ProtectionTypeReroutingNoExtra     uint8 = 0x02
+	ProtectionType1toN                 uint8 = 0x04
+	ProtectionType1plus1Unidirectional uint8 = 0x08
+	ProtectionType1plus1Bidirectional  uint8 = 0x10
+)
+
+//    PATH-PROTECTION-ASSOCIATION TLV type is 38 and length is 4
+//     0                   1                   2                   3
+//     0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |         Type = 38             |            Length = 4         |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |   PT      |               Unassigned Flags                |S|P|
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+//PathProtection https://tools.ietf.org/html/rfc8745#section-3.2
+type PathProtection struct {
+	ProtectionType uint8
+	// S flag the LSP is a secondary LSP
+	Secondary bool
+	// P flag the LSP is a protecting LSP, the working LSP has it unset
+	Protecting bool
+}
+
+// https://tools.ietf.org/html/rfc8745#section-3.2
+func newPathProtectionTLV(p *PathProtection) []byte {
+	flags := uint32(p.ProtectionType&0x3f) << 26
+	if p.Secondary {
+		flags |= (1 << 1)
+	}
+	if p.Protecting {
+		flags |= (1 << 0)
+	}
+	tlv := make([]byte, 8)
+	binary.BigEndian.PutUint16(tlv[0:2], 38)
+	binary.BigEndian.PutUint16(tlv[2:4], 4)
+	binary.BigEndian.PutUint32(tlv[4:8], flags)
+	return tlv
+}
+
+// https://tools.ietf.org/html/rfc8745#section-3.2
+func parsePathProtectionTLV(value []byte) (*PathProtection, error) {
+	if len(value) != 4 {
+		return nil, fmt.Errorf("PATH-PROTECTION-ASSOCIATION TLV len is %d but should be 4", len(value))
+	}
+	p := &PathProtection{
+		ProtectionType: value[0] >> 2,
+	}
+	var err error
+	p.Secondary, err = uintToBool(readBits(value[3], 1))
+	if err != nil {
+		return nil, err
+	}
+	p.Protecting, err = uintToBool(readBits(value[3], 0))
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
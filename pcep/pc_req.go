@@ -0,0 +1,294 @@
+package pcep
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/sirupsen/logrus"
+)
+
+//PathCompRequest is a single request of a PCReq msg https://tools.ietf.org/html/rfc5440#section-6.4
+type PathCompRequest struct {
+	RP      *RPObject
+	Src     string
+	Dst     string
+	BW      float32
+	LSPA    *LSPAObject
+	Metrics []*LSPMetric
+	// RP object as received used to report errors
+	rpObj []byte
+}
+
+//PathCompReply is the result of a path computation for a single request
+// if EROList is empty no path was found and NoPathVector
+// together with BWUnsatisfied carry the reasons
+type PathCompReply struct {
+	EROList       []SREROSub
+	Cost          uint32
+	NoPathVector  uint32
+	BWUnsatisfied bool
+}
+
+// https://tools.ietf.org/html/rfc5440#section-6.4
+//    <PCReq Message>::= <Common Header>
+//                       [<svec-list>]
+//                       <request-list>
+//    <request>::= <RP>
+//                 <END-POINTS>
+//                 [<LSPA>]
+//                 [<BANDWIDTH>]
+//                 [<metric-list>]
+//                 [<RRO>[<BANDWIDTH>]]
+//                 [<IRO>]
+//                 [<LOAD-BALANCING>]
+func parsePCReq(data []byte) ([]*PathCompRequest, error) {
+	var (
+		offset    int
+		newOffset int
+		req       *PathCompRequest
+		reqs      []*PathCompRequest
+		// offending RP object of the request being parsed
+		rpObj [][]byte
+	)
+	for (len(data) - newOffset) >= 4 {
+		offset = newOffset
+		coh, err := parseCommonObjectHeader(data[newOffset : newOffset+4])
+		if err != nil {
+			return nil, err
+		}
+		if coh.ObjectLength < 4 || offset+int(coh.ObjectLength) > len(data) {
+			return nil, newPCEPErr(10, 11, fmt.Errorf("malformed object class %d with length %d", coh.ObjectClass, coh.ObjectLength), rpObj...)
+		}
+		newOffset = newOffset + int(coh.ObjectLength)
+		body := data[offset+4 : offset+int(coh.ObjectLength)]
+
+		if coh.ObjectClass == 2 {
+			rpObj = [][]byte{data[offset : offset+int(coh.ObjectLength)]}
+			rp, err := parseRPObj(body)
+			if err != nil {
+				return nil, withPCEPErr(err, 10, 11, rpObj...)
+			}
+			req = &PathCompRequest{
+				RP:      rp,
+				Metrics: make([]*LSPMetric, 0),
+				rpObj:   rpObj[0],
+			}
+			reqs = append(reqs, req)
+			continue
+		}
+		// SVEC objects come before any RP and are not supported
+		if coh.ObjectClass == 11 {
+			printCommonObjHdr(coh, "ignoring svec obj in pcreq msg")
+			continue
+		}
+		if req == nil {
+			return nil, newPCEPErr(6, 1, errors.New("RP object missing"))
+		}
+		switch coh.ObjectClass {
+		case 4:
+			if coh.ObjectType != 1 && coh.ObjectType != 2 {
+				return nil, newPCEPErr(3, 2, fmt.Errorf("unknown END-POINTS obj type %d", coh.ObjectType), rpObj...)
+			}
+			req.Src, req.Dst, err = parseEndpointsObj(coh.ObjectType, body)
+			if err != nil {
+				return nil, withPCEPErr(err, 10, 11, rpObj...)
+			}
+		case 5:
+			// only requested bandwidth is used the bandwidth
+			// of an existing TE LSP (type 2) follows RRO
+			if coh.ObjectType != 1 {
+				continue
+			}
+			req.BW, err = parseBandwidthObj(body)
+			if err != nil {
+				return nil, withPCEPErr(err, 10, 11, rpObj...)
+			}
+		case 6:
+			m, err := parseMetric(body)
+			if err != nil {
+				return nil, withPCEPErr(err, 10, 11, rpObj...)
+			}
+			req.Metrics = append(req.Metrics, m)
+		case 9:
+			req.LSPA, err = parseLSPAObject(body)
+			if err != nil {
+				return nil, withPCEPErr(err, 10, 11, rpObj...)
+			}
+		default:
+			printCommonObjHdr(coh, "found unsupported obj in pcreq msg")
+		}
+	}
+	if len(reqs) == 0 {
+		return nil, newPCEPErr(6, 1, errors.New("RP object missing"))
+	}
+	for _, r := range reqs {
+		if r.Src == "" || r.Dst == "" {
+			return nil, newPCEPErr(6, 3, fmt.Errorf("END-POINTS object missing in request %d", r.RP.RequestID), r.rpObj)
+		}
+	}
+	return reqs, nil
+}
+
+// https://tools.ietf.org/html/rfc5440#section-6.5
+//    <PCRep Message> ::= <Common Header>
+//                        <response-list>
+//    <response>::=<RP>
+//                [<NO-PATH>]
+//                [<attribute-list>]
+//                [<path-list>]
+//    <path>::= <ERO><attribute-list>
+func newPCRepResponse(req *PathCompRequest, reply *PathCompReply) ([]byte, error) {
+	rp, err := newRPObj(req.RP)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply.EROList) == 0 {
+		np, err := newNoPathObj(&NoPathObject{
+			CFlag:  reply.BWUnsatisfied,
+			Vector: reply.NoPathVector,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp := append(rp, np...)
+		// with the C flag set the unsatisfied constraints follow NO-PATH
+		if reply.BWUnsatisfied {
+			bw, err := newBandwidthObj(1, math.Float32bits(req.BW))
+			if err != nil {
+				return nil, err
+			}
+			resp = append(resp, bw...)
+		}
+		return resp, nil
+	}
+	ero, err := newSRERObj(reply.EROList)
+	if err != nil {
+		return nil, err
+	}
+	resp := append(rp, ero...)
+	if req.BW > 0 {
+		bw, err := newBandwidthObj(1, math.Float32bits(req.BW))
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, bw...)
+	}
+	// IGP metric of the computed path
+	metric, err := newMetricObj(&LSPMetric{
+		CFlag:  true,
+		Type:   1,
+		Metric: float32(reply.Cost),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(resp, metric...), nil
+}
+
+// srRequest is true when the request asks for an SR-MPLS path, without
+// PATH-SETUP-TYPE TLV it is RSVP-TE https://tools.ietf.org/html/rfc8408#section-3
+func (req *PathCompRequest) srRequest() bool {
+	return req.RP.PST != nil && req.RP.PST.PST == PSTSRMPLS
+}
+
+// computeReply asks the controller for an SR path
+func (s *Session) computeReply(req *PathCompRequest) *PathCompReply {
+	if s.controller == nil {
+		return &PathCompReply{NoPathVector: NoPathPCEUnavailable}
+	}
+	reply, err := s.controller.ComputeSRPath(req)
+	// NO-PATH with nature of issue 0, no path satisfies the constraints
+	var noPath *NoPathError
+	if errors.As(err, &noPath) {
+		logrus.WithFields(logrus.Fields{
+			"type":       "session",
+			"event":      "no_path",
+			"peer":       s.Conn.RemoteAddr().String(),
+			"request_id": req.RP.RequestID,
+		}).Info(err)
+		return &PathCompReply{}
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type":       "err",
+			"func":       "ComputeSRPath",
+			"peer":       s.Conn.RemoteAddr().String(),
+			"request_id": req.RP.RequestID,
+		}).Error(err)
+		return &PathCompReply{NoPathVector: NoPathPCEUnavailable}
+	}
+	return reply
+}
+
+//HandlePCReq https://tools.ietf.org/html/rfc5440#section-6.4
+// runs path computation for every request and answers with a single PCRep
+func (s *Session) HandlePCReq(data []byte) {
+	reqs, err := parsePCReq(data)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "parsePCReq",
+			"peer": s.Conn.RemoteAddr().String(),
+		}).Error(err)
+		s.reportErr(err)
+		return
+	}
+	msg := make([]byte, 0)
+	for _, req := range reqs {
+		// only SR paths are computed, requests for any other path setup
+		// type are rejected https://tools.ietf.org/html/rfc8408#section-4
+		if !req.srRequest() {
+			logrus.WithFields(logrus.Fields{
+				"type":       "pcreq",
+				"peer":       s.Conn.RemoteAddr().String(),
+				"request_id": req.RP.RequestID,
+			}).Info("only SR path setup type is supported")
+			s.sendErr(21, 1, req.rpObj)
+			continue
+		}
+		reply := s.computeReply(req)
+		resp, err := newPCRepResponse(req, reply)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"type": "err",
+				"func": "newPCRepResponse",
+				"peer": s.Conn.RemoteAddr().String(),
+			}).Error(err)
+			return
+		}
+		logrus.WithFields(logrus.Fields{
+			"type":       "pcrep",
+			"peer":       s.Conn.RemoteAddr().String(),
+			"request_id": req.RP.RequestID,
+			"src":        req.Src,
+			"dst":        req.Dst,
+			"no_path":    len(reply.EROList) == 0,
+		}).Info("path computation done")
+		msg = append(msg, resp...)
+	}
+	// every request was answered with PCErr
+	if len(msg) == 0 {
+		return
+	}
+	ch, err := newCommonHeader(4, uint16(len(msg)))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "newCommonHeader",
+		}).Error(err)
+		return
+	}
+	i, err := s.Conn.Write(append(ch, msg...))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "s.Conn.Write",
+		}).Error(err)
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"type":  "info",
+		"event": "Path Computation Reply",
+	}).Info(fmt.Sprintf("sent PCRep: %d byte", i))
+}
@@ -0,0 +1,176 @@
+package pcep
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+)
+
+func TestParsePCReq(t *testing.T) {
+	rp, err := newRPObj(&RPObject{
+		Priority:  3,
+		RequestID: 42,
+		PST:       &PathSetupType{PST: 1},
+	})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	ep, err := newEndpointsObj("10.0.0.1", "10.0.0.2")
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	data := append(rp, ep...)
+
+	reqs, err := parsePCReq(data)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if len(reqs) != 1 {
+		t.Fatalf("expected 1 request got %d", len(reqs))
+	}
+	req := reqs[0]
+	if req.RP.RequestID != 42 || req.RP.Priority != 3 {
+		t.Errorf("wrong RP decoded %+v", req.RP)
+	}
+	if req.RP.PST == nil || req.RP.PST.PST != 1 {
+		t.Errorf("PATH-SETUP-TYPE TLV must be decoded as SR")
+	}
+	if req.Src != "10.0.0.1" || req.Dst != "10.0.0.2" {
+		t.Errorf("wrong endpoints decoded src: %s dst: %s", req.Src, req.Dst)
+	}
+
+	resp, err := newPCRepResponse(req, &PathCompReply{NoPathVector: NoPathUnknownDst})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	coh, err := parseCommonObjectHeader(resp[len(rp):])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if coh.ObjectClass != 3 {
+		t.Fatalf("expected NO-PATH object after RP got class %d", coh.ObjectClass)
+	}
+	np, err := parseNoPathObj(resp[len(rp)+4:])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if np.Vector != NoPathUnknownDst {
+		t.Errorf("expected unknown destination in NO-PATH-VECTOR got %d", np.Vector)
+	}
+}
+
+func TestParsePCReqNoRP(t *testing.T) {
+	ep, err := newEndpointsObj("10.0.0.1", "10.0.0.2")
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	_, err = parsePCReq(ep)
+	if err == nil {
+		t.Errorf("PCReq without RP must be rejected")
+	}
+}
+
+func TestParsePCReqOversizedObject(t *testing.T) {
+	rp, err := newRPObj(&RPObject{RequestID: 1})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	// offset and object length add up to more than 16 bits
+	length := uint16(0x10000 - len(rp) + 2)
+	data := append(rp, 4, 0x10, uint8(length>>8), uint8(length), 0, 0)
+	_, err = parsePCReq(data)
+	var pe *pcepErr
+	if !errors.As(err, &pe) || pe.ErrType != 10 || pe.ErrValue != 11 {
+		t.Errorf("expected malformed object error got %v", err)
+	}
+}
+
+func TestPCReqRSVPRejected(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	// no PATH-SETUP-TYPE TLV means RSVP-TE
+	rp, err := newRPObj(&RPObject{RequestID: 9})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	ep, err := newEndpointsObj("10.0.0.1", "10.0.0.2")
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	s := NewSession(conn)
+	go s.HandlePCReq(append(rp, ep...))
+
+	hdr := make([]byte, 4)
+	_, err = io.ReadFull(peer, hdr)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	ch, err := parseCommonHeader(hdr)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if ch.MessageType != 6 {
+		t.Fatalf("expected PCErr got msg type %d", ch.MessageType)
+	}
+	body := make([]byte, ch.MessageLength-4)
+	_, err = io.ReadFull(peer, body)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	// the offending RP goes first and PCEP-ERROR follows
+	e, err := parseErrObj(body[len(rp):])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if e.ErrType != 21 || e.ErrValue != 1 {
+		t.Errorf("expected unsupported path setup type error got %d/%d", e.ErrType, e.ErrValue)
+	}
+}
+
+// pathCompController answers every path computation with the given error
+type pathCompController struct {
+	Controller
+	err error
+}
+
+func (c *pathCompController) ComputeSRPath(req *PathCompRequest) (*PathCompReply, error) {
+	return nil, c.err
+}
+
+func TestComputeReplyNoPath(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+	s := NewSession(conn)
+	req := &PathCompRequest{RP: &RPObject{RequestID: 1}, Src: "10.0.0.1", Dst: "10.0.0.2"}
+
+	for _, c := range []struct {
+		name   string
+		err    error
+		vector uint32
+	}{
+		{
+			name: "no path within constraints",
+			err:  &NoPathError{Src: "10.0.0.1", Dst: "10.0.0.2", Constraint: "MSD 2"},
+		},
+		{
+			name: "wrapped no path error",
+			err:  fmt.Errorf("path computation failed: %w", &NoPathError{Src: "10.0.0.1", Dst: "10.0.0.2", Constraint: "MSD 2"}),
+		},
+		{
+			name:   "path computation failure",
+			err:    errors.New("dst prefix not found"),
+			vector: NoPathPCEUnavailable,
+		},
+	} {
+		s.controller = &pathCompController{err: c.err}
+		reply := s.computeReply(req)
+		if len(reply.EROList) != 0 || reply.NoPathVector != c.vector {
+			t.Errorf("%s: expected NO-PATH with vector %d got %v", c.name, c.vector, reply)
+		}
+	}
+}
@@ -2,149 +2,170 @@ package pcep
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
 )
 
+// rejectPCRpt logs the failure and reports it back to the PCC, the SRP and LSP
+// objects seen so far are included to identify the report
+func (s *Session) rejectPCRpt(err error, fn string, objs ...[]byte) {
+	logrus.WithFields(logrus.Fields{
+		"type": "err",
+		"func": fn,
+		"peer": s.Conn.RemoteAddr().String(),
+	}).Error(err)
+	s.reportErr(err, objs...)
+}
+
 //HandlePCRpt https://tools.ietf.org/html/rfc8231#section-6.1
 // A Path Computation LSP State Report message
 func (s *Session) HandlePCRpt(data []byte) {
 	var (
-		offset    uint16
-		newOffset uint16
+		offset    int
+		newOffset int
 		lsp       LSP
+		// offending objects used in PCErr
+		srpObj []byte
+		lspObj []byte
 	)
-	for (len(data) - int(newOffset)) > 4 {
+	offending := func() [][]byte {
+		objs := make([][]byte, 0)
+		if srpObj != nil {
+			objs = append(objs, srpObj)
+		}
+		if lspObj != nil {
+			objs = append(objs, lspObj)
+		}
+		return objs
+	}
+	for (len(data) - newOffset) > 4 {
 		offset = newOffset
 		coh, err := parseCommonObjectHeader(data[newOffset : newOffset+4])
 		if err != nil {
-			logrus.WithFields(logrus.Fields{
-				"type": "err",
-				"func": "parseERO",
-			}).Error(err)
+			s.rejectPCRpt(err, "parseCommonObjectHeader", offending()...)
 			return
 		}
-		newOffset = newOffset + coh.ObjectLength
+		if coh.ObjectLength < 4 || offset+int(coh.ObjectLength) > len(data) {
+			err = newPCEPErr(10, 11, fmt.Errorf("malformed object class %d with length %d", coh.ObjectClass, coh.ObjectLength))
+			s.rejectPCRpt(err, "parseCommonObjectHeader", offending()...)
+			return
+		}
+		newOffset = newOffset + int(coh.ObjectLength)
+		obj := data[offset : offset+int(coh.ObjectLength)]
 		// printCommonObjHdr(coh, "found obj in report msg")
 		switch coh.ObjectClass {
-		case 5:
+		case 5, 6, 7, 8, 9, 32, 33:
 			if coh.ObjectType != 1 {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "bandwidth",
-				}).Error(fmt.Errorf("unknown obj type %d", coh.ObjectType))
+				err = newPCEPErr(3, 2, fmt.Errorf("unknown obj type %d of class %d", coh.ObjectType, coh.ObjectClass))
+				s.rejectPCRpt(err, "HandlePCRpt", offending()...)
 				return
-
 			}
-			lsp.BW = binary.BigEndian.Uint32(data[offset+4 : offset+8])
+		case 40:
+			if coh.ObjectType != 1 && coh.ObjectType != 2 {
+				err = newPCEPErr(3, 2, fmt.Errorf("unknown obj type %d of class %d", coh.ObjectType, coh.ObjectClass))
+				s.rejectPCRpt(err, "HandlePCRpt", offending()...)
+				return
+			}
+		default:
+			printCommonObjHdr(coh, "found unknown obj in report msg")
 			continue
-		case 6:
-			if coh.ObjectType != 1 {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseMetric",
-				}).Error(fmt.Errorf("unknown obj type %d", coh.ObjectType))
+		}
+		switch coh.ObjectClass {
+		case 5:
+			if len(obj) < 8 {
+				s.rejectPCRpt(fmt.Errorf("bandwidth obj len is %d but should be 8", len(obj)), "bandwidth", offending()...)
 				return
 			}
-			_, err := parseMetric(data[offset+4 : offset+coh.ObjectLength])
+			lsp.BW = binary.BigEndian.Uint32(obj[4:8])
+		case 6:
+			m, err := parseMetric(obj[4:])
 			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseERO",
-				}).Error(err)
+				s.rejectPCRpt(err, "parseMetric", offending()...)
 				return
 			}
-			continue
+			lsp.Metrics = append(lsp.Metrics, m)
 		case 7:
-			if coh.ObjectType != 1 {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseERO",
-				}).Error(fmt.Errorf("unknown obj type %d", coh.ObjectType))
-				return
+			if isSRv6Path(obj[4:]) {
+				lsp.SRv6EROList, err = parseSRv6ERO(obj[4:])
+			} else {
+				lsp.SREROList, err = parseERO(obj[4:])
 			}
-			lsp.SREROList, err = parseERO(data[offset+4 : offset+coh.ObjectLength])
 			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseERO",
-				}).Error(err)
+				s.rejectPCRpt(err, "parseERO", offending()...)
 				return
 			}
-			continue
 		case 8:
-			if coh.ObjectType != 1 {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseMetric",
-				}).Error(fmt.Errorf("unknown obj type %d", coh.ObjectType))
-				return
+			if isSRv6Path(obj[4:]) {
+				lsp.SRv6RROList, err = parseSRv6RRO(obj[4:])
+			} else {
+				lsp.SRRROList, err = parseRRO(obj[4:])
 			}
-			lsp.SRRROList, err = parseRRO(data[offset+4 : offset+coh.ObjectLength])
 			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseERO",
-				}).Error(err)
+				s.rejectPCRpt(err, "parseRRO", offending()...)
 				return
 			}
-			continue
 		case 9:
-			if coh.ObjectType != 1 {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseLSPAObj",
-				}).Error(fmt.Errorf("unknown obj type %d", coh.ObjectType))
-				return
-			}
-			err := lsp.parseLSPAObj(data[offset+4 : offset+coh.ObjectLength])
+			err := lsp.parseLSPAObj(obj[4:])
 			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseERO",
-				}).Error(err)
+				s.rejectPCRpt(err, "parseLSPAObj", offending()...)
 				return
 			}
-			continue
 		case 32:
-			if coh.ObjectType != 1 {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseLSPObj",
-				}).Error(fmt.Errorf("unknown obj type %d", coh.ObjectType))
-				return
-			}
-			err := lsp.parseLSPObj(data[offset+4 : offset+coh.ObjectLength])
+			lspObj = obj
+			err := lsp.parseLSPObj(obj[4:])
 			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseLSPObj",
-				}).Error(err)
+				s.rejectPCRpt(err, "parseLSPObj", offending()...)
 				return
 			}
-			continue
 		case 33:
-			if coh.ObjectType != 1 {
-				logrus.WithFields(logrus.Fields{
-					"type": "err",
-					"func": "parseSRP",
-				}).Error(fmt.Errorf("unknown obj type %d", coh.ObjectType))
+			if len(obj) < 12 {
+				s.rejectPCRpt(fmt.Errorf("srp obj len is %d but should be at least 12", len(obj)), "parseSRP", offending()...)
 				return
 			}
-			srp := parseSRP(data[offset+4:])
+			srpObj = obj
+			srp := parseSRP(obj[4:])
 			lsp.SRPID = srp.SRPIDNumber
-			continue
-		default:
-			printCommonObjHdr(coh, "found unknown obj in report msg")
+		// https://tools.ietf.org/html/rfc8697#section-6.1
+		case 40:
+			assoc, err := parseAssociationObj(coh.ObjectType, obj[4:])
+			if err != nil {
+				s.rejectPCRpt(err, "parseAssociationObj", offending()...)
+				return
+			}
+			lsp.Associations = append(lsp.Associations, assoc)
 		}
 	}
+	if lspObj == nil {
+		s.rejectPCRpt(newPCEPErr(6, 8, errors.New("LSP object missing in pcrpt")), "HandlePCRpt", offending()...)
+		return
+	}
+	// https://tools.ietf.org/html/rfc8231#section-5.6
+	// the end of synchronization marker is a report with
+	// PLSP-ID 0 and the S flag clear
+	if lsp.PLSPID == 0 && !lsp.Sync {
+		s.setDBVersion(lsp.DBVersion)
+		s.endSync()
+		return
+	}
 	if lsp.PLSPID == 0 && lsp.Name == "" {
 		logrus.WithFields(logrus.Fields{
 			"event": "empty lsp name and zero plspid in pcrpt",
 		}).Info("found lsp with no id skipping")
 		return
 	}
+	// https://tools.ietf.org/html/rfc8231#section-7.3.1
+	if lsp.IPv4ID == nil && lsp.IPv6ID == nil {
+		s.rejectPCRpt(newPCEPErr(6, 11, errors.New("LSP-IDENTIFIERS TLV missing")), "HandlePCRpt", offending()...)
+		return
+	}
+	// https://tools.ietf.org/html/rfc8231#section-7.3.2
+	// the name must be included at least in the first report of the LSP
+	if lsp.Name == "" && s.getLSPName(lsp.PLSPID) == "" {
+		s.rejectPCRpt(newPCEPErr(10, 8, errors.New("SYMBOLIC-PATH-NAME TLV missing")), "HandlePCRpt", offending()...)
+		return
+	}
 
 	if lsp.Remove {
 		logrus.WithFields(logrus.Fields{
@@ -156,6 +177,7 @@ func (s *Session) HandlePCRpt(data []byte) {
 			"lsp_name": lsp.Name,
 		}).Info("lsp deleted")
 		s.delLSP(&lsp)
+		s.setDBVersion(lsp.DBVersion)
 		return
 	}
 
@@ -168,5 +190,9 @@ func (s *Session) HandlePCRpt(data []byte) {
 		"lsp_name": lsp.Name,
 	}).Info("new lsp data")
 
+	if lsp.Sync {
+		s.syncReport(lsp.PLSPID, lsp.DBVersion)
+	}
 	s.saveUpdLSP(&lsp)
+	s.setDBVersion(lsp.DBVersion)
 }
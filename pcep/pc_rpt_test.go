@@ -0,0 +1,63 @@
+package pcep
+
+import (
+	"encoding/binary"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestPCRptMetrics(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	s := NewSession(conn)
+	lsp, err := s.newLSPObj(true, false, false, true, "lsp1", 7)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	// IPV4-LSP-IDENTIFIERS TLV https://tools.ietf.org/html/rfc8231#section-7.3.1
+	ids := []byte{0, 18, 0, 16, 10, 0, 0, 1, 0, 1, 0, 1, 10, 0, 0, 1, 10, 0, 0, 2}
+	lsp = append(lsp, ids...)
+	binary.BigEndian.PutUint16(lsp[2:4], uint16(len(lsp)))
+
+	metrics := []*LSPMetric{
+		{CFlag: true, Type: MetricTypeIGP, Metric: 30},
+		{BFlag: true, Type: MetricTypeHopCount, Metric: 5},
+	}
+	msg := lsp
+	for _, m := range metrics {
+		obj, err := newMetricObj(m)
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		msg = append(msg, obj...)
+	}
+	s.HandlePCRpt(msg)
+
+	reported := s.GetLSP("lsp1")
+	if reported == nil {
+		t.Fatalf("reported LSP must be stored")
+	}
+	if !reflect.DeepEqual(reported.Metrics, metrics) {
+		t.Errorf("expected metrics %+v got %+v", metrics, reported.Metrics)
+	}
+}
+
+func TestPCRptOversizedObject(t *testing.T) {
+	s, msgs := newTestSession(t)
+	s.State = StateUp
+	lsp, err := s.newLSPObj(true, false, false, true, "lsp1", 7)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	// end of the metric obj wraps around a 16 bit offset back into the LSP obj
+	length := uint16(0x10000 - len(lsp) + 2)
+	msg := append(lsp, 6, 0x10, uint8(length>>8), uint8(length), 0, 0, 0, 0)
+	go s.HandlePCRpt(msg)
+	expectErr(t, msgs, 10, 11)
+	if s.GetLSP("lsp1") != nil {
+		t.Error("LSP of a malformed report must not be stored")
+	}
+}
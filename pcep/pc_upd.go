@@ -0,0 +1,82 @@
+package pcep
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// https://tools.ietf.org/html/rfc8231#section-6.2
+// The format of a PCUpd message is as follows:
+//    <PCUpd Message> ::= <Common Header>
+//                        <update-request-list>
+//    Where:
+//    <update-request-list> ::= <update-request>[<update-request-list>]
+//    <update-request> ::= <SRP>
+//                         <LSP>
+//                         <path>
+//    Where:
+//    <path>::= <intended-path><attribute-list>
+// Message-Type is 11.
+func (s *Session) newPCUpdMsg(l *SRLSP) ([]byte, error) {
+	// PLSP-ID 0 is reserved and a PCUpd can only refer
+	// to an LSP the PCC has already reported to us
+	if l.PLSPID == 0 {
+		return nil, errors.New("PCUpd requires a non zero PLSP-ID")
+	}
+	srp, err := s.newSRPObject(false, l.pst())
+	if err != nil {
+		return nil, err
+	}
+	// the D flag must be set in PCUpd as the PCE continues
+	// to hold the delegation https://tools.ietf.org/html/rfc8231#section-7.3
+	bsid, err := l.newBindingSIDTLVs()
+	if err != nil {
+		return nil, err
+	}
+	lsp, err := s.newLSPObj(true, false, false, l.Admin, l.Name, l.PLSPID, bsid...)
+	if err != nil {
+		return nil, err
+	}
+	ero, err := l.newERObj()
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := l.newAttributeList()
+	if err != nil {
+		return nil, err
+	}
+	msg := append(srp, lsp...)
+	msg = append(msg, ero...)
+	msg = append(msg, attrs...)
+
+	ch, err := newCommonHeader(11, uint16(len(msg)))
+	if err != nil {
+		return nil, err
+	}
+	return append(ch, msg...), nil
+}
+
+// UpdateSRLSP modifies an already delegated LSP in place by sending PCUpd
+func (s *Session) UpdateSRLSP(l *SRLSP) error {
+	msg, err := s.newPCUpdMsg(l)
+	if err != nil {
+		return err
+	}
+	i, err := s.Conn.Write(msg)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "s.Conn.Write",
+		}).Error(err)
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"type":     "info",
+		"event":    "Update Request",
+		"lsp_name": l.Name,
+		"plsp_id":  l.PLSPID,
+	}).Info(fmt.Sprintf("sent LSP Update Request: %d byte", i))
+	return nil
+}
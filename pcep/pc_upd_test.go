@@ -0,0 +1,97 @@
+package pcep
+
+import (
+	"encoding/binary"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestNewPCUpdMsg(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	s := NewSession(conn)
+	_, err := s.newPCUpdMsg(&SRLSP{Name: "lsp1"})
+	if err == nil {
+		t.Error("expected error for PLSP-ID 0")
+	}
+
+	l := &SRLSP{
+		Name:      "lsp1",
+		Admin:     true,
+		PLSPID:    5,
+		SetupPrio: 7,
+		HoldPrio:  7,
+		BW:        1000,
+		EROList: []SREROSub{
+			{NT: 1, MBit: true, SID: 16002, IPv4NodeID: "10.0.0.2"},
+			{NT: 1, MBit: true, SID: 16004, IPv4NodeID: "10.0.0.4"},
+		},
+	}
+	msg, err := s.newPCUpdMsg(l)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	ch, err := parseCommonHeader(msg[:4])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if ch.MessageType != 11 || int(ch.MessageLength) != len(msg) {
+		t.Fatalf("wrong common header %+v for msg len %d", ch, len(msg))
+	}
+
+	classes := make([]uint8, 0)
+	for offset := 4; len(msg)-offset >= 4; {
+		coh, err := parseCommonObjectHeader(msg[offset : offset+4])
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		if coh.ObjectLength < 4 || offset+int(coh.ObjectLength) > len(msg) {
+			t.Fatalf("malformed object class %d with length %d", coh.ObjectClass, coh.ObjectLength)
+		}
+		body := msg[offset+4 : offset+int(coh.ObjectLength)]
+		offset += int(coh.ObjectLength)
+		classes = append(classes, coh.ObjectClass)
+		switch coh.ObjectClass {
+		case 33:
+			srp := parseSRP(body)
+			if srp.SRPIDNumber != s.SRPID || srp.Flags != 0 {
+				t.Errorf("expected SRP-ID %d without flags got %+v", s.SRPID, srp)
+			}
+		case 32:
+			var lsp LSP
+			err = lsp.parseLSPObj(body)
+			if err != nil {
+				t.Fatalf("must not see any errors, instead got: %s", err.Error())
+			}
+			// the PCE keeps the delegation https://tools.ietf.org/html/rfc8231#section-7.3
+			if !lsp.Delegate || !lsp.Admin || lsp.PLSPID != 5 || lsp.Name != "lsp1" {
+				t.Errorf("unexpected LSP object %+v", lsp)
+			}
+		case 7:
+			eros, err := parseERO(body)
+			if err != nil {
+				t.Fatalf("must not see any errors, instead got: %s", err.Error())
+			}
+			if len(eros) != len(l.EROList) {
+				t.Fatalf("expected %d SR-ERO subobjects got %d", len(l.EROList), len(eros))
+			}
+			for i := range eros {
+				if !reflect.DeepEqual(*eros[i], l.EROList[i]) {
+					t.Errorf("expected %+v got %+v", l.EROList[i], *eros[i])
+				}
+			}
+		case 5:
+			if bw := binary.BigEndian.Uint32(body); bw != 0x447a0000 {
+				t.Errorf("expected bandwidth 1000 got %x", bw)
+			}
+		}
+	}
+	// <SRP> <LSP> <intended-path> <attribute-list>
+	expected := []uint8{33, 32, 7, 9, 5}
+	if !reflect.DeepEqual(classes, expected) {
+		t.Errorf("expected objects %v got %v", expected, classes)
+	}
+}
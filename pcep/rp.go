@@ -0,0 +1,101 @@
+package pcep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// https://tools.ietf.org/html/rfc5440#section-7.4.1
+//    RP Object-Class is 2.
+//    RP Object-Type is 1.
+//     0                   1                   2                   3
+//     0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |                          Flags                    |O|B|R| Pri |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |                        Request-ID-number                      |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//    |                                                               |
+//    //                      Optional TLVs                          //
+//    |                                                               |
+//    +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+//RPObject Request Parameters https://tools.ietf.org/html/rfc5440#section-7.4
+type RPObject struct {
+	Flags       uint32
+	Priority    uint8
+	Reoptimize  bool
+	BiDir       bool
+	StrictLoose bool
+	RequestID   uint32
+	PST         *PathSetupType
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.4
+func parseRPObj(data []byte) (*RPObject, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("data len is %d but should be at least 8", len(data))
+	}
+	var err error
+	rp := &RPObject{
+		Flags:     binary.BigEndian.Uint32(data[:4]),
+		Priority:  data[3] & 7,
+		RequestID: binary.BigEndian.Uint32(data[4:8]),
+	}
+	rp.Reoptimize, err = uintToBool(readBits(data[3], 3))
+	if err != nil {
+		return nil, err
+	}
+	rp.BiDir, err = uintToBool(readBits(data[3], 4))
+	if err != nil {
+		return nil, err
+	}
+	rp.StrictLoose, err = uintToBool(readBits(data[3], 5))
+	if err != nil {
+		return nil, err
+	}
+	offset := 8
+	for (len(data) - offset) >= 4 {
+		tlvType := binary.BigEndian.Uint16(data[offset : offset+2])
+		length := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		// https://tools.ietf.org/html/rfc8408#section-4
+		if tlvType == 28 && len(data)-offset >= 8 {
+			rp.PST = parsePathSetupType(data[offset:])
+		}
+		offset = offset + 4 + length + ((4 - length%4) % 4)
+	}
+	return rp, nil
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.4
+func newRPObj(rp *RPObject) ([]byte, error) {
+	flags := uint32(rp.Priority & 7)
+	if rp.Reoptimize {
+		flags |= (1 << 3)
+	}
+	if rp.BiDir {
+		flags |= (1 << 4)
+	}
+	if rp.StrictLoose {
+		flags |= (1 << 5)
+	}
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.BigEndian, flags)
+	if err != nil {
+		return nil, err
+	}
+	err = binary.Write(buf, binary.BigEndian, rp.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	body := buf.Bytes()
+	if rp.PST != nil {
+		ps, err := newPathSetupObj(rp.PST.PST)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, ps...)
+	}
+	return newCommonObjHeader(2, 1, true, body)
+}
@@ -17,6 +17,8 @@ type SRRROSub struct {
 	SID           uint32
 	IPv4NodeID    string
 	IPv4Adjacency []string
+	IPv6NodeID    string
+	IPv6Adjacency []string
 	UnnuV4Adj     UnnuAdjIPv4NodeIDs
 }
 
@@ -29,8 +31,16 @@ func parseRRO(data []byte) ([]*SRRROSub, error) {
 			e   SRRROSub
 			err error
 		)
+		length := int(data[offset+1])
+		if length < 4 || offset+length > len(data) {
+			return nil, newPCEPErr(10, 11, fmt.Errorf("malformed SR-RRO subobject with length %d", length))
+		}
 		if data[offset] != 36 {
-			return nil, fmt.Errorf("wrong ero type %d", uint8(data[offset]))
+			if len(eros) > 0 {
+				return nil, newPCEPErr(10, 10, fmt.Errorf("wrong ero type %d", uint8(data[offset])))
+			}
+			// only SR paths are supported
+			return nil, newPCEPErr(21, 1, fmt.Errorf("wrong ero type %d", uint8(data[offset])))
 		}
 		e.NT = data[offset+2] >> 4
 		e.NoNAI, err = uintToBool(readBits(data[offset+3], 3))
@@ -49,22 +59,28 @@ func parseRRO(data []byte) ([]*SRRROSub, error) {
 		if err != nil {
 			return nil, err
 		}
-		if e.NoSID {
-			err = parseRRONAI(data[offset+4:], &e)
+		if e.NoSID && e.NoNAI {
+			return nil, newPCEPErr(10, 7, errors.New("both SID and NAI are absent in SR-RRO subobject"))
+		}
+		body := data[offset+4 : offset+length]
+		if !e.NoSID {
+			if len(body) < 4 {
+				return nil, newPCEPErr(10, 11, errors.New("SR-RRO subobject is too short to carry SID"))
+			}
+			e.SID = binary.BigEndian.Uint32(body[:4])
+			if e.MBit {
+				e.SID = e.SID >> 12
+			}
+			body = body[4:]
+		}
+		if !e.NoNAI {
+			err = parseRRONAI(body, &e)
 			if err != nil {
 				return nil, err
 			}
 		}
-		sid := binary.BigEndian.Uint32(data[offset+4 : offset+8])
-		if e.MBit {
-			sid = sid >> 12
-		}
-		err = parseRRONAI(data[offset+8:], &e)
-		if err != nil {
-			return nil, err
-		}
 		eros = append(eros, &e)
-		offset = offset + int(data[offset+1])
+		offset = offset + length
 	}
 	return eros, nil
 }
@@ -72,10 +88,16 @@ func parseRRO(data []byte) ([]*SRRROSub, error) {
 func parseRRONAI(data []byte, ero *SRRROSub) error {
 	switch ero.NT {
 	case 1:
+		if len(data) < 4 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 4", len(data)))
+		}
 		ip := make(net.IP, 4)
 		binary.BigEndian.PutUint32(ip, binary.BigEndian.Uint32(data[:4]))
 		ero.IPv4NodeID = ip.String()
 	case 3:
+		if len(data) < 8 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 8", len(data)))
+		}
 		localIP := make(net.IP, 4)
 		binary.BigEndian.PutUint32(localIP, binary.BigEndian.Uint32(data[:4]))
 		ero.IPv4Adjacency = make([]string, 2)
@@ -83,8 +105,31 @@ func parseRRONAI(data []byte, ero *SRRROSub) error {
 		remoteIP := make(net.IP, 4)
 		binary.BigEndian.PutUint32(remoteIP, binary.BigEndian.Uint32(data[4:8]))
 		ero.IPv4Adjacency[1] = remoteIP.String()
+	case 2:
+		if len(data) < 16 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 16", len(data)))
+		}
+		ero.IPv6NodeID = bytesToIP(data[:16])
+	case 4:
+		if len(data) < 32 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 32", len(data)))
+		}
+		ero.IPv6Adjacency = []string{
+			0: bytesToIP(data[:16]),
+			1: bytesToIP(data[16:32]),
+		}
+	case 5:
+		if len(data) < 16 {
+			return newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be 16", len(data)))
+		}
+		ero.UnnuV4Adj = UnnuAdjIPv4NodeIDs{
+			LocalNodeID:       bytesToIP(data[:4]),
+			LocalInterfaceID:  binary.BigEndian.Uint32(data[4:8]),
+			RemoteNodeID:      bytesToIP(data[8:12]),
+			RemoteInterfaceID: binary.BigEndian.Uint32(data[12:16]),
+		}
 	default:
-		return errors.New("NAI type not implemented yet")
+		return newPCEPErr(10, 13, errors.New("NAI type not implemented yet"))
 	}
 	return nil
 }
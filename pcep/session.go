@@ -1,9 +1,10 @@
 package pcep
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
-	"strings"
 	"sync"
 	"time"
 
@@ -15,7 +16,8 @@ type Session struct {
 	*sync.RWMutex
 	ID                uint8
 	MsgCount          uint64
-	State             int
+	State             SessionState
+	SyncState         SyncState
 	Conn              net.Conn
 	RemoteOK          bool
 	LocalOK           bool
@@ -28,27 +30,58 @@ type Session struct {
 	StopKA            chan struct{} `json:"-"`
 	RcvKA             chan bool     `json:"-"`
 	SRCap             *SRPCECap
+	SRv6Cap           *SRv6PCECap
 	StatefulCap       *StatefulPCECapability
 	Open              *OpenObject
 	SessionReady      chan bool `json:"-"`
+	SessionSynced     chan bool `json:"-"`
 	SessionClosed     chan bool `json:"-"`
 	SessionErrRecived chan bool `json:"-"`
+	OverloadEnded     chan bool `json:"-"`
+	LocalCaps         Capabilities
+	TLS               bool
+	SpeakerEntityID   string
+	LSPDBVersion      uint64
+	controller        Controller
+	cfg               *Cfg
+	fsmTimer          *time.Timer
+	// number of Open messages from the peer rejected as negotiable
+	openRetry uint8
+	// number of our Open messages renegotiated after PCErr from the peer
+	localRetry uint8
+	// set while the PCC reports it is overloaded
+	overloaded    bool
+	overloadTimer *time.Timer
+	// LSP-DB kept from the previous session with the PCC
+	storedDB *LSPDB
+	// signals lspDBSaver the LSP-DB changed and has to be stored
+	dbSave chan struct{}
+	// set while the PCC only reports LSPs changed since storedDB
+	deltaSync bool
+	// set once a report during incremental sync carries no LSP-DB-VERSION
+	// of a change made since storedDB, the PCC does full sync instead
+	deltaFallback bool
+	// LSPs reported during a full sync, the others are stale
+	syncedPLSPIDs map[uint32]bool
 }
 
 //NewSession creates a new session with defaults
 func NewSession(conn net.Conn) *Session {
 	return &Session{
-		Conn:         conn,
-		StopKA:       make(chan struct{}),
-		RcvKA:        make(chan bool),
-		Keepalive:    30,
-		PLSPIDToName: make(map[uint32]string),
-		LSPs:         make(map[string]*LSP),
-		SessionReady: make(chan bool),
-		RWMutex:      &sync.RWMutex{},
-		SRCap:        &SRPCECap{},
-		StatefulCap:  &StatefulPCECapability{},
-		Open:         &OpenObject{},
+		Conn:          conn,
+		StopKA:        make(chan struct{}),
+		RcvKA:         make(chan bool),
+		Keepalive:     30,
+		PLSPIDToName:  make(map[uint32]string),
+		LSPs:          make(map[string]*LSP),
+		SessionReady:  make(chan bool),
+		SessionSynced: make(chan bool, 1),
+		OverloadEnded: make(chan bool, 1),
+		dbSave:        make(chan struct{}, 1),
+		RWMutex:       &sync.RWMutex{},
+		SRCap:         &SRPCECap{},
+		StatefulCap:   &StatefulPCECapability{},
+		Open:          &OpenObject{},
 	}
 }
 
@@ -58,19 +91,26 @@ func NewSession(conn net.Conn) *Session {
 // hold the lock while we marshal. Alos the copy can be used to matshal
 // into anything not only JSON
 type ExportableSession struct {
-	ID          uint8
-	MsgCount    uint64
-	State       int
-	Conn        net.Conn
-	RemoteOK    bool
-	LocalOK     bool
-	Keepalive   uint8
-	DeadTimer   uint8
-	IDCounter   uint32
-	SRPID       uint32
-	SRCap       SRPCECap
-	StatefulCap StatefulPCECapability
-	Open        OpenObject
+	ID              uint8
+	MsgCount        uint64
+	State           SessionState
+	SyncState       SyncState
+	Conn            net.Conn
+	RemoteOK        bool
+	LocalOK         bool
+	Keepalive       uint8
+	DeadTimer       uint8
+	IDCounter       uint32
+	SRPID           uint32
+	SRCap           SRPCECap
+	SRv6Cap         *SRv6PCECap
+	StatefulCap     StatefulPCECapability
+	Open            OpenObject
+	LocalCaps       Capabilities
+	TLS             bool
+	Overloaded      bool
+	SpeakerEntityID string
+	LSPDBVersion    uint64
 }
 
 func (s *Session) CopyToExportableSession() *ExportableSession {
@@ -79,26 +119,107 @@ func (s *Session) CopyToExportableSession() *ExportableSession {
 	s.RLock()
 
 	return &ExportableSession{
-		ID:          s.ID,
-		MsgCount:    s.MsgCount,
-		State:       s.State,
-		Conn:        s.Conn,
-		RemoteOK:    s.RemoteOK,
-		LocalOK:     s.LocalOK,
-		Keepalive:   s.Keepalive,
-		DeadTimer:   s.DeadTimer,
-		IDCounter:   s.IDCounter,
-		SRPID:       s.SRPID,
-		SRCap:       *s.SRCap,
-		StatefulCap: *s.StatefulCap,
-		Open:        *s.Open,
+		ID:              s.ID,
+		MsgCount:        s.MsgCount,
+		State:           s.State,
+		SyncState:       s.SyncState,
+		Conn:            s.Conn,
+		RemoteOK:        s.RemoteOK,
+		LocalOK:         s.LocalOK,
+		Keepalive:       s.Keepalive,
+		DeadTimer:       s.DeadTimer,
+		IDCounter:       s.IDCounter,
+		SRPID:           s.SRPID,
+		SRCap:           *s.SRCap,
+		SRv6Cap:         s.SRv6Cap,
+		StatefulCap:     *s.StatefulCap,
+		Open:            *s.Open,
+		LocalCaps:       s.LocalCaps,
+		TLS:             s.TLS,
+		Overloaded:      s.overloaded,
+		SpeakerEntityID: s.SpeakerEntityID,
+		LSPDBVersion:    s.LSPDBVersion,
 	}
 }
 
+// SRv6Capable is true when the PCC advertised SRv6-PCE-CAPABILITY
+func (s *Session) SRv6Capable() bool {
+	defer s.RUnlock()
+	s.RLock()
+	return s.SRv6Cap != nil
+}
+
+// CanInitiate is true when the PCC advertised the I flag
+// in STATEFUL-PCE-CAPABILITY https://tools.ietf.org/html/rfc8281#section-4.1
+func (s *Session) CanInitiate() bool {
+	defer s.RUnlock()
+	s.RLock()
+	return s.StatefulCap != nil && s.StatefulCap.Type == 16 && s.StatefulCap.LSPInitCap
+}
+
+// CanUpdate is true when the PCC advertised the U flag
+// in STATEFUL-PCE-CAPABILITY https://tools.ietf.org/html/rfc8231#section-7.1.1
+func (s *Session) CanUpdate() bool {
+	defer s.RUnlock()
+	s.RLock()
+	return s.StatefulCap != nil && s.StatefulCap.Type == 16 && s.StatefulCap.UPDFlag
+}
+
+// SupportsPST is true when the PCC can set up LSPs with the given path setup
+// type, without PATH-SETUP-TYPE-CAPABILITY it only supports RSVP-TE and SR-MPLS
+// when it sent SR-PCE-CAPABILITY https://tools.ietf.org/html/rfc8408#section-4
+func (s *Session) SupportsPST(pst uint8) bool {
+	defer s.RUnlock()
+	s.RLock()
+	if s.Open != nil && s.Open.PSTCap != nil {
+		for _, p := range s.Open.PSTCap.PSTs {
+			if p == pst {
+				return true
+			}
+		}
+		return false
+	}
+	switch pst {
+	case PSTRSVPTE:
+		return true
+	case PSTSRMPLS:
+		return s.SRCap != nil && s.SRCap.Type == 26
+	}
+	return false
+}
+
+// SupportsAssocType is true when the PCC listed the association type in
+// ASSOC-Type-List, without the TLV all types are assumed to be supported
+// https://tools.ietf.org/html/rfc8697#section-5.1
+func (s *Session) SupportsAssocType(t uint16) bool {
+	defer s.RUnlock()
+	s.RLock()
+	if s.Open == nil || len(s.Open.AssocTypes) == 0 {
+		return true
+	}
+	for _, at := range s.Open.AssocTypes {
+		if at == t {
+			return true
+		}
+	}
+	return false
+}
+
+// SRMSD is the maximum SID depth the PCC advertised, 0 means no limit
+// https://tools.ietf.org/html/rfc8664#section-4.1.2
+func (s *Session) SRMSD() uint8 {
+	defer s.RUnlock()
+	s.RLock()
+	if s.SRCap == nil || s.SRCap.NoMSDLimit {
+		return 0
+	}
+	return s.SRCap.MSD
+}
+
 func (s *Session) GetSrcAddrFromSession() string {
 	defer s.RUnlock()
 	s.RLock()
-	return strings.Split(s.Conn.RemoteAddr().String(), ":")[0]
+	return remoteIP(s.Conn)
 }
 
 func (s *Session) saveUpdLSP(lsp *LSP) {
@@ -129,6 +250,15 @@ func (s *Session) delLSP(lsp *LSP) {
 		delete(s.PLSPIDToName, lsp.PLSPID)
 	}
 }
+
+func (s *Session) getLSPName(plspID uint32) string {
+	defer s.RUnlock()
+
+	s.RLock()
+
+	return s.PLSPIDToName[plspID]
+}
+
 func (s *Session) GetLSP(name string) *LSP {
 	defer s.RUnlock()
 
@@ -138,7 +268,7 @@ func (s *Session) GetLSP(name string) *LSP {
 }
 
 //ProcessOpen recive msg handler
-func (s *Session) ProcessOpen(data []byte) {
+func (s *Session) ProcessOpen(data []byte) error {
 	defer s.Unlock()
 
 	s.Lock()
@@ -154,16 +284,19 @@ func (s *Session) ProcessOpen(data []byte) {
 			"caller": "RcvSessionOpen",
 			"func":   "parseCommonObjectHeader",
 		}).Error(err)
-		return
+		return err
 	}
-	if h.ObjectClass != 1 && h.ObjectType != 1 {
+	if h.ObjectClass != 1 || h.ObjectType != 1 {
 		logrus.WithFields(logrus.Fields{
 			"type":   "err",
 			"caller": "RcvSessionOpen",
 			"func":   "parseCommonObjectHeader",
 		}).Error(fmt.Sprintf("Remote IP: %s, object class and object type do not match OPEN msg RFC definitions", s.Conn.RemoteAddr()))
 
-		return
+		return errors.New("object class and object type do not match OPEN msg RFC definitions")
+	}
+	if len(data) < 8 || h.ObjectLength < 8 || int(h.ObjectLength) > len(data) {
+		return fmt.Errorf("malformed OPEN object with length %d", h.ObjectLength)
 	}
 	s.Open, err = parseOpenObject(data[4:8])
 	if err != nil {
@@ -171,7 +304,7 @@ func (s *Session) ProcessOpen(data []byte) {
 			"type": "err",
 			"func": "parseOpenObject",
 		}).Error(err)
-		return
+		return err
 	}
 
 	logrus.WithFields(logrus.Fields{
@@ -181,23 +314,43 @@ func (s *Session) ProcessOpen(data []byte) {
 	}).Info("parsed open obj")
 
 	s.ID = s.Open.SID
-	s.StatefulCap, err = parseStatefulPCECap(data[8:16])
+	// capabilities are optional and only set when present
+	err = s.Open.parseOpenTLVs(data[8:h.ObjectLength])
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"type": "err",
-			"func": "parseStatefulPCECap",
+			"func": "parseOpenTLVs",
 		}).Error(err)
-		return
+		return err
 	}
-	s.SRCap, err = parseSRCap(data[16:24])
-	if err != nil {
+	// https://tools.ietf.org/html/rfc8232#section-3.2
+	if s.Open.LSPDBVersion != 0 {
+		s.LSPDBVersion = s.Open.LSPDBVersion
+	}
+	if s.Open.SpeakerEntityID != "" {
+		s.SpeakerEntityID = s.Open.SpeakerEntityID
+	}
+	if s.Open.StatefulCap != nil {
+		s.StatefulCap = s.Open.StatefulCap
+	}
+	// SRv6 and SR-MPLS with PST capability come in PATH-SETUP-TYPE-CAPABILITY
+	// standalone SR-PCE-CAPABILITY is only used without it
+	if s.Open.PSTCap != nil {
+		s.SRv6Cap = s.Open.PSTCap.SRv6
+		if s.Open.PSTCap.SR != nil {
+			s.SRCap = s.Open.PSTCap.SR
+		}
+	} else if s.Open.SRCap != nil {
+		s.SRCap = s.Open.SRCap
+	}
+	for _, tlv := range s.Open.UnknownTLVs {
 		logrus.WithFields(logrus.Fields{
-			"type": "err",
-			"func": "parseSRCap",
-		}).Error(err)
-		return
+			"type":   tlv.Type,
+			"length": len(tlv.Value),
+			"peer":   s.Conn.RemoteAddr().String(),
+		}).Info("unknown open obj TLV")
 	}
-
+	return nil
 }
 
 //SendSessionOpen send OPEN msg handler
@@ -205,51 +358,20 @@ func (s *Session) SendSessionOpen() {
 	defer s.Unlock()
 
 	s.Lock()
-	//[00100000 00000001 00000000 00011100]
-	commH := []byte{
-		0: 32,
-		1: 1,
-		2: 0,
-		3: 28,
-	}
-	// 00000001 00010000 00000000 00011000
-	commObjH := []byte{
-		0: 1,
-		1: 16,
-		2: 0,
-		3: 24,
-	}
-	// 00100000 00011110 01111000 00000001
-	open := []byte{
-		0: 32,
-		1: 30,
-		2: 120,
-		3: s.ID,
-	}
-	stCap := []byte{
-		0: 0,
-		1: 16,
-		2: 0,
-		3: 4,
-		4: 0,
-		5: 0,
-		6: 0,
-		7: 5,
-	}
-	srCap := []byte{
-		0: 0,
-		1: 26,
-		2: 0,
-		3: 4,
-		4: 0,
-		5: 0,
-		6: 0,
-		7: 5,
-	}
-	packet := append(commH, commObjH...)
-	packet = append(packet, open...)
-	packet = append(packet, stCap...)
-	packet = append(packet, srCap...)
+
+	packet, err := newOpenMsg(&OpenObject{
+		Keepalive:    s.Keepalive,
+		DeadTimer:    s.DeadTimer,
+		SID:          s.ID,
+		LSPDBVersion: s.localDBVersion(),
+	}, &s.LocalCaps)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"type": "err",
+			"func": "newOpenMsg",
+		}).Error(err)
+		return
+	}
 
 	i, err := s.Conn.Write(packet)
 	if err != nil {
@@ -260,8 +382,10 @@ func (s *Session) SendSessionOpen() {
 		}).Error(err)
 	}
 	logrus.WithFields(logrus.Fields{
-		"type":  "info",
-		"event": "open",
+		"type":      "info",
+		"event":     "open",
+		"keepalive": s.Keepalive,
+		"deadtimer": s.DeadTimer,
 	}).Info(fmt.Sprintf("sent open: %d byte", i))
 }
 
@@ -280,16 +404,23 @@ func (s *Session) StartKeepAlive() {
 
 	var firstSent bool
 
-	s.RLock()
-	k := s.Keepalive
-	s.RUnlock()
-
 	for {
+		// keepalive can change if our Open gets renegotiated
+		s.RLock()
+		k := s.Keepalive
+		s.RUnlock()
+
 		logrus.WithFields(logrus.Fields{
 			"peer":      s.Conn.RemoteAddr().String(),
-			"keepalive": s.Keepalive,
+			"keepalive": k,
 		}).Info("sent keepalive")
 		if firstSent {
+			// keepalive of zero means only the first one acknowledging
+			// the Open is sent https://tools.ietf.org/html/rfc5440#section-7.3
+			if k == 0 {
+				<-s.StopKA
+				return
+			}
 			time.Sleep(time.Second * time.Duration(k))
 		}
 		select {
@@ -315,14 +446,29 @@ func (s *Session) StartKeepAlive() {
 
 //HandleDeadTimer start dead timer and wait for keepalive
 func (s *Session) HandleDeadTimer() {
+	s.RLock()
+	deadTimer := time.Duration(s.Open.DeadTimer) * time.Second
+	// DeadTimer must be ignored if the peer keepalive is zero
+	if s.Open.Keepalive == 0 {
+		deadTimer = 0
+	}
+	s.RUnlock()
+
 	for {
+		var expired <-chan time.Time
+		// DeadTimer of zero means the peer is not going to send keepalives
+		if deadTimer > 0 {
+			expired = time.After(deadTimer)
+		}
 		select {
 		case res := <-s.RcvKA:
 			logrus.WithFields(logrus.Fields{
 				"topic": "recieved keepalive",
 				"peer":  s.Conn.RemoteAddr().String(),
 			}).Info(res)
-		case <-time.After(time.Duration(s.Open.DeadTimer) * time.Second):
+		case <-s.StopKA:
+			return
+		case <-expired:
 			logrus.WithFields(logrus.Fields{
 				"topic": "dead timer expiried",
 				"peer":  s.Conn.RemoteAddr().String(),
@@ -330,40 +476,32 @@ func (s *Session) HandleDeadTimer() {
 
 			// https://tools.ietf.org/html/rfc5440#section-7.17
 			// 2          DeadTimer expired
-			msg, err := newCloseMsg(2)
-			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"topic": "close msg creation failure",
-					"peer":  s.Conn.RemoteAddr().String(),
-				}).Error(err)
-				return
-			}
-			_, err = s.Conn.Write(msg)
-			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"topic": "tcp write failure",
-					"peer":  s.Conn.RemoteAddr().String(),
-				}).Error(err)
-				return
-			}
-			err = s.Conn.Close()
-			if err != nil {
-				logrus.WithFields(logrus.Fields{
-					"topic": "tcp conn close failure",
-					"peer":  s.Conn.RemoteAddr().String(),
-				}).Error(err)
-			}
+			s.closeSession(2)
+			return
 		}
 	}
 }
 
+// rejectMalformedMsg answers a message too short to carry its mandatory object
+// https://tools.ietf.org/html/rfc5440#section-6.7
+func (s *Session) rejectMalformedMsg(msgType uint8) {
+	logrus.WithFields(logrus.Fields{
+		"type":     "session",
+		"event":    "malformed_msg",
+		"msg_type": msgType,
+		"peer":     s.Conn.RemoteAddr().String(),
+	}).Error("message is missing its mandatory object")
+	// reception of an invalid object, malformed object
+	s.sendErr(10, 11)
+}
+
 // HandleNewMsg handles incoming data
 func (s *Session) HandleNewMsg(data []byte) {
 	var (
-		offset    uint16
-		newOffset uint16
+		offset    int
+		newOffset int
 	)
-	for (len(data) - int(newOffset)) >= 4 {
+	for (len(data) - newOffset) >= 4 {
 		offset = newOffset
 		ch, err := parseCommonHeader(data[newOffset : newOffset+4])
 		if err != nil {
@@ -375,18 +513,27 @@ func (s *Session) HandleNewMsg(data []byte) {
 			}).Error(err)
 			return
 		}
-		newOffset = newOffset + ch.MessageLength
+		newOffset = newOffset + int(ch.MessageLength)
+		if ch.MessageType == 13 {
+			s.handleStartTLS()
+			return
+		}
+		if !s.msgAllowed(ch.MessageType) {
+			logrus.WithFields(logrus.Fields{
+				"type":     "fsm",
+				"event":    "unexpected_msg",
+				"msg_type": ch.MessageType,
+				"state":    s.GetState().String(),
+				"peer":     s.Conn.RemoteAddr().String(),
+			}).Error("message not allowed in current state")
+			// reception of an invalid Open message or a non Open message
+			s.sendErr(1, 1)
+			s.closeSession(1)
+			return
+		}
 		switch {
 		case ch.MessageType == 1:
-			s.ProcessOpen(data[offset+4:])
-			s.SendSessionOpen()
-
-			s.Lock()
-			s.State = 2
-			s.Unlock()
-
-			go s.StartKeepAlive()
-			go s.HandleDeadTimer()
+			s.handleOpen(data[offset+4 : offset+int(ch.MessageLength)])
 
 		case ch.MessageType == 2:
 			logrus.WithFields(logrus.Fields{
@@ -396,14 +543,7 @@ func (s *Session) HandleNewMsg(data []byte) {
 				"peer":     s.Conn.RemoteAddr().String(),
 			}).Info("received new KA msg")
 
-			s.RcvKA <- true
-
-			s.Lock()
-			if s.State == 2 {
-				s.SessionReady <- true
-			}
-			s.State = 3
-			s.Unlock()
+			s.handleKeepalive()
 
 		case ch.MessageType == 3:
 			logrus.WithFields(logrus.Fields{
@@ -411,26 +551,31 @@ func (s *Session) HandleNewMsg(data []byte) {
 				"event":    "new_msg",
 				"msg_type": ch.MessageType,
 				"peer":     s.Conn.RemoteAddr().String(),
-				"msg":      parseClose(data[offset+8 : offset+12]),
 			}).Info("received path computation request")
+			s.HandlePCReq(data[offset+4 : offset+int(ch.MessageLength)])
 		case ch.MessageType == 4:
+			// PCRep is only ever sent by a PCE so there is nothing to do
 			logrus.WithFields(logrus.Fields{
 				"type":     "session",
 				"event":    "new_msg",
 				"msg_type": ch.MessageType,
 				"peer":     s.Conn.RemoteAddr().String(),
-				"msg":      parseClose(data[offset+8 : offset+12]),
-			}).Info("received path computation reply")
+			}).Info("received unexpected path computation reply")
 		case ch.MessageType == 5:
 			logrus.WithFields(logrus.Fields{
 				"type":     "session",
 				"event":    "new_msg",
 				"msg_type": ch.MessageType,
 				"peer":     s.Conn.RemoteAddr().String(),
-				"msg":      parseClose(data[offset+8 : offset+12]),
 			}).Info("received new notification msg")
+			s.handlePCNtf(data[offset+4 : offset+int(ch.MessageLength)])
 
 		case ch.MessageType == 6:
+			// PCEP-ERROR object is mandatory
+			if ch.MessageLength < 12 {
+				s.rejectMalformedMsg(ch.MessageType)
+				continue
+			}
 			logrus.WithFields(logrus.Fields{
 				"type":     "session",
 				"event":    "new_msg",
@@ -439,8 +584,13 @@ func (s *Session) HandleNewMsg(data []byte) {
 				"msg":      parseClose(data[offset+8 : offset+12]),
 			}).Info(fmt.Sprintf("new err received with msg len %d and binary representation: %08b \n", ch.MessageLength, data[:ch.MessageLength]))
 
-			s.handleErrObj(data[offset+4 : offset+ch.MessageLength])
+			s.handleOpenErr(data[offset+4 : offset+int(ch.MessageLength)])
 		case ch.MessageType == 7:
+			// CLOSE object is mandatory
+			if ch.MessageLength < 12 {
+				s.rejectMalformedMsg(ch.MessageType)
+				continue
+			}
 			logrus.WithFields(logrus.Fields{
 				"type":     "session",
 				"event":    "new_msg",
@@ -449,6 +599,11 @@ func (s *Session) HandleNewMsg(data []byte) {
 				"msg":      parseClose(data[offset+8 : offset+12]),
 			}).Info("new close msg received")
 
+			s.Lock()
+			s.stopFSMTimer()
+			s.setState(StateIdle)
+			s.Unlock()
+
 			err := s.Conn.Close()
 			if err != nil {
 				if err != nil {
@@ -459,13 +614,14 @@ func (s *Session) HandleNewMsg(data []byte) {
 					}).Error(err)
 				}
 			}
+			return
 		case ch.MessageType == 10:
 			logrus.WithFields(logrus.Fields{
 				"type": "path computation lsp state report",
 				"peer": s.Conn.RemoteAddr().String(),
 				"len":  ch.MessageLength,
 			}).Info("new msg")
-			s.HandlePCRpt(data[offset+4 : offset+ch.MessageLength])
+			s.HandlePCRpt(data[offset+4 : offset+int(ch.MessageLength)])
 		case ch.MessageType == 11:
 			logrus.WithFields(logrus.Fields{
 				"type":     "session",
@@ -495,10 +651,26 @@ type Controller interface {
 	SessionStart(*Session) error
 	SessionEnd(string)
 	GetClients() []string
+	ComputeSRPath(*PathCompRequest) (*PathCompReply, error)
+	GetOpenParams(string) *OpenParams
+	GetTLSPolicy(string) TLSPolicy
+	GetTLSIdentities(string) []string
+	GetPeerAuth() map[string]*AuthKey
+	PeerAuthUpdates() <-chan struct{}
+	GetLSPDB(string) *LSPDB
+	StoreLSPDB(*LSPDB) error
 }
 
-func startPCEPSession(conn net.Conn, controller Controller) {
+func startPCEPSession(conn net.Conn, controller Controller, cfg *Cfg) {
 	session := NewSession(conn)
+	session.controller = controller
+	session.setOpenParams(cfg, controller.GetOpenParams(session.GetSrcAddrFromSession()))
+	if session.LocalCaps.DBVersion {
+		// the SPEAKER-ENTITY-ID is only known from the Open of the PCC
+		// so the address is all we have for the version in our Open
+		session.storedDB = controller.GetLSPDB(session.GetSrcAddrFromSession())
+		go session.lspDBSaver()
+	}
 	err := controller.SessionStart(session)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -509,6 +681,11 @@ func startPCEPSession(conn net.Conn, controller Controller) {
 	}
 
 	defer func() {
+		session.Lock()
+		session.stopFSMTimer()
+		session.setState(StateIdle)
+		session.Unlock()
+
 		err := conn.Close()
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
@@ -516,12 +693,62 @@ func startPCEPSession(conn net.Conn, controller Controller) {
 				"remote_addr": conn.RemoteAddr().String(),
 			}).Error(err)
 		}
-		controller.SessionEnd(strings.Split(conn.RemoteAddr().String(), ":")[0])
+		controller.SessionEnd(remoteIP(conn))
 	}()
 
+	reader := newMsgReader(conn)
+
+	policy := controller.GetTLSPolicy(session.GetSrcAddrFromSession())
+	if policy == "" {
+		policy = cfg.TLSPolicy
+	}
+	if !policy.Valid() {
+		logrus.WithFields(logrus.Fields{
+			"topic":       "starttls",
+			"remote_addr": conn.RemoteAddr().String(),
+			"policy":      policy,
+		}).Error("unknown tls policy refusing connection")
+		close(session.StopKA)
+		return
+	}
+	if policy == TLSDisable {
+		session.startFSM()
+	} else {
+		// the PCC certificate must be issued for its address or configured names
+		ids := append([]string{remoteIP(conn)}, controller.GetTLSIdentities(session.GetSrcAddrFromSession())...)
+		var first []byte
+		reader, first, err = session.startTLS(reader, policy, cfg.tlsConfig, ids)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"topic":       "starttls",
+				"remote_addr": conn.RemoteAddr().String(),
+				"policy":      policy,
+			}).Error(err)
+			close(session.StopKA)
+			return
+		}
+		// Open received instead of StartTLS
+		if first != nil {
+			session.HandleNewMsg(first)
+		}
+	}
+
 	for {
-		buff := make([]byte, 1024)
-		l, err := conn.Read(buff)
+		msg, err := reader.readMsg()
+		if errors.Is(err, errMalformedMsg) {
+			logrus.WithFields(logrus.Fields{
+				"topic":       "malformed msg",
+				"remote_addr": conn.RemoteAddr().String(),
+			}).Error(err)
+			// once the length can not be trusted there is no way
+			// to find the next message so the session has to go
+			// https://tools.ietf.org/html/rfc5440#section-7.17
+			// 3          Reception of a malformed PCEP message
+			session.sendErr(10, 11)
+			session.sendClose(3)
+			close(session.StopKA)
+			return
+		}
 		if err != nil {
 			logrus.WithFields(logrus.Fields{
 				"topic":       "conn read error",
@@ -532,28 +759,51 @@ func startPCEPSession(conn net.Conn, controller Controller) {
 		}
 		logrus.WithFields(logrus.Fields{
 			"remote_addr": conn.RemoteAddr().String(),
-			"len":         l,
-		}).Info("got some data ")
-		session.HandleNewMsg(buff[:l])
+			"len":         len(msg),
+		}).Info("got new msg")
+		session.HandleNewMsg(msg)
 	}
 }
 
 func clientNotInConfig(conn net.Conn, controller Controller) bool {
 	for _, ip := range controller.GetClients() {
-		if strings.Split(conn.RemoteAddr().String(), ":")[0] == ip {
+		if remoteIP(conn) == ip {
 			return false
 		}
 	}
 	return true
 }
 
+//Cfg is the PCEP listener config and the default session characteristics
 type Cfg struct {
 	ListenAddr string
 	ListenPort string
 	Keepalive  uint8
+	DeadTimer  uint8
+	// acceptable range for the timers proposed by peers
+	MinKeepalive uint8
+	MaxKeepalive uint8
+	MinDeadTimer uint8
+	MaxDeadTimer uint8
+	Capabilities Capabilities
+	// PCEPS https://tools.ietf.org/html/rfc8253
+	TLSPolicy   TLSPolicy
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSCAFile   string
+	tlsConfig   *tls.Config
 }
 
 func ListenForNewSession(controller Controller, cfg *Cfg) error {
+	cfg.setDefaults()
+	err := cfg.loadTLSConfig()
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"topic": "listen",
+			"event": "tls config error",
+		}).Error(err)
+		return err
+	}
 	ln, err := net.Listen("tcp", cfg.ListenAddr+":"+cfg.ListenPort)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -563,6 +813,17 @@ func ListenForNewSession(controller Controller, cfg *Cfg) error {
 		}).Error(err)
 		return err
 	}
+
+	// keys have to be on the listening socket before peers connect
+	// and are kept in sync with routers added or removed later on
+	auth := newListenerAuth(ln)
+	auth.sync(controller.GetPeerAuth())
+	go func() {
+		for range controller.PeerAuthUpdates() {
+			auth.sync(controller.GetPeerAuth())
+		}
+	}()
+
 	for {
 		conn, err := ln.Accept()
 		if err != nil {
@@ -581,11 +842,19 @@ func ListenForNewSession(controller Controller, cfg *Cfg) error {
 		if clientNotInConfig(conn, controller) {
 			continue
 		}
+		err = auth.refuse(remoteIP(conn))
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"remote_addr": conn.RemoteAddr().String(),
+			}).Error(err)
+			conn.Close()
+			continue
+		}
 		logrus.WithFields(logrus.Fields{
 			"remote_addr": conn.RemoteAddr().String(),
 		}).Info("new connection after client check")
 
-		go startPCEPSession(conn, controller)
+		go startPCEPSession(conn, controller, cfg)
 	}
 
 }
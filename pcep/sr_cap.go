@@ -48,3 +48,19 @@ func parseSRCap(data []byte) (*SRPCECap, error) {
 	}).Info("parsed sr capability obj")
 	return srCap, nil
 }
+
+// https://tools.ietf.org/html/draft-ietf-pce-segment-routing-14#section-5.1.1
+// SR-PCE-CAPABILITY TLV type is 26 and length is 4
+// N and L flags are never set by us
+func newSRCap(msd uint8) []byte {
+	return []byte{
+		0: 0,
+		1: 26,
+		2: 0,
+		3: 4,
+		4: 0,
+		5: 0,
+		6: 0,
+		7: msd,
+	}
+}
@@ -3,9 +3,13 @@ package pcep
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/bits"
+	"net"
+	"strconv"
 
 	"github.com/sirupsen/logrus"
 )
@@ -22,22 +26,115 @@ type SRLSP struct {
 	Src          string
 	Dst          string
 	EROList      []SREROSub
+	SRv6EROList  []SRv6EROSub
+	PST          uint8
 	SetupPrio    uint8
 	HoldPrio     uint8
 	LocalProtect bool
 	BW           uint32
 	SRPRemove    bool
 	PLSPID       uint32
+	// LSPA affinities https://tools.ietf.org/html/rfc5440#section-7.11
+	ExcludeAny uint32
+	IncludeAny uint32
+	IncludeAll uint32
+	// IGP/TE/hop count/delay metrics, bounds have the B flag set
+	Metrics []*LSPMetric
+	// MPLS label Binding SID https://www.rfc-editor.org/rfc/rfc9604
+	BindingSID uint32
+	// the controller picks the Binding SID from its label range
+	AllocBindingSID bool
+	Associations    []*Association
+	// the controller computes a working and a disjoint protecting
+	// path for protected LSPs https://tools.ietf.org/html/rfc8745
+	Protected bool
+	// protecting path avoids the working path transit nodes
+	// and not just its links
+	NodeDisjoint bool
+	// why the controller did not push the LSP to the PCC, empty once it did
+	SkipReason string
+}
+
+// newBindingSIDTLVs encodes TE-PATH-BINDING TLV carried in the LSP object
+// https://www.rfc-editor.org/rfc/rfc9604#section-4
+func (l *SRLSP) newBindingSIDTLVs() ([][]byte, error) {
+	if l.BindingSID == 0 {
+		return nil, nil
+	}
+	tlv, err := newTEPathBindingTLV(&BindingSID{
+		Type:  BindingTypeMPLSLabel,
+		Label: l.BindingSID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{tlv}, nil
+}
+
+// newAttributeList encodes LSPA, BANDWIDTH, METRICs and ASSOCIATIONs
+// https://tools.ietf.org/html/rfc8281#section-5.1
+func (l *SRLSP) newAttributeList() ([]byte, error) {
+	attrs, err := newLSPAObject(&LSPAObject{
+		ExcludeAny:   l.ExcludeAny,
+		IncludeAny:   l.IncludeAny,
+		IncludeAll:   l.IncludeAll,
+		SetupPrio:    l.SetupPrio,
+		HoldPrio:     l.HoldPrio,
+		LocalProtect: l.LocalProtect,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if l.BW > 0 {
+		// bandwidth is encoded in IEEE floating point format in bytes per second
+		bw, err := newBandwidthObj(1, math.Float32bits(float32(l.BW)))
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, bw...)
+	}
+	for _, m := range l.Metrics {
+		metric, err := newMetricObj(m)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, metric...)
+	}
+	assoc, err := newAssociationList(l.Associations)
+	if err != nil {
+		return nil, err
+	}
+	return append(attrs, assoc...), nil
+}
+
+// pst defaults to SR-MPLS so LSPs stored before SRv6 support keep working
+func (l *SRLSP) pst() uint8 {
+	if l.PST == PSTRSVPTE {
+		return PSTSRMPLS
+	}
+	return l.PST
+}
+
+// newERObj encodes SRv6EROList for SRv6 LSPs and EROList otherwise
+func (l *SRLSP) newERObj() ([]byte, error) {
+	if l.pst() == PSTSRv6 {
+		return newSRv6ERObj(l.SRv6EROList)
+	}
+	return newSRERObj(l.EROList)
 }
 
 // InitSRLSP aaaa
 func (s *Session) InitSRLSP(l *SRLSP) error {
 
-	sro, err := s.newSRPObject(l.SRPRemove)
+	sro, err := s.newSRPObject(l.SRPRemove, l.pst())
 	if err != nil {
 		return err
 	}
-	lsp, err := s.newLSPObj(l.Delegate, l.Sync, l.Remove, l.Admin, l.Name, l.PLSPID)
+	bsid, err := l.newBindingSIDTLVs()
+	if err != nil {
+		return err
+	}
+	lsp, err := s.newLSPObj(l.Delegate, l.Sync, l.Remove, l.Admin, l.Name, l.PLSPID, bsid...)
 	if err != nil {
 		return err
 	}
@@ -45,18 +142,18 @@ func (s *Session) InitSRLSP(l *SRLSP) error {
 	if err != nil {
 		return err
 	}
-	ero, err := newSRERObj(l.EROList)
+	ero, err := l.newERObj()
 	if err != nil {
 		return err
 	}
-	lspa, err := newLSPAObject(l.SetupPrio, l.HoldPrio, l.LocalProtect)
+	attrs, err := l.newAttributeList()
 	if err != nil {
 		return err
 	}
 	msg := append(sro, lsp...)
 	msg = append(msg, ep...)
 	msg = append(msg, ero...)
-	msg = append(msg, lspa...)
+	msg = append(msg, attrs...)
 	ch, err := newCommonHeader(12, uint16(len(msg)))
 	if err != nil {
 		return err
@@ -86,7 +183,7 @@ func (s *Session) InitSRLSP(l *SRLSP) error {
 //       LSP.
 // SRP Object-Class is 33.
 // SRP Object-Type is 1.
-func (s *Session) newSRPObject(removeLSP bool) ([]byte, error) {
+func (s *Session) newSRPObject(removeLSP bool, pst uint8) ([]byte, error) {
 	var flags uint32
 	if removeLSP {
 		// setting remove flag at possition 0
@@ -105,7 +202,7 @@ func (s *Session) newSRPObject(removeLSP bool) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	ps, err := newPathSetupObj()
+	ps, err := newPathSetupObj(pst)
 	if err != nil {
 		return nil, err
 	}
@@ -117,11 +214,11 @@ func (s *Session) newSRPObject(removeLSP bool) ([]byte, error) {
 }
 
 // https://tools.ietf.org/html/rfc8408#section-4
-func newPathSetupObj() ([]byte, error) {
+// PST is the last byte of the TLV value the first 3 are reserved
+func newPathSetupObj(pst uint8) ([]byte, error) {
 	var (
 		objType uint16 = 28
 		length  uint16 = 4
-		pst     uint32 = 1
 	)
 	buf := new(bytes.Buffer)
 	err := binary.Write(buf, binary.BigEndian, objType)
@@ -132,7 +229,7 @@ func newPathSetupObj() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	err = binary.Write(buf, binary.BigEndian, pst)
+	err = binary.Write(buf, binary.BigEndian, uint32(pst))
 	if err != nil {
 		return nil, err
 	}
@@ -160,22 +257,16 @@ func newPathName(name string) ([]byte, error) {
 }
 
 // https://tools.ietf.org/html/rfc5440#section-7.11
-func newLSPAObject(setupPrio, holdPrio uint8, localProtect bool) ([]byte, error) {
-	lspa := []byte{
-		0: setupPrio,
-		1: holdPrio,
-		2: func() uint8 {
-			var flags uint8
-			if localProtect {
-				flags |= (1 << 0)
-				return flags
-			}
-			return flags
-		}(),
-		3: 0,
+func newLSPAObject(l *LSPAObject) ([]byte, error) {
+	lspa := make([]byte, 16)
+	binary.BigEndian.PutUint32(lspa[0:4], l.ExcludeAny)
+	binary.BigEndian.PutUint32(lspa[4:8], l.IncludeAny)
+	binary.BigEndian.PutUint32(lspa[8:12], l.IncludeAll)
+	lspa[12] = l.SetupPrio
+	lspa[13] = l.HoldPrio
+	if l.LocalProtect {
+		lspa[14] |= (1 << 0)
 	}
-	//first 12 bytes set to zero
-	lspa = append(make([]byte, 12), lspa...)
 	headerLSPA, err := newCommonObjHeader(9, 1, true, lspa)
 	if err != nil {
 		return nil, err
@@ -200,27 +291,56 @@ func newBandwidthObj(objType uint8, bandwidth uint32) ([]byte, error) {
 	return headerBW, nil
 }
 
+// https://tools.ietf.org/html/rfc5440#section-7.7
+// bandwidth is encoded in IEEE floating point format in bytes per second
+func parseBandwidthObj(data []byte) (float32, error) {
+	if len(data) < 4 {
+		return 0, fmt.Errorf("data len is %d but should be 4", len(data))
+	}
+	return math.Float32frombits(binary.BigEndian.Uint32(data[:4])), nil
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.6
+// Object-Type 1 is IPv4 and Object-Type 2 is IPv6
+func parseEndpointsObj(objType uint8, data []byte) (string, string, error) {
+	switch objType {
+	case 1:
+		if len(data) < 8 {
+			return "", "", fmt.Errorf("data len is %d but should be 8", len(data))
+		}
+		return bytesToIP(data[:4]), bytesToIP(data[4:8]), nil
+	case 2:
+		if len(data) < 32 {
+			return "", "", fmt.Errorf("data len is %d but should be 32", len(data))
+		}
+		return bytesToIP(data[:16]), bytesToIP(data[16:32]), nil
+	default:
+		return "", "", newPCEPErr(4, 2, fmt.Errorf("END-POINTS object type %d not implemented yet", objType))
+	}
+}
+
 //https://tools.ietf.org/html/rfc5440#section-7.6
-//currently IPv4 only
+//Object-Type is picked by the address family, both endpoints must be of the same family
 func newEndpointsObj(srcStr, dstStr string) ([]byte, error) {
-	src, err := ipToUnit32(srcStr)
+	src, err := ipToBytes(srcStr)
 	if err != nil {
 		return nil, err
 	}
-	dst, err := ipToUnit32(dstStr)
+	dst, err := ipToBytes(dstStr)
 	if err != nil {
 		return nil, err
 	}
-	buf := new(bytes.Buffer)
-	err = binary.Write(buf, binary.BigEndian, src)
-	if err != nil {
-		return nil, err
+	if len(src) != len(dst) {
+		return nil, fmt.Errorf("src %s and dst %s are not of the same address family", srcStr, dstStr)
 	}
-	err = binary.Write(buf, binary.BigEndian, dst)
-	if err != nil {
-		return nil, err
+	var objType uint8 = 1
+	if len(src) == net.IPv6len {
+		objType = 2
 	}
-	headerEP, err := newCommonObjHeader(4, 1, true, buf.Bytes())
+	body := make([]byte, 0, len(src)+len(dst))
+	body = append(body, src...)
+	body = append(body, dst...)
+	headerEP, err := newCommonObjHeader(4, objType, true, body)
 	if err != nil {
 		return nil, err
 	}
@@ -232,7 +352,7 @@ func newEndpointsObj(srcStr, dstStr string) ([]byte, error) {
 // so not accepting it as a param
 //    LSP Object-Class is 32.
 //    LSP Object-Type is 1.
-func (s *Session) newLSPObj(delegate, sync, remove, admin bool, name string, PLSPID uint32) ([]byte, error) {
+func (s *Session) newLSPObj(delegate, sync, remove, admin bool, name string, PLSPID uint32, tlvs ...[]byte) ([]byte, error) {
 	// s.IDCounter++
 	// 2 ** 20 - 1 = 1048575 checking for overflow of 20bits
 	// if s.IDCounter > 1048575 {
@@ -264,7 +384,11 @@ func (s *Session) newLSPObj(delegate, sync, remove, admin bool, name string, PLS
 	if err != nil {
 		return nil, err
 	}
-	lspWH, err := newCommonObjHeader(32, 1, true, append(buf.Bytes(), pn...))
+	obj := append(buf.Bytes(), pn...)
+	for _, tlv := range tlvs {
+		obj = append(obj, tlv...)
+	}
+	lspWH, err := newCommonObjHeader(32, 1, true, obj)
 	if err != nil {
 		return nil, err
 	}
@@ -282,6 +406,8 @@ type SREROSub struct {
 	SID           uint32
 	IPv4NodeID    string
 	IPv4Adjacency []string
+	IPv6NodeID    string
+	IPv6Adjacency []string
 	UnnuV4Adj     UnnuAdjIPv4NodeIDs
 }
 
@@ -321,12 +447,57 @@ func (s *SREROSub) validateSREROSub() error {
 	return nil
 }
 
-//UnnuAdjIPv4NodeIDs aaa
+//UnnuAdjIPv4NodeIDs is an unnumbered adjacency identified by IPv4 node IDs
+//and interface IDs https://tools.ietf.org/html/rfc8664#section-4.3.2
 type UnnuAdjIPv4NodeIDs struct {
 	LocalNodeID       string
-	LocalInterfaceID  string
+	LocalInterfaceID  uint32
 	RemoteNodeID      string
-	RemoteInterfaceID string
+	RemoteInterfaceID uint32
+}
+
+//UnmarshalJSON accepts interface IDs stored as strings by older
+//versions where an empty string means no interface ID
+func (u *UnnuAdjIPv4NodeIDs) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		LocalNodeID       string
+		LocalInterfaceID  json.RawMessage
+		RemoteNodeID      string
+		RemoteInterfaceID json.RawMessage
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	u.LocalNodeID = raw.LocalNodeID
+	u.RemoteNodeID = raw.RemoteNodeID
+	u.LocalInterfaceID, err = parseInterfaceID(raw.LocalInterfaceID)
+	if err != nil {
+		return fmt.Errorf("LocalInterfaceID: %w", err)
+	}
+	u.RemoteInterfaceID, err = parseInterfaceID(raw.RemoteInterfaceID)
+	if err != nil {
+		return fmt.Errorf("RemoteInterfaceID: %w", err)
+	}
+	return nil
+}
+
+func parseInterfaceID(data json.RawMessage) (uint32, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return 0, nil
+	}
+	var id uint32
+	if data[0] != '"' {
+		err := json.Unmarshal(data, &id)
+		return id, err
+	}
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil || str == "" {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(str, 10, 32)
+	return uint32(v), err
 }
 
 // https://tools.ietf.org/html/draft-ietf-pce-segment-routing-14#section-5.3.1
@@ -384,7 +555,13 @@ func newSREROSubObject(ero SREROSub) ([]byte, error) {
 		byteERO[1] = uint8(len(byteERO))
 		return byteERO, nil
 	case 2:
-		return nil, errors.New("IPv6 Node ID not implemented yet")
+		nodeID, err := ipv6ToBytes(ero.IPv6NodeID)
+		if err != nil {
+			return nil, err
+		}
+		byteERO = append(byteERO, nodeID...)
+		byteERO[1] = uint8(len(byteERO))
+		return byteERO, nil
 	case 3:
 		if len(ero.IPv4Adjacency) != 2 {
 			return nil, errors.New("malformed IPv4 Adjacency specified")
@@ -410,9 +587,40 @@ func newSREROSubObject(ero SREROSub) ([]byte, error) {
 		byteERO[1] = uint8(len(byteERO))
 		return byteERO, nil
 	case 4:
-		return nil, errors.New("IPv6 Adjacency not implemented yet")
+		if len(ero.IPv6Adjacency) != 2 {
+			return nil, errors.New("malformed IPv6 Adjacency specified")
+		}
+		local, err := ipv6ToBytes(ero.IPv6Adjacency[0])
+		if err != nil {
+			return nil, err
+		}
+		remote, err := ipv6ToBytes(ero.IPv6Adjacency[1])
+		if err != nil {
+			return nil, err
+		}
+		byteERO = append(byteERO, local...)
+		byteERO = append(byteERO, remote...)
+		byteERO[1] = uint8(len(byteERO))
+		return byteERO, nil
 	case 5:
-		return nil, errors.New("unnumbered adjacency with IPv4 NodeIDs not implemented yet")
+		local, err := ipToUnit32(ero.UnnuV4Adj.LocalNodeID)
+		if err != nil {
+			return nil, err
+		}
+		remote, err := ipToUnit32(ero.UnnuV4Adj.RemoteNodeID)
+		if err != nil {
+			return nil, err
+		}
+		buf := new(bytes.Buffer)
+		for _, v := range []uint32{local, ero.UnnuV4Adj.LocalInterfaceID, remote, ero.UnnuV4Adj.RemoteInterfaceID} {
+			err = binary.Write(buf, binary.BigEndian, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		byteERO = append(byteERO, buf.Bytes()...)
+		byteERO[1] = uint8(len(byteERO))
+		return byteERO, nil
 	default:
 		return nil, errors.New("NAI Type not defined in RFC")
 	}
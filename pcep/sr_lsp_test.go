@@ -0,0 +1,223 @@
+package pcep
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEndpointsObjIPv6(t *testing.T) {
+	ep, err := newEndpointsObj("2001:db8::1", "2001:db8::2")
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	coh, err := parseCommonObjectHeader(ep)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if coh.ObjectClass != 4 || coh.ObjectType != 2 || coh.ObjectLength != 36 {
+		t.Fatalf("wrong END-POINTS header %+v", coh)
+	}
+	src, dst, err := parseEndpointsObj(coh.ObjectType, ep[4:])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if src != "2001:db8::1" || dst != "2001:db8::2" {
+		t.Errorf("wrong endpoints decoded src: %s dst: %s", src, dst)
+	}
+	_, err = newEndpointsObj("10.0.0.1", "2001:db8::2")
+	if err == nil {
+		t.Errorf("mixed address families must not be accepted")
+	}
+}
+
+func TestSREROIPv6NAI(t *testing.T) {
+	subs := []SREROSub{
+		{
+			NT:            4,
+			MBit:          true,
+			SID:           16001,
+			IPv6Adjacency: []string{"2001:db8:12::1", "2001:db8:12::2"},
+		},
+		{
+			NT:         2,
+			MBit:       true,
+			SID:        16002,
+			IPv6NodeID: "2001:db8::2",
+		},
+	}
+	ero, err := newSRERObj(subs)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	eros, err := parseERO(ero[4:])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if len(eros) != 2 {
+		t.Fatalf("expected 2 subobjects got %d", len(eros))
+	}
+	for i := range subs {
+		if !reflect.DeepEqual(*eros[i], subs[i]) {
+			t.Errorf("expected %+v got %+v", subs[i], *eros[i])
+		}
+	}
+}
+
+func TestSREROUnnumberedNAI(t *testing.T) {
+	sub := SREROSub{
+		NT:   5,
+		MBit: true,
+		SID:  24001,
+		UnnuV4Adj: UnnuAdjIPv4NodeIDs{
+			LocalNodeID:       "10.0.0.1",
+			LocalInterfaceID:  7,
+			RemoteNodeID:      "10.0.0.2",
+			RemoteInterfaceID: 9,
+		},
+	}
+	ero, err := newSREROSubObject(sub)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if len(ero) != 24 {
+		t.Fatalf("expected 24 byte subobject got %d", len(ero))
+	}
+	eros, err := parseERO(ero)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if len(eros) != 1 || !reflect.DeepEqual(*eros[0], sub) {
+		t.Errorf("expected %+v got %+v", sub, eros)
+	}
+}
+
+func TestSRLSPAttributeList(t *testing.T) {
+	l := &SRLSP{
+		SetupPrio:  3,
+		HoldPrio:   2,
+		ExcludeAny: 0x1,
+		IncludeAny: 0x6,
+		IncludeAll: 0x8,
+		BW:         125000,
+		Metrics: []*LSPMetric{
+			{Type: MetricTypeTE, Metric: 10},
+			{BFlag: true, Type: MetricTypePathDelay, Metric: 5000},
+		},
+	}
+	attrs, err := l.newAttributeList()
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	classes := make([]uint8, 0)
+	metrics := make([]*LSPMetric, 0)
+	for offset := 0; offset < len(attrs); {
+		coh, err := parseCommonObjectHeader(attrs[offset : offset+4])
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		body := attrs[offset+4 : offset+int(coh.ObjectLength)]
+		classes = append(classes, coh.ObjectClass)
+		switch coh.ObjectClass {
+		case 9:
+			lspa, err := parseLSPAObject(body)
+			if err != nil {
+				t.Fatalf("must not see any errors, instead got: %s", err.Error())
+			}
+			expected := &LSPAObject{ExcludeAny: 0x1, IncludeAny: 0x6, IncludeAll: 0x8, SetupPrio: 3, HoldPrio: 2}
+			if !reflect.DeepEqual(lspa, expected) {
+				t.Errorf("expected %+v got %+v", expected, lspa)
+			}
+		case 5:
+			bw, err := parseBandwidthObj(body)
+			if err != nil {
+				t.Fatalf("must not see any errors, instead got: %s", err.Error())
+			}
+			if bw != 125000 {
+				t.Errorf("expected bandwidth 125000 got %f", bw)
+			}
+		case 6:
+			m, err := parseMetric(body)
+			if err != nil {
+				t.Fatalf("must not see any errors, instead got: %s", err.Error())
+			}
+			metrics = append(metrics, m)
+		}
+		offset += int(coh.ObjectLength)
+	}
+	if !reflect.DeepEqual(classes, []uint8{9, 5, 6, 6}) {
+		t.Errorf("expected LSPA, BANDWIDTH and two METRICs got classes %v", classes)
+	}
+	if len(metrics) == 2 && (!reflect.DeepEqual(metrics[0], l.Metrics[0]) || !reflect.DeepEqual(metrics[1], l.Metrics[1])) {
+		t.Errorf("expected metrics %+v %+v got %+v %+v", l.Metrics[0], l.Metrics[1], metrics[0], metrics[1])
+	}
+}
+
+func TestUnnuAdjIPv4NodeIDsUnmarshalJSON(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		data     string
+		expected UnnuAdjIPv4NodeIDs
+		err      bool
+	}{
+		{
+			name: "empty strings stored by older versions",
+			data: `{"LocalNodeID":"","LocalInterfaceID":"","RemoteNodeID":"","RemoteInterfaceID":""}`,
+		},
+		{
+			name:     "interface IDs as strings",
+			data:     `{"LocalNodeID":"10.0.0.1","LocalInterfaceID":"7","RemoteNodeID":"10.0.0.2","RemoteInterfaceID":"9"}`,
+			expected: UnnuAdjIPv4NodeIDs{LocalNodeID: "10.0.0.1", LocalInterfaceID: 7, RemoteNodeID: "10.0.0.2", RemoteInterfaceID: 9},
+		},
+		{
+			name:     "interface IDs as numbers",
+			data:     `{"LocalNodeID":"10.0.0.1","LocalInterfaceID":7,"RemoteNodeID":"10.0.0.2","RemoteInterfaceID":9}`,
+			expected: UnnuAdjIPv4NodeIDs{LocalNodeID: "10.0.0.1", LocalInterfaceID: 7, RemoteNodeID: "10.0.0.2", RemoteInterfaceID: 9},
+		},
+		{
+			name: "interface IDs missing",
+			data: `{}`,
+		},
+		{
+			name: "invalid interface ID",
+			data: `{"LocalInterfaceID":"eth0"}`,
+			err:  true,
+		},
+		{
+			name: "interface ID out of range",
+			data: `{"RemoteInterfaceID":4294967296}`,
+			err:  true,
+		},
+	} {
+		var u UnnuAdjIPv4NodeIDs
+		err := json.Unmarshal([]byte(c.data), &u)
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: expected error got %+v", c.name, u)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: must not see any errors, instead got: %s", c.name, err.Error())
+			continue
+		}
+		if u != c.expected {
+			t.Errorf("%s: expected %+v got %+v", c.name, c.expected, u)
+		}
+	}
+
+	// what is written now is read back unchanged
+	sub := SREROSub{NT: 5, UnnuV4Adj: UnnuAdjIPv4NodeIDs{LocalNodeID: "10.0.0.1", LocalInterfaceID: 7, RemoteNodeID: "10.0.0.2", RemoteInterfaceID: 9}}
+	data, err := json.Marshal(sub)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	var decoded SREROSub
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if !reflect.DeepEqual(decoded, sub) {
+		t.Errorf("expected %+v got %+v", sub, decoded)
+	}
+}
@@ -0,0 +1,168 @@
+package pcep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// https://tools.ietf.org/html/draft-ietf-pce-segment-routing-policy-cp#section-5.2
+// TLVs carried in the SR Policy association
+const (
+	srPolicyPolNameTLV    uint16 = 56
+	srPolicyCPathIDTLV    uint16 = 57
+	srPolicyCPathNameTLV  uint16 = 58
+	srPolicyCPathPrefTLV  uint16 = 59
+	srPolicyCPathIDTLVLen        = 28
+	srPolicyExtIDIPv4Len         = 8
+	srPolicyExtIDIPv6Len         = 20
+)
+
+// https://tools.ietf.org/html/draft-ietf-pce-segment-routing-policy-cp#section-5.2.2
+// Protocol Origin of the candidate path
+const (
+	SRPolicyOriginPCEP   uint8 = 10
+	SRPolicyOriginBGP    uint8 = 20
+	SRPolicyOriginConfig uint8 = 30
+)
+
+//SRPolicyCPathID SRPOLICY-CPATH-ID TLV https://tools.ietf.org/html/draft-ietf-pce-segment-routing-policy-cp#section-5.2.2
+type SRPolicyCPathID struct {
+	ProtoOrigin    uint8
+	OriginatorASN  uint32
+	OriginatorAddr string
+	Discriminator  uint32
+}
+
+//SRPolicyAssoc holds the SR Policy identifier and the candidate path
+// attributes of the SR Policy association https://tools.ietf.org/html/draft-ietf-pce-segment-routing-policy-cp#section-5
+type SRPolicyAssoc struct {
+	// color and endpoint are carried in EXTENDED-ASSOCIATION-ID TLV
+	Color      uint32
+	Endpoint   string
+	PolicyName string
+	CPathID    *SRPolicyCPathID
+	CPathName  string
+	Preference uint32
+}
+
+// extendedID encodes color and endpoint as EXTENDED-ASSOCIATION-ID
+// https://tools.ietf.org/html/draft-ietf-pce-segment-routing-policy-cp#section-5.1
+func (p *SRPolicyAssoc) extendedID() ([]byte, error) {
+	ep, err := ipToBytes(p.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 4, 4+len(ep))
+	binary.BigEndian.PutUint32(id, p.Color)
+	return append(id, ep...), nil
+}
+
+// parseSRPolicyExtendedID decodes color and endpoint
+func parseSRPolicyExtendedID(id []byte) (uint32, string, error) {
+	if len(id) != srPolicyExtIDIPv4Len && len(id) != srPolicyExtIDIPv6Len {
+		return 0, "", fmt.Errorf("SR Policy extended association ID len is %d but should be 8 or 20", len(id))
+	}
+	return binary.BigEndian.Uint32(id[:4]), bytesToIP(id[4:]), nil
+}
+
+// parseTLV decodes an SR Policy association TLV value, unknown TLVs are ignored
+func (p *SRPolicyAssoc) parseTLV(tlvType uint16, value []byte) error {
+	switch tlvType {
+	case srPolicyPolNameTLV:
+		p.PolicyName = string(bytes.TrimRight(value, "\x00"))
+	case srPolicyCPathIDTLV:
+		if len(value) != srPolicyCPathIDTLVLen {
+			return fmt.Errorf("SRPOLICY-CPATH-ID TLV len is %d but should be %d", len(value), srPolicyCPathIDTLVLen)
+		}
+		// originator address is always 128 bits with IPv4 mapped to IPv6
+		addr := net.IP(value[8:24])
+		p.CPathID = &SRPolicyCPathID{
+			ProtoOrigin:    value[0],
+			OriginatorASN:  binary.BigEndian.Uint32(value[4:8]),
+			OriginatorAddr: addr.String(),
+			Discriminator:  binary.BigEndian.Uint32(value[24:28]),
+		}
+	case srPolicyCPathNameTLV:
+		p.CPathName = string(bytes.TrimRight(value, "\x00"))
+	case srPolicyCPathPrefTLV:
+		if len(value) != 4 {
+			return fmt.Errorf("SRPOLICY-CPATH-PREFERENCE TLV len is %d but should be 4", len(value))
+		}
+		p.Preference = binary.BigEndian.Uint32(value)
+	}
+	return nil
+}
+
+// newSRPolicyTLVs encodes the SR Policy association TLVs
+// https://tools.ietf.org/html/draft-ietf-pce-segment-routing-policy-cp#section-5.2
+func newSRPolicyTLVs(p *SRPolicyAssoc) ([]byte, error) {
+	tlvs := make([]byte, 0)
+	if p.PolicyName != "" {
+		tlvs = append(tlvs, newStringTLV(srPolicyPolNameTLV, p.PolicyName)...)
+	}
+	if p.CPathID != nil {
+		addr := net.IPv6zero
+		if p.CPathID.OriginatorAddr != "" {
+			addr = net.ParseIP(p.CPathID.OriginatorAddr)
+			if addr == nil {
+				return nil, fmt.Errorf("invalid candidate path originator address: %s", p.CPathID.OriginatorAddr)
+			}
+		}
+		buf := new(bytes.Buffer)
+		for _, v := range []interface{}{
+			srPolicyCPathIDTLV,
+			uint16(srPolicyCPathIDTLVLen),
+			[]uint8{p.CPathID.ProtoOrigin, 0, 0, 0},
+			p.CPathID.OriginatorASN,
+			[]byte(addr.To16()),
+			p.CPathID.Discriminator,
+		} {
+			err := binary.Write(buf, binary.BigEndian, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		tlvs = append(tlvs, buf.Bytes()...)
+	}
+	if p.CPathName != "" {
+		tlvs = append(tlvs, newStringTLV(srPolicyCPathNameTLV, p.CPathName)...)
+	}
+	pref := make([]byte, 8)
+	binary.BigEndian.PutUint16(pref[0:2], srPolicyCPathPrefTLV)
+	binary.BigEndian.PutUint16(pref[2:4], 4)
+	binary.BigEndian.PutUint32(pref[4:8], p.Preference)
+	return append(tlvs, pref...), nil
+}
+
+// newStringTLV encodes a TLV with a string value padded to 4-byte alignment
+func newStringTLV(tlvType uint16, value string) []byte {
+	tlv := make([]byte, 4, 4+len(value)+3)
+	binary.BigEndian.PutUint16(tlv[0:2], tlvType)
+	binary.BigEndian.PutUint16(tlv[2:4], uint16(len(value)))
+	tlv = append(tlv, value...)
+	return append(tlv, make([]byte, (4-len(value)%4)%4)...)
+}
+
+// srPolicyAssoc fills in the SR Policy attributes of a parsed association
+func (a *Association) srPolicyAssoc(tlvs map[uint16][]byte) error {
+	if a.ExtendedID == nil {
+		return errors.New("EXTENDED-ASSOCIATION-ID TLV missing in SR Policy association")
+	}
+	p := &SRPolicyAssoc{}
+	var err error
+	p.Color, p.Endpoint, err = parseSRPolicyExtendedID(a.ExtendedID)
+	if err != nil {
+		return err
+	}
+	for tlvType, value := range tlvs {
+		err = p.parseTLV(tlvType, value)
+		if err != nil {
+			return err
+		}
+	}
+	a.SRPolicy = p
+	return nil
+}
@@ -0,0 +1,409 @@
+package pcep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Path setup types https://www.iana.org/assignments/pcep/pcep.xhtml#path-setup-types
+const (
+	PSTRSVPTE uint8 = 0
+	PSTSRMPLS uint8 = 1
+	PSTSRv6   uint8 = 3
+)
+
+// SRv6 endpoint behaviors https://tools.ietf.org/html/rfc8986#section-10.2
+const (
+	SRv6BehaviorEnd  uint16 = 1
+	SRv6BehaviorEndX uint16 = 5
+)
+
+//SRv6MSD is a MSD-Type and MSD-Value pair https://www.rfc-editor.org/rfc/rfc9603.html#section-4.1.1
+type SRv6MSD struct {
+	Type  uint8
+	Value uint8
+}
+
+//SRv6PCECap https://www.rfc-editor.org/rfc/rfc9603.html#section-4.1.1
+type SRv6PCECap struct {
+	NAIToSID bool
+	MSDs     []SRv6MSD
+}
+
+//PSTCap is PATH-SETUP-TYPE-CAPABILITY TLV https://tools.ietf.org/html/rfc8408#section-4
+type PSTCap struct {
+	PSTs []uint8
+	SR   *SRPCECap
+	SRv6 *SRv6PCECap
+}
+
+// https://tools.ietf.org/html/rfc8408#section-4
+// PATH-SETUP-TYPE-CAPABILITY TLV type is 34, the list of PSTs is padded
+// to 4 bytes and followed by sub-TLVs for each PST which needs them
+func parsePSTCap(data []byte) (*PSTCap, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("data len is %d but should be at least 8", len(data))
+	}
+	length := int(binary.BigEndian.Uint16(data[2:4]))
+	if 4+length > len(data) {
+		return nil, fmt.Errorf("PST capability TLV len is %d but only %d bytes left", length, len(data)-4)
+	}
+	value := data[4 : 4+length]
+	num := int(value[3])
+	// PST list starts after 3 reserved bytes and number of PSTs
+	listLen := (num + 3) &^ 3
+	if 4+listLen > len(value) {
+		return nil, fmt.Errorf("PST capability TLV len is %d but has %d PSTs", length, num)
+	}
+	pstCap := &PSTCap{
+		PSTs: append([]uint8{}, value[4:4+num]...),
+	}
+	offset := 4 + listLen
+	for len(value)-offset >= 4 {
+		subType := binary.BigEndian.Uint16(value[offset : offset+2])
+		subLen := int(binary.BigEndian.Uint16(value[offset+2 : offset+4]))
+		if offset+4+subLen > len(value) {
+			return nil, fmt.Errorf("malformed sub-TLV type %d in PST capability TLV", subType)
+		}
+		sub := value[offset : offset+4+subLen]
+		var err error
+		switch subType {
+		case 26:
+			pstCap.SR, err = parseSRCap(sub)
+		case 27:
+			pstCap.SRv6, err = parseSRv6Cap(sub)
+		default:
+			logrus.WithFields(logrus.Fields{
+				"type":   subType,
+				"length": subLen,
+			}).Info("unknown PST capability sub-TLV")
+		}
+		if err != nil {
+			return nil, err
+		}
+		offset = offset + 4 + ((subLen + 3) &^ 3)
+	}
+	return pstCap, nil
+}
+
+// https://www.rfc-editor.org/rfc/rfc9603.html#section-4.1.1
+// SRv6-PCE-CAPABILITY sub-TLV type is 27
+func parseSRv6Cap(data []byte) (*SRv6PCECap, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("data len is %d but should be at least 8", len(data))
+	}
+	length := int(binary.BigEndian.Uint16(data[2:4]))
+	if 4+length > len(data) {
+		return nil, fmt.Errorf("SRv6 capability sub-TLV len is %d but only %d bytes left", length, len(data)-4)
+	}
+	// N is bit 14 of the 16 bit flags field
+	NAIToSID, err := uintToBool(readBits(data[7], 1))
+	if err != nil {
+		return nil, err
+	}
+	srv6Cap := &SRv6PCECap{
+		NAIToSID: NAIToSID,
+		MSDs:     make([]SRv6MSD, 0),
+	}
+	for i := 8; i+1 < 4+length; i += 2 {
+		// padding
+		if data[i] == 0 {
+			continue
+		}
+		srv6Cap.MSDs = append(srv6Cap.MSDs, SRv6MSD{Type: data[i], Value: data[i+1]})
+	}
+	return srv6Cap, nil
+}
+
+// https://tools.ietf.org/html/rfc8408#section-4
+// PCE does not send any MSD as MSD is a PCC limitation
+func newPSTCap(caps *Capabilities) []byte {
+	psts := make([]byte, 0)
+	subTLVs := make([]byte, 0)
+	if caps.SR {
+		psts = append(psts, PSTSRMPLS)
+		subTLVs = append(subTLVs, newSRCap(caps.MSD)...)
+	}
+	if caps.SRv6 {
+		psts = append(psts, PSTSRv6)
+		// SRv6-PCE-CAPABILITY with no flags and no MSDs
+		subTLVs = append(subTLVs, 0, 27, 0, 4, 0, 0, 0, 0)
+	}
+	value := []byte{0, 0, 0, uint8(len(psts))}
+	value = append(value, psts...)
+	for len(value)%4 != 0 {
+		value = append(value, 0)
+	}
+	value = append(value, subTLVs...)
+	tlv := make([]byte, 4, 4+len(value))
+	binary.BigEndian.PutUint16(tlv[:2], 34)
+	binary.BigEndian.PutUint16(tlv[2:4], uint16(len(value)))
+	return append(tlv, value...)
+}
+
+//SRv6SIDStructure https://www.rfc-editor.org/rfc/rfc9603.html#section-4.3.1.1
+type SRv6SIDStructure struct {
+	LBLength  uint8
+	LNLength  uint8
+	FunLength uint8
+	ArgLength uint8
+}
+
+//LinkLocalV6Adj is a link-local IPv6 adjacency identified by global node
+//addresses and interface IDs https://tools.ietf.org/html/rfc8664#section-4.3.2
+type LinkLocalV6Adj struct {
+	LocalNodeID       string
+	LocalInterfaceID  uint32
+	RemoteNodeID      string
+	RemoteInterfaceID uint32
+}
+
+//SRv6EROSub is SRv6-ERO subobject, SRv6-RRO has the same format without L bit
+//https://www.rfc-editor.org/rfc/rfc9603.html#section-4.3.1
+type SRv6EROSub struct {
+	LooseHop       bool
+	NT             uint8
+	VBit           bool
+	NoSID          bool
+	NoNAI          bool
+	Behavior       uint16
+	SID            string
+	IPv6NodeID     string
+	IPv6Adjacency  []string
+	LinkLocalV6Adj LinkLocalV6Adj
+	SIDStructure   *SRv6SIDStructure
+}
+
+func (s *SRv6EROSub) validateSRv6EROSub() error {
+	if s.NoSID && s.NoNAI {
+		return errors.New("S and F bits MUST NOT both be set to 1")
+	}
+	if s.NoNAI && s.NT > 0 {
+		return errors.New("NoNAI flag is set but NT is not zero ")
+	}
+	if !s.NoNAI && s.NT == 0 {
+		return errors.New("NoNAI flag is not set but NT is zero ")
+	}
+	if s.NoSID && s.SIDStructure != nil {
+		return errors.New("SID structure is set but SID is absent")
+	}
+	return nil
+}
+
+// https://www.rfc-editor.org/rfc/rfc9603.html#section-4.3.1
+func newSRv6EROSubObject(ero SRv6EROSub) ([]byte, error) {
+	err := ero.validateSRv6EROSub()
+	if err != nil {
+		return nil, err
+	}
+	var objType uint8 = 40
+	if ero.LooseHop {
+		objType |= (1 << 7)
+	}
+	var flags uint8
+	if ero.NoSID {
+		flags |= (1 << 0)
+	}
+	if ero.NoNAI {
+		flags |= (1 << 1)
+	}
+	if ero.SIDStructure != nil {
+		flags |= (1 << 2)
+	}
+	if ero.VBit {
+		flags |= (1 << 3)
+	}
+	sub := []byte{
+		0: objType,
+		1: 0,
+		2: ero.NT << 4,
+		3: flags,
+		// reserved
+		4: 0,
+		5: 0,
+		6: uint8(ero.Behavior >> 8),
+		7: uint8(ero.Behavior),
+	}
+	if !ero.NoSID {
+		sid, err := ipv6ToBytes(ero.SID)
+		if err != nil {
+			return nil, err
+		}
+		sub = append(sub, sid...)
+	}
+	switch ero.NT {
+	case 0:
+	case 2:
+		nodeID, err := ipv6ToBytes(ero.IPv6NodeID)
+		if err != nil {
+			return nil, err
+		}
+		sub = append(sub, nodeID...)
+	case 4:
+		if len(ero.IPv6Adjacency) != 2 {
+			return nil, errors.New("malformed IPv6 Adjacency specified")
+		}
+		for _, addr := range ero.IPv6Adjacency {
+			b, err := ipv6ToBytes(addr)
+			if err != nil {
+				return nil, err
+			}
+			sub = append(sub, b...)
+		}
+	case 6:
+		local, err := ipv6ToBytes(ero.LinkLocalV6Adj.LocalNodeID)
+		if err != nil {
+			return nil, err
+		}
+		remote, err := ipv6ToBytes(ero.LinkLocalV6Adj.RemoteNodeID)
+		if err != nil {
+			return nil, err
+		}
+		buf := new(bytes.Buffer)
+		for _, v := range []interface{}{local, ero.LinkLocalV6Adj.LocalInterfaceID, remote, ero.LinkLocalV6Adj.RemoteInterfaceID} {
+			err = binary.Write(buf, binary.BigEndian, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		sub = append(sub, buf.Bytes()...)
+	default:
+		return nil, fmt.Errorf("NAI Type %d is not defined for SRv6", ero.NT)
+	}
+	if ero.SIDStructure != nil {
+		sub = append(sub,
+			ero.SIDStructure.LBLength,
+			ero.SIDStructure.LNLength,
+			ero.SIDStructure.FunLength,
+			ero.SIDStructure.ArgLength,
+			// reserved and flags
+			0, 0, 0, 0,
+		)
+	}
+	sub[1] = uint8(len(sub))
+	return sub, nil
+}
+
+// https://tools.ietf.org/html/rfc5440#section-7.9
+func newSRv6ERObj(subEROs []SRv6EROSub) ([]byte, error) {
+	ero := make([]byte, 0)
+	for _, subERO := range subEROs {
+		subEROBytes, err := newSRv6EROSubObject(subERO)
+		if err != nil {
+			return nil, err
+		}
+		ero = append(ero, subEROBytes...)
+	}
+	return newCommonObjHeader(7, 1, true, ero)
+}
+
+// isSRv6Path checks the type of the first ERO or RRO subobject
+func isSRv6Path(data []byte) bool {
+	return len(data) > 0 && data[0]&0x7f == 40
+}
+
+// https://www.rfc-editor.org/rfc/rfc9603.html#section-4.3.1
+func parseSRv6ERO(data []byte) ([]*SRv6EROSub, error) {
+	return parseSRv6Subs(data, "SRv6-ERO", 5, 6)
+}
+
+// https://www.rfc-editor.org/rfc/rfc9603.html#section-4.4
+func parseSRv6RRO(data []byte) ([]*SRv6EROSub, error) {
+	return parseSRv6Subs(data, "SRv6-RRO", 36, 35)
+}
+
+// parseSRv6Subs walks SRv6-ERO or SRv6-RRO subobjects, error values
+// for mixed subobject types and absent SID and NAI differ between the two
+func parseSRv6Subs(data []byte, name string, mixedErr, absentErr uint8) ([]*SRv6EROSub, error) {
+	subs := make([]*SRv6EROSub, 0)
+	var offset int
+	for (len(data) - offset) > 4 {
+		length := int(data[offset+1])
+		if length < 8 || offset+length > len(data) {
+			return nil, newPCEPErr(10, 11, fmt.Errorf("malformed %s subobject with length %d", name, length))
+		}
+		if data[offset]&0x7f != 40 {
+			return nil, newPCEPErr(10, mixedErr, fmt.Errorf("wrong %s type %d", name, data[offset]))
+		}
+		e := &SRv6EROSub{
+			LooseHop: data[offset]>>7 == 1,
+			NT:       data[offset+2] >> 4,
+			NoSID:    readBits(data[offset+3], 0) == 1,
+			NoNAI:    readBits(data[offset+3], 1) == 1,
+			VBit:     readBits(data[offset+3], 3) == 1,
+			Behavior: binary.BigEndian.Uint16(data[offset+6 : offset+8]),
+		}
+		hasStructure := readBits(data[offset+3], 2) == 1
+		if e.NoSID && e.NoNAI {
+			return nil, newPCEPErr(10, absentErr, fmt.Errorf("both SID and NAI are absent in %s subobject", name))
+		}
+		body := data[offset+8 : offset+length]
+		if !e.NoSID {
+			if len(body) < 16 {
+				return nil, newPCEPErr(10, 11, fmt.Errorf("%s subobject is too short to carry SID", name))
+			}
+			e.SID = bytesToIP(body[:16])
+			body = body[16:]
+		}
+		if !e.NoNAI {
+			n, err := parseSRv6NAI(body, e)
+			if err != nil {
+				return nil, err
+			}
+			body = body[n:]
+		}
+		if hasStructure {
+			if e.NoSID || len(body) < 8 {
+				return nil, newPCEPErr(10, 37, fmt.Errorf("invalid SID structure in %s subobject", name))
+			}
+			e.SIDStructure = &SRv6SIDStructure{
+				LBLength:  body[0],
+				LNLength:  body[1],
+				FunLength: body[2],
+				ArgLength: body[3],
+			}
+		}
+		subs = append(subs, e)
+		offset = offset + length
+	}
+	return subs, nil
+}
+
+// parseSRv6NAI returns the number of bytes used by the NAI
+func parseSRv6NAI(data []byte, ero *SRv6EROSub) (int, error) {
+	var n int
+	switch ero.NT {
+	case 2:
+		n = 16
+	case 4:
+		n = 32
+	case 6:
+		n = 40
+	default:
+		return 0, newPCEPErr(10, 13, fmt.Errorf("NAI type %d is not supported in SRv6 subobject", ero.NT))
+	}
+	if len(data) < n {
+		return 0, newPCEPErr(10, 11, fmt.Errorf("NAI len is %d but should be %d", len(data), n))
+	}
+	switch ero.NT {
+	case 2:
+		ero.IPv6NodeID = bytesToIP(data[:16])
+	case 4:
+		ero.IPv6Adjacency = []string{
+			0: bytesToIP(data[:16]),
+			1: bytesToIP(data[16:32]),
+		}
+	case 6:
+		ero.LinkLocalV6Adj = LinkLocalV6Adj{
+			LocalNodeID:       bytesToIP(data[:16]),
+			LocalInterfaceID:  binary.BigEndian.Uint32(data[16:20]),
+			RemoteNodeID:      bytesToIP(data[20:36]),
+			RemoteInterfaceID: binary.BigEndian.Uint32(data[36:40]),
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,110 @@
+package pcep
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSRv6ERO(t *testing.T) {
+	subs := []SRv6EROSub{
+		{
+			NT:            4,
+			Behavior:      SRv6BehaviorEndX,
+			SID:           "fc00:0:1:e001::",
+			IPv6Adjacency: []string{"2001:db8:12::1", "2001:db8:12::2"},
+			SIDStructure: &SRv6SIDStructure{
+				LBLength:  32,
+				LNLength:  16,
+				FunLength: 16,
+			},
+		},
+		{
+			NT: 6,
+			LinkLocalV6Adj: LinkLocalV6Adj{
+				LocalNodeID:       "2001:db8::2",
+				LocalInterfaceID:  3,
+				RemoteNodeID:      "2001:db8::3",
+				RemoteInterfaceID: 4,
+			},
+			NoSID: true,
+		},
+		{
+			Behavior: SRv6BehaviorEnd,
+			SID:      "fc00:0:4::",
+			NoNAI:    true,
+		},
+	}
+	ero, err := newSRv6ERObj(subs)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if !isSRv6Path(ero[4:]) {
+		t.Fatalf("SRv6-ERO must be detected by the first subobject type")
+	}
+	eros, err := parseSRv6ERO(ero[4:])
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if len(eros) != len(subs) {
+		t.Fatalf("expected %d subobjects got %d", len(subs), len(eros))
+	}
+	for i := range subs {
+		if !reflect.DeepEqual(*eros[i], subs[i]) {
+			t.Errorf("expected %+v got %+v", subs[i], *eros[i])
+		}
+	}
+}
+
+func TestSRv6RRONoSIDNoNAI(t *testing.T) {
+	// S and F flags are both set
+	data := []byte{40, 8, 0, 3, 0, 0, 0, 1}
+	_, err := parseSRv6RRO(data)
+	var pErr *pcepErr
+	if !errors.As(err, &pErr) || pErr.ErrType != 10 || pErr.ErrValue != 35 {
+		t.Errorf("expected error type 10 value 35 got %v", err)
+	}
+}
+
+func TestPSTCap(t *testing.T) {
+	tlv := newPSTCap(&Capabilities{SR: true, MSD: 5, SRv6: true})
+	if len(tlv)%4 != 0 {
+		t.Fatalf("PST capability TLV must be padded got len %d", len(tlv))
+	}
+	pstCap, err := parsePSTCap(tlv)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	if !reflect.DeepEqual(pstCap.PSTs, []uint8{PSTSRMPLS, PSTSRv6}) {
+		t.Errorf("wrong PSTs %v", pstCap.PSTs)
+	}
+	if pstCap.SR == nil || pstCap.SR.MSD != 5 {
+		t.Errorf("SR-PCE-CAPABILITY sub-TLV must be decoded with MSD 5 got %+v", pstCap.SR)
+	}
+	if pstCap.SRv6 == nil {
+		t.Errorf("SRv6-PCE-CAPABILITY sub-TLV must be decoded")
+	}
+}
+
+func TestSRv6CapNFlag(t *testing.T) {
+	for _, c := range []struct {
+		flags    byte
+		naiToSID bool
+	}{
+		{flags: 0x00, naiToSID: false},
+		{flags: 0x02, naiToSID: true},
+		// bit 15 is unassigned
+		{flags: 0x01, naiToSID: false},
+	} {
+		srv6Cap, err := parseSRv6Cap([]byte{0, 27, 0, 6, 0, 0, 0, c.flags, 41, 10, 0, 0})
+		if err != nil {
+			t.Fatalf("must not see any errors, instead got: %s", err.Error())
+		}
+		if srv6Cap.NAIToSID != c.naiToSID {
+			t.Errorf("flags %#x expected N %t got %t", c.flags, c.naiToSID, srv6Cap.NAIToSID)
+		}
+		if !reflect.DeepEqual(srv6Cap.MSDs, []SRv6MSD{{Type: 41, Value: 10}}) {
+			t.Errorf("expected MSD 41/10 got %+v", srv6Cap.MSDs)
+		}
+	}
+}
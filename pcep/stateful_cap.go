@@ -1,6 +1,7 @@
 package pcep
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 )
@@ -83,3 +84,35 @@ func parseStatefulPCECap(data []byte) (*StatefulPCECapability, error) {
 		UPDFlag:              UFlag,
 	}, nil
 }
+
+// https://tools.ietf.org/html/rfc8231#section-7.1.1
+// STATEFUL-PCE-CAPABILITY TLV type is 16 and length is 4
+func newStatefulPCECap(caps *Capabilities) ([]byte, error) {
+	var flags uint32
+	if caps.LSPUpdate {
+		flags |= (1 << 0)
+	}
+	if caps.DBVersion {
+		flags |= (1 << 1)
+	}
+	if caps.LSPInit {
+		flags |= (1 << 2)
+	}
+	if caps.TriggeredResync {
+		flags |= (1 << 3)
+	}
+	if caps.DeltaSync {
+		flags |= (1 << 4)
+	}
+	if caps.TriggeredInitialSync {
+		flags |= (1 << 5)
+	}
+	buf := new(bytes.Buffer)
+	for _, v := range []interface{}{uint16(16), uint16(4), flags} {
+		err := binary.Write(buf, binary.BigEndian, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,190 @@
+package pcep
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+//SyncState represents LSP state synchronization https://tools.ietf.org/html/rfc8231#section-5.6
+type SyncState int
+
+// LSP state synchronization starts once the session is up
+// and is over when the PCC sends the end of synchronization marker
+const (
+	SyncStatePending SyncState = iota
+	SyncStateInProgress
+	SyncStateSynced
+)
+
+var syncStateNames = map[SyncState]string{
+	SyncStatePending:    "Pending",
+	SyncStateInProgress: "InProgress",
+	SyncStateSynced:     "Synced",
+}
+
+func (st SyncState) String() string {
+	name, ok := syncStateNames[st]
+	if !ok {
+		return fmt.Sprintf("Unknown(%d)", int(st))
+	}
+	return name
+}
+
+// MarshalText makes the state readable when sessions are exported as JSON
+func (st SyncState) MarshalText() ([]byte, error) {
+	return []byte(st.String()), nil
+}
+
+// Synced is true once the PCC has reported all of its LSPs
+func (s *Session) Synced() bool {
+	defer s.RUnlock()
+	s.RLock()
+	return s.SyncState == SyncStateSynced
+}
+
+// startSync is called once the session is up, state synchronization
+// only happens when both sides advertised STATEFUL-PCE-CAPABILITY
+// otherwise there is nothing to wait for
+func (s *Session) startSync() {
+	s.loadStoredDB()
+	s.Lock()
+	stateful := s.StatefulCap != nil && s.StatefulCap.Type == 16
+	avoided := stateful && s.avoidSync()
+	trigger := stateful && !avoided && s.triggeredInitialSync()
+	s.deltaSync = false
+	s.deltaFallback = false
+	s.syncedPLSPIDs = nil
+	if stateful && !avoided {
+		s.SyncState = SyncStatePending
+		s.deltaSync = s.startDeltaSync()
+		s.syncedPLSPIDs = make(map[uint32]bool)
+	}
+	version := s.LSPDBVersion
+	delta := s.deltaSync
+	s.Unlock()
+
+	if avoided {
+		logrus.WithFields(logrus.Fields{
+			"type":    "session",
+			"event":   "sync_avoided",
+			"peer":    s.Conn.RemoteAddr().String(),
+			"version": version,
+		}).Info("lsp db version matches skipping state synchronization")
+	}
+	if delta {
+		logrus.WithFields(logrus.Fields{
+			"type":    "session",
+			"event":   "delta_sync",
+			"peer":    s.Conn.RemoteAddr().String(),
+			"version": version,
+		}).Info("lsp db version differs expecting incremental state synchronization")
+	}
+	if !stateful || avoided {
+		s.endSync()
+		return
+	}
+	// with triggered initial sync the PCC waits for us to ask for its LSPs
+	if trigger {
+		err := s.TriggerResync(0)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"type": "err",
+				"func": "TriggerResync",
+				"peer": s.Conn.RemoteAddr().String(),
+			}).Error(err)
+		}
+	}
+}
+
+// syncReport tracks reports with the S flag set which
+// are only sent while synchronization is in progress
+func (s *Session) syncReport(plspID uint32, version uint64) {
+	defer s.Unlock()
+	s.Lock()
+	if s.SyncState == SyncStatePending {
+		s.SyncState = SyncStateInProgress
+	}
+	if s.syncedPLSPIDs != nil {
+		s.syncedPLSPIDs[plspID] = true
+	}
+	// every change reported during incremental sync is newer than the stored
+	// LSP-DB, an older or reset LSP-DB-VERSION comes from full sync
+	// https://tools.ietf.org/html/rfc8232#section-5.3
+	if s.deltaSync && !s.deltaFallback && (s.storedDB == nil || version <= s.storedDB.Version) {
+		s.deltaFallback = true
+		logrus.WithFields(logrus.Fields{
+			"type":    "session",
+			"event":   "delta_sync_fallback",
+			"peer":    s.Conn.RemoteAddr().String(),
+			"plsp_id": plspID,
+			"version": version,
+		}).Info("pcc does full state synchronization instead of incremental one")
+	}
+}
+
+// reconcile removes the LSPs the PCC did not report during a full sync,
+// with incremental sync only the changed LSPs are reported and removed
+// ones come with the R flag so all the others are still valid
+// https://tools.ietf.org/html/rfc8232#section-5.2
+// a PCC unable to do incremental sync falls back to full sync which
+// syncReport records https://tools.ietf.org/html/rfc8232#section-5.3
+// must be called with the session lock held
+func (s *Session) reconcile() []string {
+	stale := make([]string, 0)
+	full := !s.deltaSync || s.deltaFallback
+	if full && s.syncedPLSPIDs != nil {
+		for name, lsp := range s.LSPs {
+			if s.syncedPLSPIDs[lsp.PLSPID] {
+				continue
+			}
+			stale = append(stale, name)
+			delete(s.LSPs, name)
+			if s.PLSPIDToName[lsp.PLSPID] == name {
+				delete(s.PLSPIDToName, lsp.PLSPID)
+			}
+		}
+	}
+	s.deltaSync = false
+	s.deltaFallback = false
+	s.syncedPLSPIDs = nil
+	return stale
+}
+
+// endSync marks the LSP DB as synchronized and lets the controller start provisioning
+// https://tools.ietf.org/html/rfc8231#section-5.6
+func (s *Session) endSync() {
+	s.Lock()
+	if s.SyncState == SyncStateSynced {
+		s.Unlock()
+		return
+	}
+	s.SyncState = SyncStateSynced
+	stale := s.reconcile()
+	lsps := len(s.LSPs)
+	s.Unlock()
+
+	if len(stale) > 0 {
+		logrus.WithFields(logrus.Fields{
+			"type":  "session",
+			"event": "stale_lsps",
+			"peer":  s.Conn.RemoteAddr().String(),
+			"lsps":  stale,
+		}).Info("removed lsps not reported during state synchronization")
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"type":  "session",
+		"event": "synced",
+		"peer":  s.Conn.RemoteAddr().String(),
+		"lsps":  lsps,
+	}).Info("lsp state synchronization done")
+
+	s.saveLSPDB()
+
+	// one pending signal is enough to start provisioning
+	select {
+	case s.SessionSynced <- true:
+	default:
+	}
+}
@@ -0,0 +1,297 @@
+package pcep
+
+import (
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestEndOfSyncMarker(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	s := NewSession(conn)
+	s.StatefulCap.Type = 16
+	s.startSync()
+	if s.Synced() {
+		t.Fatalf("stateful session must wait for the end of sync marker")
+	}
+	lsp, err := s.newLSPObj(false, false, false, true, "", 0)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	s.HandlePCRpt(lsp)
+	if !s.Synced() {
+		t.Errorf("report with PLSP-ID 0 and S flag clear must end sync got %s", s.SyncState)
+	}
+	select {
+	case <-s.SessionSynced:
+	default:
+		t.Errorf("controller must be signaled once synced")
+	}
+}
+
+func TestSyncAvoidance(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	s := NewSession(conn)
+	s.LocalCaps.DBVersion = true
+	s.StatefulCap = &StatefulPCECapability{Type: 16, IncludeDBVersion: true}
+	s.storedDB = &LSPDB{
+		Version: 5,
+		LSPs: map[string]*LSP{
+			"lsp1": {Name: "lsp1", PLSPID: 3},
+		},
+	}
+	// the PCC reports a different version so the LSP-DB must be synced
+	s.LSPDBVersion = 4
+	s.startSync()
+	if s.Synced() {
+		t.Fatalf("session must not skip sync when versions differ")
+	}
+
+	s.LSPDBVersion = 5
+	s.startSync()
+	if !s.Synced() {
+		t.Fatalf("session must skip sync when versions match got %s", s.SyncState)
+	}
+	if s.GetLSP("lsp1") == nil || s.getLSPName(3) != "lsp1" {
+		t.Errorf("stored LSPs must be restored when sync is skipped")
+	}
+}
+
+func TestSyncReconcile(t *testing.T) {
+	for _, delta := range []bool{false, true} {
+		conn, peer := net.Pipe()
+
+		s := NewSession(conn)
+		s.LocalCaps.DBVersion = true
+		s.LocalCaps.DeltaSync = true
+		s.StatefulCap = &StatefulPCECapability{Type: 16, IncludeDBVersion: true, DeltaLSPSyncCap: delta}
+		s.storedDB = &LSPDB{
+			Version: 5,
+			LSPs: map[string]*LSP{
+				"lsp1": {Name: "lsp1", PLSPID: 1},
+				"lsp2": {Name: "lsp2", PLSPID: 2},
+			},
+		}
+		s.LSPDBVersion = 6
+		s.startSync()
+		// only lsp1 is reported before the end of sync marker
+		s.saveUpdLSP(&LSP{Name: "lsp1", PLSPID: 1, Sync: true})
+		s.syncReport(1, 6)
+		s.endSync()
+
+		if s.GetLSP("lsp1") == nil {
+			t.Errorf("delta %t: reported LSP must be kept", delta)
+		}
+		// with incremental sync LSPs which did not change are not reported
+		if (s.GetLSP("lsp2") != nil) != delta {
+			t.Errorf("delta %t: unreported LSP must only be kept with incremental sync", delta)
+		}
+		conn.Close()
+		peer.Close()
+	}
+}
+
+// lspDBController only implements what the LSP-DB handling needs
+type lspDBController struct {
+	Controller
+	dbs    map[string]*LSPDB
+	stored chan *LSPDB
+}
+
+func (c *lspDBController) GetLSPDB(key string) *LSPDB {
+	return c.dbs[key]
+}
+
+func (c *lspDBController) StoreLSPDB(db *LSPDB) error {
+	c.stored <- db
+	return nil
+}
+
+func TestStoredDBBySpeakerEntityID(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	ctr := &lspDBController{
+		dbs: map[string]*LSPDB{
+			"pcc1": {SpeakerEntityID: "pcc1", Addr: "10.0.0.2", Version: 5,
+				LSPs: map[string]*LSP{"lsp1": {Name: "lsp1", PLSPID: 3}}},
+		},
+	}
+	s := NewSession(conn)
+	s.controller = ctr
+	s.LocalCaps.DBVersion = true
+	s.StatefulCap = &StatefulPCECapability{Type: 16, IncludeDBVersion: true}
+	// the DB found by address belongs to another PCC
+	s.storedDB = &LSPDB{SpeakerEntityID: "pcc2", Addr: "10.0.0.1", Version: 5}
+	s.SpeakerEntityID = "pcc1"
+	s.LSPDBVersion = 5
+	s.startSync()
+	if !s.Synced() {
+		t.Fatalf("session must skip sync with the DB of its SPEAKER-ENTITY-ID got %s", s.SyncState)
+	}
+	if s.GetLSP("lsp1") == nil {
+		t.Errorf("stored LSPs must be restored when sync is skipped")
+	}
+}
+
+func TestLSPDBSaver(t *testing.T) {
+	conn, peer := net.Pipe()
+	defer conn.Close()
+	defer peer.Close()
+
+	ctr := &lspDBController{stored: make(chan *LSPDB, 10)}
+	s := NewSession(conn)
+	s.controller = ctr
+	s.LocalCaps.DBVersion = true
+	s.StatefulCap = &StatefulPCECapability{Type: 16, IncludeDBVersion: true}
+	s.SyncState = SyncStateSynced
+	s.LSPDBVersion = 1
+
+	// reports do not wait for the DB to be written
+	for v := uint64(2); v <= 5; v++ {
+		s.setDBVersion(v)
+	}
+	select {
+	case db := <-ctr.stored:
+		t.Fatalf("expected no DB to be stored without the saver got %+v", db)
+	default:
+	}
+
+	// the saver stores the latest version when the session closes
+	done := make(chan struct{})
+	go func() {
+		s.lspDBSaver()
+		close(done)
+	}()
+	close(s.StopKA)
+	<-done
+	select {
+	case db := <-ctr.stored:
+		if db.Version != 5 {
+			t.Errorf("expected version 5 to be stored got %d", db.Version)
+		}
+	default:
+		t.Error("expected pending DB to be stored once the session closed")
+	}
+	select {
+	case db := <-ctr.stored:
+		t.Errorf("expected a single store got %+v", db)
+	default:
+	}
+}
+
+func TestDeltaSyncFallback(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		version uint64
+		// LSP-DB-VERSION of the report by PLSP-ID
+		reported map[uint32]uint64
+		delta    bool
+		fallback bool
+		kept     []string
+		removed  []string
+	}{
+		{
+			name:     "incremental sync",
+			version:  7,
+			reported: map[uint32]uint64{1: 6, 3: 7},
+			delta:    true,
+			kept:     []string{"lsp1", "lsp2", "lsp3"},
+		},
+		{
+			// a single LSP changed several times
+			name:     "incremental sync with fewer LSPs than changes",
+			version:  9,
+			reported: map[uint32]uint64{1: 9},
+			delta:    true,
+			kept:     []string{"lsp1", "lsp2", "lsp3"},
+		},
+		{
+			// unchanged LSPs are reported with the version of their last change
+			name:     "full sync with fewer LSPs than changes",
+			version:  9,
+			reported: map[uint32]uint64{1: 3, 3: 8},
+			delta:    true,
+			fallback: true,
+			kept:     []string{"lsp1", "lsp3"},
+			removed:  []string{"lsp2"},
+		},
+		{
+			name:     "LSP-DB-VERSION reset",
+			version:  7,
+			reported: map[uint32]uint64{1: 1},
+			delta:    true,
+			fallback: true,
+			kept:     []string{"lsp1"},
+			removed:  []string{"lsp2", "lsp3"},
+		},
+		{
+			name:     "report without LSP-DB-VERSION",
+			version:  7,
+			reported: map[uint32]uint64{1: 6, 3: 0},
+			delta:    true,
+			fallback: true,
+			kept:     []string{"lsp1", "lsp3"},
+			removed:  []string{"lsp2"},
+		},
+		{
+			// the PCC lost its LSP-DB
+			name:     "PCC version behind the stored one",
+			version:  2,
+			reported: map[uint32]uint64{1: 2},
+			kept:     []string{"lsp1"},
+			removed:  []string{"lsp2", "lsp3"},
+		},
+	} {
+		conn, peer := net.Pipe()
+
+		s := NewSession(conn)
+		s.LocalCaps.DBVersion = true
+		s.LocalCaps.DeltaSync = true
+		s.StatefulCap = &StatefulPCECapability{Type: 16, IncludeDBVersion: true, DeltaLSPSyncCap: true}
+		s.storedDB = &LSPDB{
+			Version: 5,
+			LSPs: map[string]*LSP{
+				"lsp1": {Name: "lsp1", PLSPID: 1},
+				"lsp2": {Name: "lsp2", PLSPID: 2},
+				"lsp3": {Name: "lsp3", PLSPID: 3},
+			},
+		}
+		s.LSPDBVersion = c.version
+		s.startSync()
+		if s.deltaSync != c.delta {
+			t.Errorf("%s: expected incremental sync %t", c.name, c.delta)
+		}
+		for id, version := range c.reported {
+			s.saveUpdLSP(&LSP{Name: fmt.Sprintf("lsp%d", id), PLSPID: id, Sync: true})
+			s.syncReport(id, version)
+		}
+		if s.deltaFallback != c.fallback {
+			t.Errorf("%s: expected fallback to full sync %t", c.name, c.fallback)
+		}
+		s.endSync()
+
+		for _, name := range c.kept {
+			if s.GetLSP(name) == nil {
+				t.Errorf("%s: %s must be kept", c.name, name)
+			}
+		}
+		for _, name := range c.removed {
+			if s.GetLSP(name) != nil {
+				t.Errorf("%s: %s must be removed", c.name, name)
+			}
+		}
+		if s.deltaFallback {
+			t.Errorf("%s: fallback must be cleared once synced", c.name)
+		}
+		conn.Close()
+		peer.Close()
+	}
+}
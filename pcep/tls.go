@@ -0,0 +1,243 @@
+package pcep
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+//TLSPolicy defines if PCEPS is used with a peer https://tools.ietf.org/html/rfc8253#section-3.2
+type TLSPolicy string
+
+// require only accepts PCEPS, prefer waits for the PCC to choose
+// between StartTLS and Open and disable never uses TLS
+const (
+	TLSDisable TLSPolicy = "disable"
+	TLSPrefer  TLSPolicy = "prefer"
+	TLSRequire TLSPolicy = "require"
+)
+
+// Valid is true for the known policies
+func (p TLSPolicy) Valid() bool {
+	return p == TLSDisable || p == TLSPrefer || p == TLSRequire
+}
+
+// loadTLSConfig builds the server side TLS config, the PCE acts as TLS server
+// and PCCs have to present a certificate signed by the configured CA
+// https://tools.ietf.org/html/rfc8253#section-3.4
+func (c *Cfg) loadTLSConfig() error {
+	if c.TLSPolicy == "" {
+		c.TLSPolicy = TLSDisable
+	}
+	if !c.TLSPolicy.Valid() {
+		return fmt.Errorf("unknown tls policy %q", c.TLSPolicy)
+	}
+	if c.TLSCertFile == "" || c.TLSKeyFile == "" {
+		if c.TLSPolicy != TLSDisable {
+			return errors.New("tls cert and key files are required to use PCEPS")
+		}
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile)
+	if err != nil {
+		return err
+	}
+	if c.TLSCAFile == "" {
+		return errors.New("tls ca file is required to verify PCC certificates")
+	}
+	ca, err := ioutil.ReadFile(c.TLSCAFile)
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("no certificates found in %s", c.TLSCAFile)
+	}
+	c.tlsConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	return nil
+}
+
+// verifyPeerIdentity binds the PCC certificate to the identities configured
+// for the peer, its address or name must be in the subjectAltName or in the
+// CN when the certificate has no subjectAltName https://tools.ietf.org/html/rfc8253#section-3.4
+func verifyPeerIdentity(ids []string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
+			return errors.New("no verified PCC certificate")
+		}
+		cert := verifiedChains[0][0]
+		for _, id := range ids {
+			if ip := net.ParseIP(id); ip != nil {
+				for _, certIP := range cert.IPAddresses {
+					if certIP.Equal(ip) {
+						return nil
+					}
+				}
+				continue
+			}
+			if cert.VerifyHostname(id) == nil {
+				return nil
+			}
+			noSAN := len(cert.DNSNames) == 0 && len(cert.IPAddresses) == 0
+			if noSAN && cert.Subject.CommonName == id {
+				return nil
+			}
+		}
+		return fmt.Errorf("PCC certificate %q does not match any of %v", cert.Subject.CommonName, ids)
+	}
+}
+
+// bufferedConn hands over bytes already buffered by msgReader
+// so the TLS handshake does not miss the beginning of ClientHello
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// https://tools.ietf.org/html/rfc8253#section-3.3
+// Message-Type is 13 and the message has the common header only
+func newStartTLSMsg() ([]byte, error) {
+	return newCommonHeader(13, 0)
+}
+
+func (s *Session) sendStartTLS() error {
+	msg, err := newStartTLSMsg()
+	if err != nil {
+		return err
+	}
+	_, err = s.Conn.Write(msg)
+	if err != nil {
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"type": "tls",
+		"peer": s.Conn.RemoteAddr().String(),
+	}).Info("sent StartTLS")
+	return nil
+}
+
+// startTLS runs the StartTLS exchange and the TLS handshake before the PCEP
+// session initialization. With prefer policy the PCC may send Open instead,
+// in this case the Open is returned so it is processed as usual
+// the PCC certificate has to match one of ids https://tools.ietf.org/html/rfc8253#section-3.2
+func (s *Session) startTLS(reader *msgReader, policy TLSPolicy, cfg *tls.Config, ids []string) (*msgReader, []byte, error) {
+	if cfg == nil {
+		return nil, nil, errors.New("PCEPS is not configured")
+	}
+	cfg = cfg.Clone()
+	cfg.VerifyPeerCertificate = verifyPeerIdentity(ids)
+	s.Lock()
+	s.setState(StateStartTLSWait)
+	// no StartTLS message (nor PCErr/Open) before StartTLSWait timer expiry
+	s.startFSMTimer(startTLSWaitTimer, StateStartTLSWait, 25, 5)
+	s.Unlock()
+
+	// PCE supporting PCEPS only may send StartTLS straight away
+	if policy == TLSRequire {
+		err := s.sendStartTLS()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	msg, err := reader.readMsg()
+	if err != nil {
+		return nil, nil, err
+	}
+	ch, err := parseCommonHeader(msg[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	switch ch.MessageType {
+	case 13:
+		if policy == TLSPrefer {
+			err := s.sendStartTLS()
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		s.Lock()
+		s.stopFSMTimer()
+		s.Unlock()
+
+		tlsConn := tls.Server(&bufferedConn{Conn: s.Conn, r: reader.r}, cfg)
+		// handshake must not hang forever if the PCC goes silent
+		err = tlsConn.SetDeadline(time.Now().Add(startTLSWaitTimer))
+		if err != nil {
+			return nil, nil, err
+		}
+		// on failure both peers close the connection without any PCEP message
+		err = tlsConn.Handshake()
+		if err != nil {
+			return nil, nil, err
+		}
+		err = tlsConn.SetDeadline(time.Time{})
+		if err != nil {
+			return nil, nil, err
+		}
+		s.Lock()
+		s.Conn = tlsConn
+		s.TLS = true
+		s.Unlock()
+
+		logrus.WithFields(logrus.Fields{
+			"type":    "tls",
+			"peer":    tlsConn.RemoteAddr().String(),
+			"version": tlsConn.ConnectionState().Version,
+		}).Info("tls established")
+
+		s.startFSM()
+		return newMsgReader(tlsConn), nil, nil
+	case 1:
+		if policy == TLSRequire {
+			// PCEPS only speaker treats Open as unexpected
+			s.sendErr(1, 1)
+			return nil, nil, errors.New("received Open but PCEPS is required")
+		}
+		logrus.WithFields(logrus.Fields{
+			"type": "tls",
+			"peer": s.Conn.RemoteAddr().String(),
+		}).Warn("PCC does not use PCEPS continuing without tls")
+		s.startFSM()
+		return reader, msg, nil
+	case 6:
+		s.handleErrObj(msg[4:])
+		return nil, nil, errors.New("PCC refused to establish PCEPS")
+	default:
+		// reception of any other message apart from StartTLS, Open, or PCErr
+		s.sendErr(25, 2)
+		return nil, nil, fmt.Errorf("unexpected msg type %d before StartTLS", ch.MessageType)
+	}
+}
+
+// handleStartTLS is used when StartTLS is received outside of StartTLSWait
+// https://tools.ietf.org/html/rfc8253#section-3.2
+func (s *Session) handleStartTLS() {
+	s.RLock()
+	// only our Open has been sent so far
+	first := s.State == StateOpenWait && s.openRetry == 0 && !s.LocalOK
+	s.RUnlock()
+	if first {
+		// failure, connection without TLS is possible
+		s.sendErr(25, 4)
+	} else {
+		// reception of StartTLS after any PCEP exchange
+		s.sendErr(25, 1)
+	}
+	s.closeSession(1)
+}
@@ -0,0 +1,274 @@
+package pcep
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+// newTestCert returns a self signed certificate with the given CN and subjectAltName
+func newTestCert(t *testing.T, cn string, dns []string, ips []net.IP) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     dns,
+		IPAddresses:  ips,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	return cert
+}
+
+func TestVerifyPeerIdentity(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		cert  *x509.Certificate
+		ids   []string
+		valid bool
+	}{
+		{
+			name:  "ip san matches peer address",
+			cert:  newTestCert(t, "pcc1", nil, []net.IP{net.ParseIP("10.0.0.1")}),
+			ids:   []string{"10.0.0.1"},
+			valid: true,
+		},
+		{
+			name:  "ip san matches loopback",
+			cert:  newTestCert(t, "pcc1", nil, []net.IP{net.ParseIP("1.1.1.1")}),
+			ids:   []string{"10.0.0.1", "pcc1", "1.1.1.1"},
+			valid: true,
+		},
+		{
+			name:  "dns san matches router name",
+			cert:  newTestCert(t, "other", []string{"pcc1"}, nil),
+			ids:   []string{"10.0.0.1", "pcc1"},
+			valid: true,
+		},
+		{
+			name:  "cn without san matches router name",
+			cert:  newTestCert(t, "pcc1", nil, nil),
+			ids:   []string{"10.0.0.1", "pcc1"},
+			valid: true,
+		},
+		{
+			name: "cn is ignored when san is present",
+			cert: newTestCert(t, "pcc1", []string{"pcc2"}, nil),
+			ids:  []string{"10.0.0.1", "pcc1"},
+		},
+		{
+			name: "certificate of another router",
+			cert: newTestCert(t, "pcc2", nil, []net.IP{net.ParseIP("10.0.0.2")}),
+			ids:  []string{"10.0.0.1", "pcc1"},
+		},
+	} {
+		err := verifyPeerIdentity(c.ids)(nil, [][]*x509.Certificate{{c.cert}})
+		if (err == nil) != c.valid {
+			t.Errorf("%s: expected valid %t got %v", c.name, c.valid, err)
+		}
+	}
+
+	err := verifyPeerIdentity([]string{"10.0.0.1"})(nil, nil)
+	if err == nil {
+		t.Error("expected error without verified chains")
+	}
+}
+
+func TestTLSPolicyValid(t *testing.T) {
+	for _, c := range []struct {
+		policy TLSPolicy
+		valid  bool
+	}{
+		{policy: TLSDisable, valid: true},
+		{policy: TLSPrefer, valid: true},
+		{policy: TLSRequire, valid: true},
+		{policy: "", valid: false},
+		{policy: "required", valid: false},
+	} {
+		if c.policy.Valid() != c.valid {
+			t.Errorf("policy %q must be valid: %t", c.policy, c.valid)
+		}
+	}
+
+	cfg := &Cfg{TLSPolicy: "always"}
+	if cfg.loadTLSConfig() == nil {
+		t.Error("expected error for unknown tls policy")
+	}
+	cfg = &Cfg{}
+	if cfg.loadTLSConfig() != nil || cfg.TLSPolicy != TLSDisable {
+		t.Errorf("expected disable policy by default got %q", cfg.TLSPolicy)
+	}
+}
+
+// newTLSTestSession is like newTestSession but hands out the peer
+// side of the pipe so the test can act as PCC
+func newTLSTestSession(t *testing.T) (*Session, net.Conn, *msgReader, <-chan []byte) {
+	conn, peer := net.Pipe()
+	t.Cleanup(func() {
+		conn.Close()
+		peer.Close()
+	})
+	cfg := &Cfg{MinKeepalive: 10, MaxKeepalive: 60}
+	cfg.setDefaults()
+	s := NewSession(conn)
+	s.cfg = cfg
+
+	msgs := make(chan []byte, 10)
+	go func() {
+		defer close(msgs)
+		r := newMsgReader(peer)
+		for {
+			msg, err := r.readMsg()
+			if err != nil {
+				return
+			}
+			msgs <- msg
+		}
+	}()
+	return s, peer, newMsgReader(conn), msgs
+}
+
+// expectMsg reads the next msg and checks its type
+func expectMsg(t *testing.T, msgs <-chan []byte, msgType uint8) {
+	t.Helper()
+	select {
+	case msg, ok := <-msgs:
+		if !ok {
+			t.Fatalf("expected msg type %d got connection closed", msgType)
+		}
+		if msg[1] != msgType {
+			t.Fatalf("expected msg type %d got %d", msgType, msg[1])
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected msg type %d got nothing", msgType)
+	}
+}
+
+func newTestOpenMsg(t *testing.T) []byte {
+	t.Helper()
+	open, err := newOpenObj(&OpenObject{Keepalive: 30, DeadTimer: 120}, &Capabilities{})
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	ch, err := newCommonHeader(1, uint16(len(open)))
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	return append(ch, open...)
+}
+
+type startTLSResult struct {
+	msg []byte
+	err error
+}
+
+func runStartTLS(s *Session, reader *msgReader, policy TLSPolicy) <-chan startTLSResult {
+	res := make(chan startTLSResult, 1)
+	go func() {
+		_, msg, err := s.startTLS(reader, policy, &tls.Config{}, []string{"10.0.0.1"})
+		res <- startTLSResult{msg: msg, err: err}
+	}()
+	return res
+}
+
+func TestStartTLSPolicy(t *testing.T) {
+	// PCC without PCEPS is accepted with prefer and the Open is handed over
+	s, peer, reader, msgs := newTLSTestSession(t)
+	res := runStartTLS(s, reader, TLSPrefer)
+	open := newTestOpenMsg(t)
+	_, err := peer.Write(open)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	r := <-res
+	if r.err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", r.err.Error())
+	}
+	if len(r.msg) != len(open) {
+		t.Errorf("expected the Open of the PCC got %v", r.msg)
+	}
+	// no StartTLS is sent, our Open goes first
+	expectMsg(t, msgs, 1)
+	if s.GetState() != StateOpenWait {
+		t.Errorf("expected OpenWait state got %s", s.GetState())
+	}
+	s.Lock()
+	s.stopFSMTimer()
+	s.Unlock()
+
+	// PCC without PCEPS is refused with require
+	s, peer, reader, msgs = newTLSTestSession(t)
+	res = runStartTLS(s, reader, TLSRequire)
+	expectMsg(t, msgs, 13)
+	_, err = peer.Write(open)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	expectErr(t, msgs, 1, 1)
+	r = <-res
+	if r.err == nil {
+		t.Error("expected error when Open is received with require policy")
+	}
+	s.Lock()
+	s.stopFSMTimer()
+	s.Unlock()
+
+	// any other msg before StartTLS
+	s, peer, reader, msgs = newTLSTestSession(t)
+	res = runStartTLS(s, reader, TLSPrefer)
+	ka, err := newCommonHeader(2, 0)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	_, err = peer.Write(ka)
+	if err != nil {
+		t.Fatalf("must not see any errors, instead got: %s", err.Error())
+	}
+	expectErr(t, msgs, 25, 2)
+	r = <-res
+	if r.err == nil {
+		t.Error("expected error when keepalive is received before StartTLS")
+	}
+	s.Lock()
+	s.stopFSMTimer()
+	s.Unlock()
+}
+
+func TestStartTLSWaitTimer(t *testing.T) {
+	defer func(d time.Duration) { startTLSWaitTimer = d }(startTLSWaitTimer)
+	startTLSWaitTimer = 10 * time.Millisecond
+
+	for _, policy := range []TLSPolicy{TLSPrefer, TLSRequire} {
+		s, _, reader, msgs := newTLSTestSession(t)
+		res := runStartTLS(s, reader, policy)
+		if policy == TLSRequire {
+			expectMsg(t, msgs, 13)
+		}
+		// silent PCC
+		expectErr(t, msgs, 25, 5)
+		expectClosed(t, s, msgs)
+		r := <-res
+		if r.err == nil {
+			t.Errorf("%s: expected error once StartTLSWait timer expired", policy)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package proto
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
@@ -26,6 +27,44 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// PCEP FSM states https://tools.ietf.org/html/rfc5440#appendix-A
+type SessionState int32
+
+const (
+	SessionState_Idle         SessionState = 0
+	SessionState_TCPPending   SessionState = 1
+	SessionState_OpenWait     SessionState = 2
+	SessionState_KeepWait     SessionState = 3
+	SessionState_Up           SessionState = 4
+	SessionState_StartTLSWait SessionState = 5
+)
+
+var SessionState_name = map[int32]string{
+	0: "Idle",
+	1: "TCPPending",
+	2: "OpenWait",
+	3: "KeepWait",
+	4: "Up",
+	5: "StartTLSWait",
+}
+
+var SessionState_value = map[string]int32{
+	"Idle":         0,
+	"TCPPending":   1,
+	"OpenWait":     2,
+	"KeepWait":     3,
+	"Up":           4,
+	"StartTLSWait": 5,
+}
+
+func (x SessionState) String() string {
+	return proto.EnumName(SessionState_name, int32(x))
+}
+
+func (SessionState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{0}
+}
+
 type StartBGPRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -230,15 +269,16 @@ func (m *SessionsRequest) GetPccName() string {
 }
 
 type Session struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	MsgCount             uint64   `protobuf:"varint,2,opt,name=MsgCount,proto3" json:"MsgCount,omitempty"`
-	State                int32    `protobuf:"varint,3,opt,name=State,proto3" json:"State,omitempty"`
-	Address              string   `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
-	Keepalive            uint32   `protobuf:"varint,5,opt,name=Keepalive,proto3" json:"Keepalive,omitempty"`
-	DeadTimer            uint32   `protobuf:"varint,6,opt,name=DeadTimer,proto3" json:"DeadTimer,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ID                   string       `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	MsgCount             uint64       `protobuf:"varint,2,opt,name=MsgCount,proto3" json:"MsgCount,omitempty"`
+	State                SessionState `protobuf:"varint,3,opt,name=State,proto3,enum=pceapiproto.SessionState" json:"State,omitempty"`
+	Address              string       `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Keepalive            uint32       `protobuf:"varint,5,opt,name=Keepalive,proto3" json:"Keepalive,omitempty"`
+	DeadTimer            uint32       `protobuf:"varint,6,opt,name=DeadTimer,proto3" json:"DeadTimer,omitempty"`
+	TLS                  bool         `protobuf:"varint,7,opt,name=TLS,proto3" json:"TLS,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Session) Reset()         { *m = Session{} }
@@ -288,11 +328,11 @@ func (m *Session) GetMsgCount() uint64 {
 	return 0
 }
 
-func (m *Session) GetState() int32 {
+func (m *Session) GetState() SessionState {
 	if m != nil {
 		return m.State
 	}
-	return 0
+	return SessionState_Idle
 }
 
 func (m *Session) GetAddress() string {
@@ -316,6 +356,150 @@ func (m *Session) GetDeadTimer() uint32 {
 	return 0
 }
 
+func (m *Session) GetTLS() bool {
+	if m != nil {
+		return m.TLS
+	}
+	return false
+}
+
+type AuthStatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthStatsRequest) Reset()         { *m = AuthStatsRequest{} }
+func (m *AuthStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*AuthStatsRequest) ProtoMessage()    {}
+func (*AuthStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{6}
+}
+func (m *AuthStatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AuthStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AuthStatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AuthStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthStatsRequest.Merge(m, src)
+}
+func (m *AuthStatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AuthStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthStatsRequest proto.InternalMessageInfo
+
+// TCP segments dropped by the kernel because of failed authentication,
+// the counters are host wide and not per PCEP peer
+type AuthStatsReply struct {
+	MD5NotFound   uint64 `protobuf:"varint,1,opt,name=MD5NotFound,proto3" json:"MD5NotFound,omitempty"`
+	MD5Unexpected uint64 `protobuf:"varint,2,opt,name=MD5Unexpected,proto3" json:"MD5Unexpected,omitempty"`
+	MD5Failure    uint64 `protobuf:"varint,3,opt,name=MD5Failure,proto3" json:"MD5Failure,omitempty"`
+	AORequired    uint64 `protobuf:"varint,4,opt,name=AORequired,proto3" json:"AORequired,omitempty"`
+	AOBad         uint64 `protobuf:"varint,5,opt,name=AOBad,proto3" json:"AOBad,omitempty"`
+	AOKeyNotFound uint64 `protobuf:"varint,6,opt,name=AOKeyNotFound,proto3" json:"AOKeyNotFound,omitempty"`
+	// host as the counters cover all TCP sockets
+	Scope                string   `protobuf:"bytes,7,opt,name=Scope,proto3" json:"Scope,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthStatsReply) Reset()         { *m = AuthStatsReply{} }
+func (m *AuthStatsReply) String() string { return proto.CompactTextString(m) }
+func (*AuthStatsReply) ProtoMessage()    {}
+func (*AuthStatsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{7}
+}
+func (m *AuthStatsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AuthStatsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AuthStatsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AuthStatsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthStatsReply.Merge(m, src)
+}
+func (m *AuthStatsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *AuthStatsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthStatsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthStatsReply proto.InternalMessageInfo
+
+func (m *AuthStatsReply) GetMD5NotFound() uint64 {
+	if m != nil {
+		return m.MD5NotFound
+	}
+	return 0
+}
+
+func (m *AuthStatsReply) GetMD5Unexpected() uint64 {
+	if m != nil {
+		return m.MD5Unexpected
+	}
+	return 0
+}
+
+func (m *AuthStatsReply) GetMD5Failure() uint64 {
+	if m != nil {
+		return m.MD5Failure
+	}
+	return 0
+}
+
+func (m *AuthStatsReply) GetAORequired() uint64 {
+	if m != nil {
+		return m.AORequired
+	}
+	return 0
+}
+
+func (m *AuthStatsReply) GetAOBad() uint64 {
+	if m != nil {
+		return m.AOBad
+	}
+	return 0
+}
+
+func (m *AuthStatsReply) GetAOKeyNotFound() uint64 {
+	if m != nil {
+		return m.AOKeyNotFound
+	}
+	return 0
+}
+
+func (m *AuthStatsReply) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
 type SessionsReply struct {
 	Sessions             []*Session `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -327,7 +511,7 @@ func (m *SessionsReply) Reset()         { *m = SessionsReply{} }
 func (m *SessionsReply) String() string { return proto.CompactTextString(m) }
 func (*SessionsReply) ProtoMessage()    {}
 func (*SessionsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_614bac86d996c9a3, []int{6}
+	return fileDescriptor_614bac86d996c9a3, []int{8}
 }
 func (m *SessionsReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -374,7 +558,7 @@ func (m *LSPRequest) Reset()         { *m = LSPRequest{} }
 func (m *LSPRequest) String() string { return proto.CompactTextString(m) }
 func (*LSPRequest) ProtoMessage()    {}
 func (*LSPRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_614bac86d996c9a3, []int{7}
+	return fileDescriptor_614bac86d996c9a3, []int{9}
 }
 func (m *LSPRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -430,20 +614,21 @@ type LSP struct {
 	LSPID        uint32 `protobuf:"varint,14,opt,name=LSPID,proto3" json:"LSPID,omitempty"`
 	// IPv4ID       *LSPIPv4Identifiers
 	// IPv6ID       *LSPIPv6Identifiers
-	SRPID                uint32   `protobuf:"varint,15,opt,name=SRPID,proto3" json:"SRPID,omitempty"`
-	ExcludeAny           uint32   `protobuf:"varint,16,opt,name=ExcludeAny,proto3" json:"ExcludeAny,omitempty"`
-	IncludeAny           uint32   `protobuf:"varint,17,opt,name=IncludeAny,proto3" json:"IncludeAny,omitempty"`
-	IncludeAll           uint32   `protobuf:"varint,18,opt,name=IncludeAll,proto3" json:"IncludeAll,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	SRPID                uint32    `protobuf:"varint,15,opt,name=SRPID,proto3" json:"SRPID,omitempty"`
+	ExcludeAny           uint32    `protobuf:"varint,16,opt,name=ExcludeAny,proto3" json:"ExcludeAny,omitempty"`
+	IncludeAny           uint32    `protobuf:"varint,17,opt,name=IncludeAny,proto3" json:"IncludeAny,omitempty"`
+	IncludeAll           uint32    `protobuf:"varint,18,opt,name=IncludeAll,proto3" json:"IncludeAll,omitempty"`
+	Metrics              []*Metric `protobuf:"bytes,19,rep,name=Metrics,proto3" json:"Metrics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *LSP) Reset()         { *m = LSP{} }
 func (m *LSP) String() string { return proto.CompactTextString(m) }
 func (*LSP) ProtoMessage()    {}
 func (*LSP) Descriptor() ([]byte, []int) {
-	return fileDescriptor_614bac86d996c9a3, []int{8}
+	return fileDescriptor_614bac86d996c9a3, []int{10}
 }
 func (m *LSP) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -598,6 +783,87 @@ func (m *LSP) GetIncludeAll() uint32 {
 	return 0
 }
 
+func (m *LSP) GetMetrics() []*Metric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+// METRIC object reported for the LSP https://tools.ietf.org/html/rfc5440#section-7.8
+type Metric struct {
+	Type uint32 `protobuf:"varint,1,opt,name=Type,proto3" json:"Type,omitempty"`
+	// B flag the value is a bound
+	Bound bool `protobuf:"varint,2,opt,name=Bound,proto3" json:"Bound,omitempty"`
+	// C flag the value is the computed path cost
+	Computed             bool     `protobuf:"varint,3,opt,name=Computed,proto3" json:"Computed,omitempty"`
+	Value                float32  `protobuf:"fixed32,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Metric) Reset()         { *m = Metric{} }
+func (m *Metric) String() string { return proto.CompactTextString(m) }
+func (*Metric) ProtoMessage()    {}
+func (*Metric) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{11}
+}
+func (m *Metric) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Metric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Metric.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Metric) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metric.Merge(m, src)
+}
+func (m *Metric) XXX_Size() int {
+	return m.Size()
+}
+func (m *Metric) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metric.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metric proto.InternalMessageInfo
+
+func (m *Metric) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *Metric) GetBound() bool {
+	if m != nil {
+		return m.Bound
+	}
+	return false
+}
+
+func (m *Metric) GetComputed() bool {
+	if m != nil {
+		return m.Computed
+	}
+	return false
+}
+
+func (m *Metric) GetValue() float32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
 type LSPReply struct {
 	LSPs                 []*LSP   `protobuf:"bytes,1,rep,name=LSPs,proto3" json:"LSPs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -609,7 +875,7 @@ func (m *LSPReply) Reset()         { *m = LSPReply{} }
 func (m *LSPReply) String() string { return proto.CompactTextString(m) }
 func (*LSPReply) ProtoMessage()    {}
 func (*LSPReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_614bac86d996c9a3, []int{9}
+	return fileDescriptor_614bac86d996c9a3, []int{12}
 }
 func (m *LSPReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -645,960 +911,4159 @@ func (m *LSPReply) GetLSPs() []*LSP {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*StartBGPRequest)(nil), "pceapiproto.StartBGPRequest")
-	proto.RegisterType((*StartBGPReplay)(nil), "pceapiproto.StartBGPReplay")
-	proto.RegisterType((*StopBGPRequest)(nil), "pceapiproto.StopBGPRequest")
-	proto.RegisterType((*StopBGPReplay)(nil), "pceapiproto.StopBGPReplay")
-	proto.RegisterType((*SessionsRequest)(nil), "pceapiproto.SessionsRequest")
-	proto.RegisterType((*Session)(nil), "pceapiproto.Session")
-	proto.RegisterType((*SessionsReply)(nil), "pceapiproto.SessionsReply")
-	proto.RegisterType((*LSPRequest)(nil), "pceapiproto.LSPRequest")
-	proto.RegisterType((*LSP)(nil), "pceapiproto.LSP")
-	proto.RegisterType((*LSPReply)(nil), "pceapiproto.LSPReply")
+// SR hop of a candidate path, node ID is either IPv4 or IPv6
+// a hop without node ID is sent with the SID only
+type SRHop struct {
+	SID                  uint32   `protobuf:"varint,1,opt,name=SID,proto3" json:"SID,omitempty"`
+	IPv4NodeID           string   `protobuf:"bytes,2,opt,name=IPv4NodeID,proto3" json:"IPv4NodeID,omitempty"`
+	IPv6NodeID           string   `protobuf:"bytes,3,opt,name=IPv6NodeID,proto3" json:"IPv6NodeID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("pceapi.proto", fileDescriptor_614bac86d996c9a3) }
-
-var fileDescriptor_614bac86d996c9a3 = []byte{
-	// 621 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x94, 0xcd, 0x8a, 0xdb, 0x3c,
-	0x14, 0x86, 0xc7, 0x71, 0x7e, 0x9c, 0x93, 0xc9, 0xcf, 0x88, 0xf9, 0xbe, 0x8a, 0xcc, 0x10, 0x82,
-	0x29, 0x25, 0x50, 0x08, 0xc3, 0x74, 0xd9, 0x55, 0x12, 0x87, 0x34, 0x34, 0x6d, 0x8d, 0x5c, 0x18,
-	0xe8, 0xce, 0xb5, 0xc5, 0x60, 0x50, 0x6c, 0xd5, 0x56, 0x86, 0xe6, 0x4e, 0xba, 0xe8, 0xa6, 0x97,
-	0xd2, 0x5d, 0x97, 0xbd, 0x84, 0x92, 0xde, 0x48, 0x91, 0xe4, 0x38, 0xf1, 0x4c, 0x87, 0xae, 0xa2,
-	0xf7, 0x79, 0x8f, 0x25, 0x9d, 0x93, 0xa3, 0x03, 0xa7, 0x3c, 0xa0, 0x3e, 0x8f, 0xc6, 0x3c, 0x4d,
-	0x44, 0x82, 0x5a, 0x5a, 0x29, 0x61, 0x9f, 0x41, 0xd7, 0x13, 0x7e, 0x2a, 0xa6, 0x0b, 0x97, 0xd0,
-	0x4f, 0x1b, 0x9a, 0x09, 0xbb, 0x07, 0x9d, 0x03, 0xe2, 0xcc, 0xdf, 0x6a, 0x92, 0xf0, 0xa3, 0x98,
-	0x2e, 0xb4, 0x0b, 0xa2, 0x42, 0x9e, 0x43, 0xd7, 0xa3, 0x59, 0x16, 0x25, 0x71, 0x96, 0xc7, 0x20,
-	0x0c, 0x0d, 0x1e, 0x04, 0x6f, 0xfd, 0x35, 0xc5, 0xc6, 0xd0, 0x18, 0x35, 0xc9, 0x5e, 0xda, 0xdf,
-	0x0c, 0x68, 0xe4, 0xd1, 0xa8, 0x03, 0x95, 0xa5, 0x93, 0x07, 0x54, 0x96, 0x0e, 0xea, 0x83, 0xf5,
-	0x26, 0xbb, 0x9d, 0x25, 0x9b, 0x58, 0xe0, 0xca, 0xd0, 0x18, 0x55, 0x49, 0xa1, 0xd1, 0x39, 0xd4,
-	0x3c, 0xe1, 0x0b, 0x8a, 0xcd, 0xa1, 0x31, 0xaa, 0x11, 0x2d, 0xe4, 0x39, 0x7e, 0x18, 0xa6, 0x34,
-	0xcb, 0x70, 0x55, 0x9f, 0x93, 0x4b, 0x74, 0x09, 0xcd, 0xd7, 0x94, 0x72, 0x9f, 0x45, 0x77, 0x14,
-	0xd7, 0x86, 0xc6, 0xa8, 0x4d, 0x0e, 0x40, 0xba, 0x0e, 0xf5, 0xc3, 0xf7, 0xd1, 0x9a, 0xa6, 0xb8,
-	0xae, 0xdd, 0x02, 0xd8, 0x13, 0x68, 0x1f, 0x12, 0xe2, 0x6c, 0x8b, 0xae, 0xc0, 0xca, 0x72, 0x80,
-	0x8d, 0xa1, 0x39, 0x6a, 0x5d, 0x9f, 0x8f, 0x8f, 0x2a, 0x39, 0xce, 0xa3, 0x49, 0x11, 0x65, 0x3f,
-	0x03, 0x58, 0x79, 0xee, 0xbf, 0xcb, 0xf1, 0xdd, 0x04, 0x73, 0xe5, 0xb9, 0x32, 0x75, 0x87, 0x32,
-	0x7a, 0x2b, 0x33, 0x94, 0x21, 0x16, 0x29, 0x34, 0x42, 0x50, 0xf5, 0xb6, 0x71, 0xa0, 0x4a, 0x62,
-	0x11, 0xb5, 0x46, 0xff, 0x43, 0x9d, 0xd0, 0x75, 0x72, 0xa7, 0xeb, 0x61, 0x91, 0x5c, 0xc9, 0x32,
-	0x4d, 0xc2, 0x75, 0x14, 0xab, 0x72, 0x58, 0x44, 0x0b, 0xb9, 0xc3, 0x3b, 0x4e, 0xd3, 0xbc, 0x0e,
-	0x6a, 0x2d, 0x99, 0xba, 0x50, 0x5d, 0x5d, 0x48, 0xad, 0x51, 0x0f, 0x4c, 0x2f, 0x0d, 0x70, 0x43,
-	0x21, 0xb9, 0x94, 0xc4, 0xc9, 0x04, 0xb6, 0x34, 0x71, 0x32, 0x21, 0x4b, 0xe7, 0x51, 0xb1, 0xe1,
-	0x6e, 0x1a, 0x25, 0xb8, 0xa9, 0x4b, 0x57, 0x00, 0x99, 0xc7, 0xab, 0x84, 0x85, 0xca, 0x04, 0x65,
-	0x16, 0x1a, 0xd9, 0x70, 0xba, 0x4a, 0x02, 0x9f, 0xb9, 0x69, 0x22, 0x68, 0x20, 0x70, 0x4b, 0x5d,
-	0xb1, 0xc4, 0x64, 0x4b, 0x4c, 0x6f, 0xf0, 0xa9, 0xfa, 0xb2, 0x32, 0xbd, 0x91, 0x79, 0xba, 0x2b,
-	0xcf, 0x5d, 0x3a, 0xb8, 0xad, 0x58, 0xae, 0x64, 0x9e, 0x1a, 0x77, 0x14, 0xae, 0x15, 0xd4, 0x23,
-	0x92, 0x76, 0x35, 0x55, 0x02, 0x0d, 0x00, 0xe6, 0x9f, 0x03, 0xb6, 0x09, 0xe9, 0x24, 0xde, 0xe2,
-	0x9e, 0xb2, 0x8e, 0x88, 0xf4, 0x97, 0x71, 0xe1, 0x9f, 0x69, 0xff, 0x40, 0x8e, 0x7d, 0xc6, 0x30,
-	0x2a, 0xfb, 0x8c, 0xd9, 0x57, 0x60, 0xa9, 0xff, 0x5a, 0x76, 0xca, 0x53, 0xa8, 0xae, 0x3c, 0x77,
-	0xdf, 0x25, 0xbd, 0x52, 0x97, 0xc8, 0x20, 0xe5, 0x5e, 0x7f, 0xad, 0x80, 0xe9, 0xce, 0xe6, 0x68,
-	0x09, 0xad, 0x05, 0x15, 0xfb, 0x5e, 0x43, 0x97, 0x7f, 0x6b, 0xaa, 0xfd, 0x9b, 0xea, 0xf7, 0x1f,
-	0x71, 0x39, 0xdb, 0xda, 0x27, 0xe8, 0x25, 0x34, 0x16, 0x54, 0xc8, 0xdd, 0xd1, 0x93, 0x07, 0xa7,
-	0xe6, 0x3b, 0xfc, 0xf7, 0xd0, 0xd0, 0x1f, 0x3b, 0xd0, 0xc8, 0x9f, 0x34, 0xba, 0x28, 0x9f, 0x52,
-	0x7a, 0xfa, 0xf7, 0xaf, 0x50, 0x9a, 0x02, 0x27, 0x68, 0x01, 0xd6, 0x7e, 0x78, 0xdc, 0x4f, 0xa5,
-	0x3c, 0x66, 0xfa, 0x17, 0x8f, 0xb8, 0x7a, 0xa3, 0xe9, 0xf8, 0xc7, 0x6e, 0x60, 0xfc, 0xdc, 0x0d,
-	0x8c, 0x5f, 0xbb, 0x81, 0xf1, 0xe5, 0xf7, 0xe0, 0x04, 0x9a, 0x3c, 0xa0, 0x7a, 0x84, 0x4d, 0x2d,
-	0x77, 0x36, 0x97, 0xdd, 0x92, 0xb8, 0xc6, 0x87, 0x9a, 0x42, 0x1f, 0xeb, 0xea, 0xe7, 0xc5, 0x9f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xc1, 0x19, 0xf4, 0x9d, 0xec, 0x04, 0x00, 0x00,
+func (m *SRHop) Reset()         { *m = SRHop{} }
+func (m *SRHop) String() string { return proto.CompactTextString(m) }
+func (*SRHop) ProtoMessage()    {}
+func (*SRHop) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{13}
 }
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// PCEClient is the client API for PCE service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PCEClient interface {
-	GetSessions(ctx context.Context, in *SessionsRequest, opts ...grpc.CallOption) (*SessionsReply, error)
-	GetLSPs(ctx context.Context, in *LSPRequest, opts ...grpc.CallOption) (*LSPReply, error)
-	StopBGP(ctx context.Context, in *StopBGPRequest, opts ...grpc.CallOption) (*StopBGPReplay, error)
-	StartBGP(ctx context.Context, in *StartBGPRequest, opts ...grpc.CallOption) (*StartBGPReplay, error)
+func (m *SRHop) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-type pCEClient struct {
-	cc *grpc.ClientConn
+func (m *SRHop) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SRHop.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-
-func NewPCEClient(cc *grpc.ClientConn) PCEClient {
-	return &pCEClient{cc}
+func (m *SRHop) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SRHop.Merge(m, src)
+}
+func (m *SRHop) XXX_Size() int {
+	return m.Size()
+}
+func (m *SRHop) XXX_DiscardUnknown() {
+	xxx_messageInfo_SRHop.DiscardUnknown(m)
 }
 
-func (c *pCEClient) GetSessions(ctx context.Context, in *SessionsRequest, opts ...grpc.CallOption) (*SessionsReply, error) {
-	out := new(SessionsReply)
-	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/GetSessions", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SRHop proto.InternalMessageInfo
+
+func (m *SRHop) GetSID() uint32 {
+	if m != nil {
+		return m.SID
 	}
-	return out, nil
+	return 0
 }
 
-func (c *pCEClient) GetLSPs(ctx context.Context, in *LSPRequest, opts ...grpc.CallOption) (*LSPReply, error) {
-	out := new(LSPReply)
-	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/GetLSPs", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SRHop) GetIPv4NodeID() string {
+	if m != nil {
+		return m.IPv4NodeID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *pCEClient) StopBGP(ctx context.Context, in *StopBGPRequest, opts ...grpc.CallOption) (*StopBGPReplay, error) {
-	out := new(StopBGPReplay)
-	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/StopBGP", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SRHop) GetIPv6NodeID() string {
+	if m != nil {
+		return m.IPv6NodeID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *pCEClient) StartBGP(ctx context.Context, in *StartBGPRequest, opts ...grpc.CallOption) (*StartBGPReplay, error) {
-	out := new(StartBGPReplay)
-	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/StartBGP", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// Candidate path of an SR Policy, the path is computed
+// by the controller when it has no hops
+type CandidatePath struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Preference           uint32   `protobuf:"varint,2,opt,name=Preference,proto3" json:"Preference,omitempty"`
+	Discriminator        uint32   `protobuf:"varint,3,opt,name=Discriminator,proto3" json:"Discriminator,omitempty"`
+	BW                   uint32   `protobuf:"varint,4,opt,name=BW,proto3" json:"BW,omitempty"`
+	Hops                 []*SRHop `protobuf:"bytes,5,rep,name=Hops,proto3" json:"Hops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// PCEServer is the server API for PCE service.
-type PCEServer interface {
-	GetSessions(context.Context, *SessionsRequest) (*SessionsReply, error)
-	GetLSPs(context.Context, *LSPRequest) (*LSPReply, error)
-	StopBGP(context.Context, *StopBGPRequest) (*StopBGPReplay, error)
-	StartBGP(context.Context, *StartBGPRequest) (*StartBGPReplay, error)
+func (m *CandidatePath) Reset()         { *m = CandidatePath{} }
+func (m *CandidatePath) String() string { return proto.CompactTextString(m) }
+func (*CandidatePath) ProtoMessage()    {}
+func (*CandidatePath) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{14}
 }
-
-// UnimplementedPCEServer can be embedded to have forward compatible implementations.
-type UnimplementedPCEServer struct {
+func (m *CandidatePath) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (*UnimplementedPCEServer) GetSessions(ctx context.Context, req *SessionsRequest) (*SessionsReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSessions not implemented")
+func (m *CandidatePath) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CandidatePath.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedPCEServer) GetLSPs(ctx context.Context, req *LSPRequest) (*LSPReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetLSPs not implemented")
+func (m *CandidatePath) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CandidatePath.Merge(m, src)
 }
-func (*UnimplementedPCEServer) StopBGP(ctx context.Context, req *StopBGPRequest) (*StopBGPReplay, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StopBGP not implemented")
+func (m *CandidatePath) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedPCEServer) StartBGP(ctx context.Context, req *StartBGPRequest) (*StartBGPReplay, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StartBGP not implemented")
+func (m *CandidatePath) XXX_DiscardUnknown() {
+	xxx_messageInfo_CandidatePath.DiscardUnknown(m)
 }
 
-func RegisterPCEServer(s *grpc.Server, srv PCEServer) {
-	s.RegisterService(&_PCE_serviceDesc, srv)
-}
+var xxx_messageInfo_CandidatePath proto.InternalMessageInfo
 
-func _PCE_GetSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SessionsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PCEServer).GetSessions(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pceapiproto.PCE/GetSessions",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PCEServer).GetSessions(ctx, req.(*SessionsRequest))
+func (m *CandidatePath) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _PCE_GetLSPs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LSPRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PCEServer).GetLSPs(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pceapiproto.PCE/GetLSPs",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PCEServer).GetLSPs(ctx, req.(*LSPRequest))
+func (m *CandidatePath) GetPreference() uint32 {
+	if m != nil {
+		return m.Preference
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _PCE_StopBGP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StopBGPRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PCEServer).StopBGP(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pceapiproto.PCE/StopBGP",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PCEServer).StopBGP(ctx, req.(*StopBGPRequest))
+func (m *CandidatePath) GetDiscriminator() uint32 {
+	if m != nil {
+		return m.Discriminator
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _PCE_StartBGP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StartBGPRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PCEServer).StartBGP(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pceapiproto.PCE/StartBGP",
+func (m *CandidatePath) GetBW() uint32 {
+	if m != nil {
+		return m.BW
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PCEServer).StartBGP(ctx, req.(*StartBGPRequest))
+	return 0
+}
+
+func (m *CandidatePath) GetHops() []*SRHop {
+	if m != nil {
+		return m.Hops
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _PCE_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pceapiproto.PCE",
-	HandlerType: (*PCEServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetSessions",
-			Handler:    _PCE_GetSessions_Handler,
-		},
-		{
-			MethodName: "GetLSPs",
-			Handler:    _PCE_GetLSPs_Handler,
-		},
-		{
-			MethodName: "StopBGP",
-			Handler:    _PCE_StopBGP_Handler,
-		},
-		{
-			MethodName: "StartBGP",
-			Handler:    _PCE_StartBGP_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "pceapi.proto",
+// SR Policy https://tools.ietf.org/html/draft-ietf-pce-segment-routing-policy-cp
+type SRPolicy struct {
+	Name                 string           `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	HeadEnd              string           `protobuf:"bytes,2,opt,name=HeadEnd,proto3" json:"HeadEnd,omitempty"`
+	Color                uint32           `protobuf:"varint,3,opt,name=Color,proto3" json:"Color,omitempty"`
+	Endpoint             string           `protobuf:"bytes,4,opt,name=Endpoint,proto3" json:"Endpoint,omitempty"`
+	AssociationID        uint32           `protobuf:"varint,5,opt,name=AssociationID,proto3" json:"AssociationID,omitempty"`
+	CandidatePaths       []*CandidatePath `protobuf:"bytes,6,rep,name=CandidatePaths,proto3" json:"CandidatePaths,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *StartBGPRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SRPolicy) Reset()         { *m = SRPolicy{} }
+func (m *SRPolicy) String() string { return proto.CompactTextString(m) }
+func (*SRPolicy) ProtoMessage()    {}
+func (*SRPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{15}
+}
+func (m *SRPolicy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SRPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SRPolicy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *StartBGPRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SRPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SRPolicy.Merge(m, src)
+}
+func (m *SRPolicy) XXX_Size() int {
+	return m.Size()
+}
+func (m *SRPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_SRPolicy.DiscardUnknown(m)
 }
 
-func (m *StartBGPRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_SRPolicy proto.InternalMessageInfo
+
+func (m *SRPolicy) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *StartBGPReplay) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SRPolicy) GetHeadEnd() string {
+	if m != nil {
+		return m.HeadEnd
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *StartBGPReplay) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SRPolicy) GetColor() uint32 {
+	if m != nil {
+		return m.Color
+	}
+	return 0
 }
 
-func (m *StartBGPReplay) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *SRPolicy) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *StopBGPRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SRPolicy) GetAssociationID() uint32 {
+	if m != nil {
+		return m.AssociationID
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *StopBGPRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SRPolicy) GetCandidatePaths() []*CandidatePath {
+	if m != nil {
+		return m.CandidatePaths
+	}
+	return nil
 }
 
-func (m *StopBGPRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return len(dAtA) - i, nil
+type SRPoliciesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StopBGPReplay) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SRPoliciesRequest) Reset()         { *m = SRPoliciesRequest{} }
+func (m *SRPoliciesRequest) String() string { return proto.CompactTextString(m) }
+func (*SRPoliciesRequest) ProtoMessage()    {}
+func (*SRPoliciesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{16}
+}
+func (m *SRPoliciesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SRPoliciesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SRPoliciesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *StopBGPReplay) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SRPoliciesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SRPoliciesRequest.Merge(m, src)
+}
+func (m *SRPoliciesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SRPoliciesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SRPoliciesRequest.DiscardUnknown(m)
 }
 
-func (m *StopBGPReplay) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return len(dAtA) - i, nil
+var xxx_messageInfo_SRPoliciesRequest proto.InternalMessageInfo
+
+type SRPoliciesReply struct {
+	SRPolicies           []*SRPolicy `protobuf:"bytes,1,rep,name=SRPolicies,proto3" json:"SRPolicies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *SessionsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SRPoliciesReply) Reset()         { *m = SRPoliciesReply{} }
+func (m *SRPoliciesReply) String() string { return proto.CompactTextString(m) }
+func (*SRPoliciesReply) ProtoMessage()    {}
+func (*SRPoliciesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{17}
+}
+func (m *SRPoliciesReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SRPoliciesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SRPoliciesReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *SessionsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SRPoliciesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SRPoliciesReply.Merge(m, src)
+}
+func (m *SRPoliciesReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *SRPoliciesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SRPoliciesReply.DiscardUnknown(m)
 }
 
-func (m *SessionsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_SRPoliciesReply proto.InternalMessageInfo
+
+func (m *SRPoliciesReply) GetSRPolicies() []*SRPolicy {
+	if m != nil {
+		return m.SRPolicies
 	}
-	if len(m.PccName) > 0 {
-		i -= len(m.PccName)
-		copy(dAtA[i:], m.PccName)
-		i = encodeVarintPceapi(dAtA, i, uint64(len(m.PccName)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+type DelSRPolicyRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DelSRPolicyRequest) Reset()         { *m = DelSRPolicyRequest{} }
+func (m *DelSRPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*DelSRPolicyRequest) ProtoMessage()    {}
+func (*DelSRPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{18}
+}
+func (m *DelSRPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DelSRPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DelSRPolicyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *DelSRPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelSRPolicyRequest.Merge(m, src)
+}
+func (m *DelSRPolicyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DelSRPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelSRPolicyRequest.DiscardUnknown(m)
 }
 
-func (m *Session) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DelSRPolicyRequest proto.InternalMessageInfo
+
+func (m *DelSRPolicyRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Session) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type DelSRPolicyReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Session) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.DeadTimer != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.DeadTimer))
-		i--
-		dAtA[i] = 0x30
-	}
-	if m.Keepalive != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.Keepalive))
-		i--
-		dAtA[i] = 0x28
-	}
-	if len(m.Address) > 0 {
-		i -= len(m.Address)
-		copy(dAtA[i:], m.Address)
-		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Address)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.State != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.State))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.MsgCount != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.MsgCount))
-		i--
-		dAtA[i] = 0x10
+func (m *DelSRPolicyReply) Reset()         { *m = DelSRPolicyReply{} }
+func (m *DelSRPolicyReply) String() string { return proto.CompactTextString(m) }
+func (*DelSRPolicyReply) ProtoMessage()    {}
+func (*DelSRPolicyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{19}
+}
+func (m *DelSRPolicyReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DelSRPolicyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DelSRPolicyReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintPceapi(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *DelSRPolicyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelSRPolicyReply.Merge(m, src)
+}
+func (m *DelSRPolicyReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *DelSRPolicyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelSRPolicyReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DelSRPolicyReply proto.InternalMessageInfo
+
+// SR LSP as stored in the controller, SkipReason tells why
+// it was not pushed to the PCC based on its capabilities
+type SRLSP struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Src                  string   `protobuf:"bytes,2,opt,name=Src,proto3" json:"Src,omitempty"`
+	Dst                  string   `protobuf:"bytes,3,opt,name=Dst,proto3" json:"Dst,omitempty"`
+	PST                  uint32   `protobuf:"varint,4,opt,name=PST,proto3" json:"PST,omitempty"`
+	BW                   uint32   `protobuf:"varint,5,opt,name=BW,proto3" json:"BW,omitempty"`
+	Delegate             bool     `protobuf:"varint,6,opt,name=Delegate,proto3" json:"Delegate,omitempty"`
+	Admin                bool     `protobuf:"varint,7,opt,name=Admin,proto3" json:"Admin,omitempty"`
+	Hops                 []*SRHop `protobuf:"bytes,8,rep,name=Hops,proto3" json:"Hops,omitempty"`
+	BindingSID           uint32   `protobuf:"varint,9,opt,name=BindingSID,proto3" json:"BindingSID,omitempty"`
+	SkipReason           string   `protobuf:"bytes,10,opt,name=SkipReason,proto3" json:"SkipReason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SRLSP) Reset()         { *m = SRLSP{} }
+func (m *SRLSP) String() string { return proto.CompactTextString(m) }
+func (*SRLSP) ProtoMessage()    {}
+func (*SRLSP) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{20}
+}
+func (m *SRLSP) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SRLSP) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SRLSP.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SRLSP) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SRLSP.Merge(m, src)
+}
+func (m *SRLSP) XXX_Size() int {
+	return m.Size()
+}
+func (m *SRLSP) XXX_DiscardUnknown() {
+	xxx_messageInfo_SRLSP.DiscardUnknown(m)
 }
 
-func (m *SessionsReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SRLSP proto.InternalMessageInfo
+
+func (m *SRLSP) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SessionsReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SRLSP) GetSrc() string {
+	if m != nil {
+		return m.Src
+	}
+	return ""
 }
 
-func (m *SessionsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *SRLSP) GetDst() string {
+	if m != nil {
+		return m.Dst
 	}
-	if len(m.Sessions) > 0 {
-		for iNdEx := len(m.Sessions) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Sessions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPceapi(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	return ""
+}
+
+func (m *SRLSP) GetPST() uint32 {
+	if m != nil {
+		return m.PST
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *LSPRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SRLSP) GetBW() uint32 {
+	if m != nil {
+		return m.BW
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *LSPRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SRLSP) GetDelegate() bool {
+	if m != nil {
+		return m.Delegate
+	}
+	return false
 }
 
-func (m *LSPRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *SRLSP) GetAdmin() bool {
+	if m != nil {
+		return m.Admin
 	}
-	if len(m.PccName) > 0 {
-		i -= len(m.PccName)
-		copy(dAtA[i:], m.PccName)
-		i = encodeVarintPceapi(dAtA, i, uint64(len(m.PccName)))
-		i--
-		dAtA[i] = 0xa
+	return false
+}
+
+func (m *SRLSP) GetHops() []*SRHop {
+	if m != nil {
+		return m.Hops
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *LSP) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SRLSP) GetBindingSID() uint32 {
+	if m != nil {
+		return m.BindingSID
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *LSP) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SRLSP) GetSkipReason() string {
+	if m != nil {
+		return m.SkipReason
+	}
+	return ""
 }
 
-func (m *LSP) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.IncludeAll != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.IncludeAll))
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x90
-	}
-	if m.IncludeAny != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.IncludeAny))
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x88
-	}
-	if m.ExcludeAny != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.ExcludeAny))
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x80
-	}
-	if m.SRPID != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.SRPID))
-		i--
-		dAtA[i] = 0x78
-	}
-	if m.LSPID != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.LSPID))
-		i--
-		dAtA[i] = 0x70
-	}
-	if m.PLSPID != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.PLSPID))
-		i--
-		dAtA[i] = 0x68
-	}
-	if m.BW != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.BW))
-		i--
-		dAtA[i] = 0x60
-	}
-	if m.LocalProtect {
-		i--
-		if m.LocalProtect {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x58
-	}
-	if m.HoldPrio != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.HoldPrio))
-		i--
-		dAtA[i] = 0x50
-	}
-	if m.SetupPrio != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.SetupPrio))
-		i--
-		dAtA[i] = 0x48
-	}
-	if len(m.Dst) > 0 {
-		i -= len(m.Dst)
-		copy(dAtA[i:], m.Dst)
-		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Dst)))
-		i--
-		dAtA[i] = 0x42
-	}
-	if len(m.Src) > 0 {
-		i -= len(m.Src)
-		copy(dAtA[i:], m.Src)
-		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Src)))
-		i--
-		dAtA[i] = 0x3a
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if m.Oper != 0 {
-		i = encodeVarintPceapi(dAtA, i, uint64(m.Oper))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.Admin {
-		i--
-		if m.Admin {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Remove {
-		i--
-		if m.Remove {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Sync {
-		i--
-		if m.Sync {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Delegate {
-		i--
-		if m.Delegate {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+type SRLSPRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LSPReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *SRLSPRequest) Reset()         { *m = SRLSPRequest{} }
+func (m *SRLSPRequest) String() string { return proto.CompactTextString(m) }
+func (*SRLSPRequest) ProtoMessage()    {}
+func (*SRLSPRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{21}
 }
-
-func (m *LSPReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SRLSPRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *LSPReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.LSPs) > 0 {
-		for iNdEx := len(m.LSPs) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.LSPs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPceapi(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *SRLSPRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SRLSPRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func encodeVarintPceapi(dAtA []byte, offset int, v uint64) int {
-	offset -= sovPceapi(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (m *SRLSPRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SRLSPRequest.Merge(m, src)
 }
-func (m *StartBGPRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *SRLSPRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SRLSPRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SRLSPRequest.DiscardUnknown(m)
 }
 
-func (m *StartBGPReplay) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+var xxx_messageInfo_SRLSPRequest proto.InternalMessageInfo
+
+type SRLSPReply struct {
+	SRLSPs               []*SRLSP `protobuf:"bytes,1,rep,name=SRLSPs,proto3" json:"SRLSPs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StopBGPRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *SRLSPReply) Reset()         { *m = SRLSPReply{} }
+func (m *SRLSPReply) String() string { return proto.CompactTextString(m) }
+func (*SRLSPReply) ProtoMessage()    {}
+func (*SRLSPReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_614bac86d996c9a3, []int{22}
+}
+func (m *SRLSPReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SRLSPReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SRLSPReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *SRLSPReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SRLSPReply.Merge(m, src)
+}
+func (m *SRLSPReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *SRLSPReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SRLSPReply.DiscardUnknown(m)
 }
 
-func (m *StopBGPReplay) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_SRLSPReply proto.InternalMessageInfo
+
+func (m *SRLSPReply) GetSRLSPs() []*SRLSP {
+	if m != nil {
+		return m.SRLSPs
 	}
-	return n
+	return nil
 }
 
-func (m *SessionsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.PccName)
-	if l > 0 {
-		n += 1 + l + sovPceapi(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func init() {
+	proto.RegisterEnum("pceapiproto.SessionState", SessionState_name, SessionState_value)
+	proto.RegisterType((*StartBGPRequest)(nil), "pceapiproto.StartBGPRequest")
+	proto.RegisterType((*StartBGPReplay)(nil), "pceapiproto.StartBGPReplay")
+	proto.RegisterType((*StopBGPRequest)(nil), "pceapiproto.StopBGPRequest")
+	proto.RegisterType((*StopBGPReplay)(nil), "pceapiproto.StopBGPReplay")
+	proto.RegisterType((*SessionsRequest)(nil), "pceapiproto.SessionsRequest")
+	proto.RegisterType((*Session)(nil), "pceapiproto.Session")
+	proto.RegisterType((*AuthStatsRequest)(nil), "pceapiproto.AuthStatsRequest")
+	proto.RegisterType((*AuthStatsReply)(nil), "pceapiproto.AuthStatsReply")
+	proto.RegisterType((*SessionsReply)(nil), "pceapiproto.SessionsReply")
+	proto.RegisterType((*LSPRequest)(nil), "pceapiproto.LSPRequest")
+	proto.RegisterType((*LSP)(nil), "pceapiproto.LSP")
+	proto.RegisterType((*Metric)(nil), "pceapiproto.Metric")
+	proto.RegisterType((*LSPReply)(nil), "pceapiproto.LSPReply")
+	proto.RegisterType((*SRHop)(nil), "pceapiproto.SRHop")
+	proto.RegisterType((*CandidatePath)(nil), "pceapiproto.CandidatePath")
+	proto.RegisterType((*SRPolicy)(nil), "pceapiproto.SRPolicy")
+	proto.RegisterType((*SRPoliciesRequest)(nil), "pceapiproto.SRPoliciesRequest")
+	proto.RegisterType((*SRPoliciesReply)(nil), "pceapiproto.SRPoliciesReply")
+	proto.RegisterType((*DelSRPolicyRequest)(nil), "pceapiproto.DelSRPolicyRequest")
+	proto.RegisterType((*DelSRPolicyReply)(nil), "pceapiproto.DelSRPolicyReply")
+	proto.RegisterType((*SRLSP)(nil), "pceapiproto.SRLSP")
+	proto.RegisterType((*SRLSPRequest)(nil), "pceapiproto.SRLSPRequest")
+	proto.RegisterType((*SRLSPReply)(nil), "pceapiproto.SRLSPReply")
+}
+
+func init() { proto.RegisterFile("pceapi.proto", fileDescriptor_614bac86d996c9a3) }
+
+var fileDescriptor_614bac86d996c9a3 = []byte{
+	// 1315 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x57, 0xcd, 0x6e, 0xdb, 0xc6,
+	0x16, 0x36, 0xf5, 0x4b, 0x1d, 0x4b, 0xb2, 0x3c, 0x49, 0x6e, 0x18, 0x25, 0xd1, 0x15, 0x88, 0x20,
+	0x10, 0x72, 0x71, 0x7d, 0x83, 0xdc, 0xba, 0x28, 0xd0, 0x95, 0x24, 0x3a, 0xb6, 0x10, 0x39, 0x26,
+	0x86, 0x4e, 0x83, 0x76, 0x51, 0x80, 0x25, 0xa7, 0x09, 0x51, 0x9a, 0x64, 0x49, 0x2a, 0x88, 0xde,
+	0xa3, 0x8b, 0x6e, 0xfb, 0x36, 0x5d, 0x15, 0x5d, 0xf4, 0x01, 0x8a, 0x74, 0xd7, 0x55, 0xf7, 0xdd,
+	0x14, 0xe7, 0xcc, 0x88, 0x26, 0x6d, 0xb9, 0x5d, 0x79, 0xce, 0xf7, 0x1d, 0xce, 0x9c, 0x9f, 0x6f,
+	0x8e, 0xc6, 0xd0, 0x4d, 0x3c, 0xe1, 0x26, 0xc1, 0x41, 0x92, 0xc6, 0x79, 0xcc, 0x76, 0xa5, 0x45,
+	0x86, 0xb9, 0x0f, 0x7b, 0x4e, 0xee, 0xa6, 0xf9, 0xec, 0xd8, 0xe6, 0xe2, 0xdb, 0x95, 0xc8, 0x72,
+	0x73, 0x00, 0xfd, 0x4b, 0x28, 0x09, 0xdd, 0xb5, 0x44, 0xe2, 0xa4, 0xe4, 0xb3, 0x07, 0xbd, 0x02,
+	0x21, 0x97, 0xff, 0xc0, 0x9e, 0x23, 0xb2, 0x2c, 0x88, 0xa3, 0x4c, 0xf9, 0x30, 0x03, 0xda, 0x89,
+	0xe7, 0xbd, 0x74, 0x2f, 0x84, 0xa1, 0x8d, 0xb5, 0x49, 0x87, 0x6f, 0x4c, 0xf3, 0x27, 0x0d, 0xda,
+	0xca, 0x9b, 0xf5, 0xa1, 0xb6, 0xb0, 0x94, 0x43, 0x6d, 0x61, 0xb1, 0x21, 0xe8, 0xa7, 0xd9, 0x9b,
+	0x79, 0xbc, 0x8a, 0x72, 0xa3, 0x36, 0xd6, 0x26, 0x0d, 0x5e, 0xd8, 0xec, 0x7f, 0xd0, 0x74, 0x72,
+	0x37, 0x17, 0x46, 0x7d, 0xac, 0x4d, 0xfa, 0xcf, 0xee, 0x1d, 0x94, 0x32, 0x39, 0x50, 0x1b, 0x92,
+	0x03, 0x97, 0x7e, 0x18, 0x82, 0xeb, 0xfb, 0xa9, 0xc8, 0x32, 0xa3, 0x21, 0x43, 0x50, 0x26, 0x7b,
+	0x00, 0x9d, 0x17, 0x42, 0x24, 0x6e, 0x18, 0xbc, 0x13, 0x46, 0x73, 0xac, 0x4d, 0x7a, 0xfc, 0x12,
+	0x40, 0xd6, 0x12, 0xae, 0x7f, 0x1e, 0x5c, 0x88, 0xd4, 0x68, 0x49, 0xb6, 0x00, 0xd8, 0x00, 0xea,
+	0xe7, 0x4b, 0xc7, 0x68, 0x8f, 0xb5, 0x89, 0xce, 0x71, 0x69, 0x32, 0x18, 0x4c, 0x57, 0xf9, 0x5b,
+	0x3c, 0x74, 0x93, 0xbe, 0xf9, 0xbb, 0x06, 0xfd, 0x12, 0x98, 0x84, 0x6b, 0x36, 0x86, 0xdd, 0x53,
+	0xeb, 0xf0, 0x65, 0x9c, 0x3f, 0x8f, 0x57, 0x91, 0x4f, 0x49, 0x37, 0x78, 0x19, 0x62, 0x8f, 0xa0,
+	0x77, 0x6a, 0x1d, 0xbe, 0x8a, 0xc4, 0xfb, 0x44, 0x78, 0xb9, 0xf0, 0x55, 0x09, 0xaa, 0x20, 0x1b,
+	0x01, 0x9c, 0x5a, 0x87, 0xcf, 0xdd, 0x20, 0x5c, 0xa5, 0xb2, 0x18, 0x0d, 0x5e, 0x42, 0x90, 0x9f,
+	0x9e, 0x61, 0x1c, 0x41, 0x2a, 0x7c, 0xca, 0xbc, 0xc1, 0x4b, 0x08, 0xbb, 0x0d, 0xcd, 0xe9, 0xd9,
+	0xcc, 0xf5, 0x29, 0xf1, 0x06, 0x97, 0x06, 0x9e, 0x3d, 0x3d, 0x7b, 0x21, 0xd6, 0x45, 0x7c, 0x2d,
+	0x79, 0x76, 0x05, 0xc4, 0x6f, 0x1d, 0x2f, 0x4e, 0x04, 0xa5, 0xdf, 0xe1, 0xd2, 0x30, 0xa7, 0xd0,
+	0xbb, 0x6c, 0x3f, 0xa6, 0xfa, 0x14, 0xf4, 0x4c, 0x01, 0x86, 0x36, 0xae, 0x4f, 0x76, 0x9f, 0xdd,
+	0xde, 0xd6, 0x2d, 0x5e, 0x78, 0x99, 0x8f, 0x01, 0x96, 0x8e, 0xfd, 0xcf, 0xe2, 0xf9, 0xa3, 0x0e,
+	0xf5, 0xa5, 0x63, 0xa3, 0x50, 0x2c, 0x11, 0x8a, 0x37, 0x6e, 0x2e, 0x5d, 0x74, 0x5e, 0xd8, 0x8c,
+	0x41, 0xc3, 0x59, 0x47, 0x1e, 0x55, 0x4f, 0xe7, 0xb4, 0x66, 0xff, 0x82, 0x16, 0x17, 0x17, 0xf1,
+	0x3b, 0x59, 0x30, 0x9d, 0x2b, 0x8b, 0x8a, 0xe1, 0x5f, 0x04, 0x11, 0xd5, 0x49, 0xe7, 0xd2, 0xc0,
+	0x1d, 0xce, 0x12, 0x91, 0x2a, 0x69, 0xd0, 0x1a, 0x31, 0x0a, 0xa8, 0x45, 0x01, 0xd1, 0x1a, 0xb5,
+	0xe0, 0xa4, 0x9e, 0x2a, 0x06, 0x2e, 0x11, 0xb1, 0xb2, 0xdc, 0xd0, 0x25, 0x62, 0x65, 0x39, 0xaa,
+	0xc9, 0x11, 0xf9, 0x2a, 0xb1, 0xd3, 0x20, 0x36, 0x3a, 0x52, 0x4d, 0x05, 0x80, 0x79, 0x9c, 0xc4,
+	0xa1, 0x4f, 0x24, 0x10, 0x59, 0xd8, 0xcc, 0x84, 0xee, 0x32, 0xf6, 0xdc, 0xd0, 0x4e, 0xe3, 0x5c,
+	0x78, 0xb9, 0xb1, 0x4b, 0x21, 0x56, 0x30, 0xbc, 0x40, 0xb3, 0xd7, 0x46, 0x97, 0xbe, 0xac, 0xcd,
+	0x5e, 0x63, 0x9e, 0xf6, 0xd2, 0xb1, 0x17, 0x96, 0xd1, 0x23, 0x4c, 0x59, 0x98, 0xa7, 0x84, 0xfb,
+	0x04, 0x37, 0x0b, 0xd4, 0xe1, 0x88, 0xee, 0x49, 0x94, 0x0c, 0x14, 0xd0, 0xd1, 0x7b, 0x2f, 0x5c,
+	0xf9, 0x62, 0x1a, 0xad, 0x8d, 0x01, 0x51, 0x25, 0x04, 0xf9, 0x45, 0x54, 0xf0, 0xfb, 0x92, 0x5f,
+	0x44, 0xdb, 0xf8, 0x30, 0x34, 0x58, 0x95, 0x0f, 0x43, 0xf6, 0x5f, 0x68, 0x9f, 0x8a, 0x3c, 0x0d,
+	0xbc, 0xcc, 0xb8, 0x45, 0xe2, 0xb8, 0x55, 0x11, 0x87, 0xe4, 0xf8, 0xc6, 0xc7, 0xf4, 0xa1, 0x25,
+	0x97, 0xd8, 0x82, 0xf3, 0x75, 0x22, 0x1b, 0xde, 0xe3, 0xb4, 0xc6, 0x14, 0x66, 0xa4, 0x57, 0xd9,
+	0x6d, 0x69, 0x60, 0x59, 0xe7, 0xf1, 0x45, 0xb2, 0xc2, 0x4b, 0x24, 0x1b, 0x5e, 0xd8, 0xf8, 0xc5,
+	0x67, 0x6e, 0xb8, 0x12, 0xd4, 0xf2, 0x1a, 0x97, 0x86, 0xf9, 0x14, 0x74, 0x12, 0x20, 0xca, 0xf7,
+	0x11, 0x34, 0x96, 0x8e, 0xbd, 0x91, 0xee, 0xa0, 0x12, 0x1d, 0x3a, 0x11, 0x6b, 0x7e, 0x8e, 0xc5,
+	0x3b, 0x89, 0x13, 0x52, 0x81, 0x9a, 0x62, 0x3d, 0x8e, 0x4b, 0xaa, 0x80, 0xfd, 0xee, 0xa3, 0x97,
+	0xb1, 0x2f, 0x16, 0x16, 0x45, 0xd6, 0xe1, 0x25, 0x44, 0xf1, 0x1f, 0x2b, 0xbe, 0x5e, 0xf0, 0x0a,
+	0x31, 0x7f, 0xd0, 0xa0, 0x37, 0x77, 0x23, 0x3f, 0xf0, 0xdd, 0x5c, 0xd8, 0x6e, 0xfe, 0xb6, 0x50,
+	0x9f, 0x56, 0x52, 0xdf, 0x08, 0xc0, 0x4e, 0xc5, 0xd7, 0x22, 0x15, 0x91, 0x27, 0xe8, 0x94, 0x1e,
+	0x2f, 0x21, 0x78, 0xa5, 0xad, 0x20, 0xf3, 0xd2, 0xe0, 0x22, 0x88, 0xdc, 0x3c, 0x4e, 0xe9, 0xa0,
+	0x1e, 0xaf, 0x82, 0x4a, 0x41, 0x8d, 0x42, 0x41, 0x8f, 0xa1, 0x71, 0x12, 0x27, 0x99, 0xd1, 0xa4,
+	0xe4, 0x59, 0xf5, 0xde, 0x62, 0xbe, 0x9c, 0x78, 0xf3, 0x17, 0x0d, 0x74, 0x87, 0xdb, 0x71, 0x18,
+	0x78, 0xeb, 0xad, 0xe1, 0x19, 0xd0, 0x3e, 0x11, 0xae, 0x7f, 0xa4, 0x7a, 0xd3, 0xe1, 0x1b, 0x13,
+	0x3b, 0x30, 0x8f, 0xc3, 0x22, 0x20, 0x69, 0x60, 0xcf, 0x8e, 0x22, 0x3f, 0x89, 0x83, 0x28, 0x57,
+	0xf3, 0xba, 0xb0, 0x69, 0x3a, 0x65, 0x59, 0xec, 0x05, 0x6e, 0x1e, 0xc4, 0xd1, 0xc2, 0x52, 0x37,
+	0xb3, 0x0a, 0xb2, 0x19, 0xf4, 0x2b, 0x55, 0xcb, 0x8c, 0x16, 0x25, 0x31, 0xac, 0x24, 0x51, 0x71,
+	0xe1, 0x57, 0xbe, 0x30, 0x6f, 0xc1, 0xbe, 0xca, 0x2a, 0x10, 0xc5, 0x34, 0x3f, 0x81, 0xbd, 0x32,
+	0x88, 0x1a, 0x39, 0x04, 0xb8, 0x84, 0x94, 0x52, 0xee, 0x5c, 0x29, 0x96, 0x2c, 0x0e, 0x2f, 0x39,
+	0x9a, 0x13, 0x60, 0x96, 0x08, 0x0b, 0x4a, 0xcd, 0xbb, 0x2d, 0xe5, 0xc3, 0x5f, 0x95, 0x8a, 0x67,
+	0x12, 0xae, 0xcd, 0x3f, 0x35, 0xd4, 0x1c, 0xce, 0xbf, 0x6d, 0x05, 0x57, 0xd3, 0xa8, 0x76, 0x6d,
+	0x1a, 0xd5, 0x2f, 0xa7, 0xd1, 0x00, 0xea, 0xb6, 0x73, 0xae, 0xda, 0x8d, 0x4b, 0xd5, 0xff, 0x66,
+	0xd1, 0xff, 0xf2, 0x64, 0x6d, 0x5d, 0x99, 0xac, 0xc5, 0xb4, 0x6c, 0x97, 0xa7, 0xe5, 0x46, 0x31,
+	0xfa, 0xdf, 0x2b, 0x06, 0xf5, 0x3a, 0x0b, 0x22, 0x3f, 0x88, 0xde, 0xe0, 0x75, 0x91, 0xa3, 0xb0,
+	0x84, 0x20, 0xef, 0x7c, 0x13, 0x24, 0x5c, 0xb8, 0x59, 0x1c, 0xd1, 0x34, 0xec, 0xf0, 0x12, 0x62,
+	0xf6, 0xa1, 0x4b, 0xc9, 0x6f, 0xba, 0xf2, 0x09, 0x80, 0xb2, 0xb1, 0x21, 0x4f, 0xa0, 0x45, 0xd6,
+	0xa6, 0x19, 0x57, 0xe3, 0x40, 0x47, 0xe5, 0xf1, 0xe4, 0x4b, 0xe8, 0x96, 0x1f, 0x0c, 0x4c, 0x87,
+	0xc6, 0xc2, 0x0f, 0xc5, 0x60, 0x87, 0xf5, 0x01, 0xce, 0xe7, 0xb6, 0x2d, 0x28, 0xa8, 0x81, 0xc6,
+	0xba, 0xa0, 0x9f, 0x25, 0x22, 0x7a, 0xed, 0x06, 0xf9, 0xa0, 0x86, 0x16, 0x3e, 0x13, 0xc8, 0xaa,
+	0xb3, 0x16, 0xd4, 0x5e, 0x25, 0x83, 0x06, 0x1b, 0x40, 0x97, 0x9e, 0x4c, 0xe7, 0x4b, 0x87, 0x98,
+	0xe6, 0xb3, 0xef, 0x9a, 0x50, 0xb7, 0xe7, 0x47, 0x6c, 0x01, 0xbb, 0xc7, 0x22, 0xdf, 0xfc, 0x36,
+	0xb2, 0x07, 0xdb, 0x7e, 0x04, 0x37, 0x22, 0x1b, 0x0e, 0x6f, 0x60, 0xb1, 0xf1, 0x3b, 0xec, 0x53,
+	0x68, 0x1f, 0x8b, 0x1c, 0xa3, 0x67, 0x77, 0xaf, 0x0d, 0x24, 0xb5, 0xc3, 0x9d, 0xeb, 0x84, 0xfc,
+	0xd8, 0x82, 0xb6, 0x7a, 0xb0, 0xb1, 0xfb, 0xd5, 0x53, 0x2a, 0x0f, 0xbb, 0xe1, 0x70, 0x3b, 0x49,
+	0x6f, 0xbc, 0x1d, 0x76, 0x0c, 0xfa, 0xe6, 0x69, 0x78, 0x35, 0x95, 0xea, 0x23, 0x72, 0x78, 0xff,
+	0x06, 0x56, 0x6d, 0xb4, 0x84, 0xee, 0xb1, 0xc8, 0x8b, 0xe7, 0x11, 0x7b, 0x58, 0x71, 0xbf, 0xfa,
+	0x96, 0x1a, 0xde, 0xbf, 0x89, 0x96, 0xc9, 0x9d, 0x41, 0x0f, 0x8b, 0x5c, 0xdc, 0x31, 0x36, 0xda,
+	0x76, 0x0d, 0x2f, 0x6f, 0xf3, 0xf0, 0xc1, 0x8d, 0xbc, 0xdc, 0x70, 0x0e, 0xfb, 0xf3, 0x54, 0xb8,
+	0xb9, 0x78, 0x95, 0xf8, 0xc5, 0x84, 0xdb, 0x7e, 0xb7, 0x87, 0xdb, 0x61, 0x8a, 0x6a, 0xb7, 0x74,
+	0x7d, 0xd9, 0xbf, 0x2b, 0x7e, 0xd7, 0x47, 0xc0, 0xf0, 0xe1, 0xcd, 0x0e, 0x32, 0xaa, 0x29, 0x74,
+	0x28, 0x4d, 0x92, 0xc0, 0xbd, 0x2d, 0xe2, 0x56, 0x1b, 0xdd, 0xdd, 0x46, 0xd1, 0x16, 0xb3, 0x83,
+	0x1f, 0x3f, 0x8c, 0xb4, 0x9f, 0x3f, 0x8c, 0xb4, 0x5f, 0x3f, 0x8c, 0xb4, 0xef, 0x7f, 0x1b, 0xed,
+	0x40, 0x27, 0xf1, 0x84, 0xfc, 0xc7, 0x60, 0xa6, 0xdb, 0xf3, 0x23, 0x7c, 0x55, 0xc4, 0xb6, 0xf6,
+	0x45, 0x93, 0xa0, 0xaf, 0x5a, 0xf4, 0xe7, 0xff, 0x7f, 0x0d, 0x00, 0x18, 0x8a, 0x17, 0x28, 0x42,
+	0x0c, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PCEClient is the client API for PCE service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PCEClient interface {
+	GetSessions(ctx context.Context, in *SessionsRequest, opts ...grpc.CallOption) (*SessionsReply, error)
+	GetLSPs(ctx context.Context, in *LSPRequest, opts ...grpc.CallOption) (*LSPReply, error)
+	StopBGP(ctx context.Context, in *StopBGPRequest, opts ...grpc.CallOption) (*StopBGPReplay, error)
+	StartBGP(ctx context.Context, in *StartBGPRequest, opts ...grpc.CallOption) (*StartBGPReplay, error)
+	GetAuthStats(ctx context.Context, in *AuthStatsRequest, opts ...grpc.CallOption) (*AuthStatsReply, error)
+	GetSRPolicies(ctx context.Context, in *SRPoliciesRequest, opts ...grpc.CallOption) (*SRPoliciesReply, error)
+	CreateUpdSRPolicy(ctx context.Context, in *SRPolicy, opts ...grpc.CallOption) (*SRPolicy, error)
+	DelSRPolicy(ctx context.Context, in *DelSRPolicyRequest, opts ...grpc.CallOption) (*DelSRPolicyReply, error)
+	GetSRLSPs(ctx context.Context, in *SRLSPRequest, opts ...grpc.CallOption) (*SRLSPReply, error)
+}
+
+type pCEClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPCEClient(cc *grpc.ClientConn) PCEClient {
+	return &pCEClient{cc}
+}
+
+func (c *pCEClient) GetSessions(ctx context.Context, in *SessionsRequest, opts ...grpc.CallOption) (*SessionsReply, error) {
+	out := new(SessionsReply)
+	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/GetSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *Session) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *pCEClient) GetLSPs(ctx context.Context, in *LSPRequest, opts ...grpc.CallOption) (*LSPReply, error) {
+	out := new(LSPReply)
+	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/GetLSPs", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovPceapi(uint64(l))
+	return out, nil
+}
+
+func (c *pCEClient) StopBGP(ctx context.Context, in *StopBGPRequest, opts ...grpc.CallOption) (*StopBGPReplay, error) {
+	out := new(StopBGPReplay)
+	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/StopBGP", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.MsgCount != 0 {
-		n += 1 + sovPceapi(uint64(m.MsgCount))
+	return out, nil
+}
+
+func (c *pCEClient) StartBGP(ctx context.Context, in *StartBGPRequest, opts ...grpc.CallOption) (*StartBGPReplay, error) {
+	out := new(StartBGPReplay)
+	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/StartBGP", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.State != 0 {
-		n += 1 + sovPceapi(uint64(m.State))
+	return out, nil
+}
+
+func (c *pCEClient) GetAuthStats(ctx context.Context, in *AuthStatsRequest, opts ...grpc.CallOption) (*AuthStatsReply, error) {
+	out := new(AuthStatsReply)
+	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/GetAuthStats", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovPceapi(uint64(l))
+	return out, nil
+}
+
+func (c *pCEClient) GetSRPolicies(ctx context.Context, in *SRPoliciesRequest, opts ...grpc.CallOption) (*SRPoliciesReply, error) {
+	out := new(SRPoliciesReply)
+	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/GetSRPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Keepalive != 0 {
-		n += 1 + sovPceapi(uint64(m.Keepalive))
+	return out, nil
+}
+
+func (c *pCEClient) CreateUpdSRPolicy(ctx context.Context, in *SRPolicy, opts ...grpc.CallOption) (*SRPolicy, error) {
+	out := new(SRPolicy)
+	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/CreateUpdSRPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.DeadTimer != 0 {
-		n += 1 + sovPceapi(uint64(m.DeadTimer))
+	return out, nil
+}
+
+func (c *pCEClient) DelSRPolicy(ctx context.Context, in *DelSRPolicyRequest, opts ...grpc.CallOption) (*DelSRPolicyReply, error) {
+	out := new(DelSRPolicyReply)
+	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/DelSRPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return out, nil
+}
+
+func (c *pCEClient) GetSRLSPs(ctx context.Context, in *SRLSPRequest, opts ...grpc.CallOption) (*SRLSPReply, error) {
+	out := new(SRLSPReply)
+	err := c.cc.Invoke(ctx, "/pceapiproto.PCE/GetSRLSPs", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *SessionsReply) Size() (n int) {
-	if m == nil {
-		return 0
+// PCEServer is the server API for PCE service.
+type PCEServer interface {
+	GetSessions(context.Context, *SessionsRequest) (*SessionsReply, error)
+	GetLSPs(context.Context, *LSPRequest) (*LSPReply, error)
+	StopBGP(context.Context, *StopBGPRequest) (*StopBGPReplay, error)
+	StartBGP(context.Context, *StartBGPRequest) (*StartBGPReplay, error)
+	GetAuthStats(context.Context, *AuthStatsRequest) (*AuthStatsReply, error)
+	GetSRPolicies(context.Context, *SRPoliciesRequest) (*SRPoliciesReply, error)
+	CreateUpdSRPolicy(context.Context, *SRPolicy) (*SRPolicy, error)
+	DelSRPolicy(context.Context, *DelSRPolicyRequest) (*DelSRPolicyReply, error)
+	GetSRLSPs(context.Context, *SRLSPRequest) (*SRLSPReply, error)
+}
+
+// UnimplementedPCEServer can be embedded to have forward compatible implementations.
+type UnimplementedPCEServer struct {
+}
+
+func (*UnimplementedPCEServer) GetSessions(ctx context.Context, req *SessionsRequest) (*SessionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessions not implemented")
+}
+func (*UnimplementedPCEServer) GetLSPs(ctx context.Context, req *LSPRequest) (*LSPReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLSPs not implemented")
+}
+func (*UnimplementedPCEServer) StopBGP(ctx context.Context, req *StopBGPRequest) (*StopBGPReplay, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopBGP not implemented")
+}
+func (*UnimplementedPCEServer) StartBGP(ctx context.Context, req *StartBGPRequest) (*StartBGPReplay, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBGP not implemented")
+}
+func (*UnimplementedPCEServer) GetAuthStats(ctx context.Context, req *AuthStatsRequest) (*AuthStatsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthStats not implemented")
+}
+func (*UnimplementedPCEServer) GetSRPolicies(ctx context.Context, req *SRPoliciesRequest) (*SRPoliciesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSRPolicies not implemented")
+}
+func (*UnimplementedPCEServer) CreateUpdSRPolicy(ctx context.Context, req *SRPolicy) (*SRPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUpdSRPolicy not implemented")
+}
+func (*UnimplementedPCEServer) DelSRPolicy(ctx context.Context, req *DelSRPolicyRequest) (*DelSRPolicyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DelSRPolicy not implemented")
+}
+func (*UnimplementedPCEServer) GetSRLSPs(ctx context.Context, req *SRLSPRequest) (*SRLSPReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSRLSPs not implemented")
+}
+
+func RegisterPCEServer(s *grpc.Server, srv PCEServer) {
+	s.RegisterService(&_PCE_serviceDesc, srv)
+}
+
+func _PCE_GetSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Sessions) > 0 {
-		for _, e := range m.Sessions {
-			l = e.Size()
-			n += 1 + l + sovPceapi(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(PCEServer).GetSessions(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pceapiproto.PCE/GetSessions",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PCEServer).GetSessions(ctx, req.(*SessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LSPRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _PCE_GetLSPs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LSPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.PccName)
-	if l > 0 {
-		n += 1 + l + sovPceapi(uint64(l))
+	if interceptor == nil {
+		return srv.(PCEServer).GetLSPs(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pceapiproto.PCE/GetLSPs",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PCEServer).GetLSPs(ctx, req.(*LSPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LSP) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Delegate {
-		n += 2
+func _PCE_StopBGP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopBGPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Sync {
-		n += 2
+	if interceptor == nil {
+		return srv.(PCEServer).StopBGP(ctx, in)
 	}
-	if m.Remove {
-		n += 2
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pceapiproto.PCE/StopBGP",
 	}
-	if m.Admin {
-		n += 2
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PCEServer).StopBGP(ctx, req.(*StopBGPRequest))
 	}
-	if m.Oper != 0 {
-		n += 1 + sovPceapi(uint64(m.Oper))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PCE_StartBGP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartBGPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPceapi(uint64(l))
+	if interceptor == nil {
+		return srv.(PCEServer).StartBGP(ctx, in)
 	}
-	l = len(m.Src)
-	if l > 0 {
-		n += 1 + l + sovPceapi(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pceapiproto.PCE/StartBGP",
 	}
-	l = len(m.Dst)
-	if l > 0 {
-		n += 1 + l + sovPceapi(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PCEServer).StartBGP(ctx, req.(*StartBGPRequest))
 	}
-	if m.SetupPrio != 0 {
-		n += 1 + sovPceapi(uint64(m.SetupPrio))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PCE_GetAuthStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.HoldPrio != 0 {
-		n += 1 + sovPceapi(uint64(m.HoldPrio))
+	if interceptor == nil {
+		return srv.(PCEServer).GetAuthStats(ctx, in)
 	}
-	if m.LocalProtect {
-		n += 2
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pceapiproto.PCE/GetAuthStats",
 	}
-	if m.BW != 0 {
-		n += 1 + sovPceapi(uint64(m.BW))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PCEServer).GetAuthStats(ctx, req.(*AuthStatsRequest))
 	}
-	if m.PLSPID != 0 {
-		n += 1 + sovPceapi(uint64(m.PLSPID))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PCE_GetSRPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SRPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.LSPID != 0 {
-		n += 1 + sovPceapi(uint64(m.LSPID))
+	if interceptor == nil {
+		return srv.(PCEServer).GetSRPolicies(ctx, in)
 	}
-	if m.SRPID != 0 {
-		n += 1 + sovPceapi(uint64(m.SRPID))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pceapiproto.PCE/GetSRPolicies",
 	}
-	if m.ExcludeAny != 0 {
-		n += 2 + sovPceapi(uint64(m.ExcludeAny))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PCEServer).GetSRPolicies(ctx, req.(*SRPoliciesRequest))
 	}
-	if m.IncludeAny != 0 {
-		n += 2 + sovPceapi(uint64(m.IncludeAny))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PCE_CreateUpdSRPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SRPolicy)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.IncludeAll != 0 {
-		n += 2 + sovPceapi(uint64(m.IncludeAll))
+	if interceptor == nil {
+		return srv.(PCEServer).CreateUpdSRPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pceapiproto.PCE/CreateUpdSRPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PCEServer).CreateUpdSRPolicy(ctx, req.(*SRPolicy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PCE_DelSRPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelSRPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PCEServer).DelSRPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pceapiproto.PCE/DelSRPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PCEServer).DelSRPolicy(ctx, req.(*DelSRPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PCE_GetSRLSPs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SRLSPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PCEServer).GetSRLSPs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pceapiproto.PCE/GetSRLSPs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PCEServer).GetSRLSPs(ctx, req.(*SRLSPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PCE_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pceapiproto.PCE",
+	HandlerType: (*PCEServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSessions",
+			Handler:    _PCE_GetSessions_Handler,
+		},
+		{
+			MethodName: "GetLSPs",
+			Handler:    _PCE_GetLSPs_Handler,
+		},
+		{
+			MethodName: "StopBGP",
+			Handler:    _PCE_StopBGP_Handler,
+		},
+		{
+			MethodName: "StartBGP",
+			Handler:    _PCE_StartBGP_Handler,
+		},
+		{
+			MethodName: "GetAuthStats",
+			Handler:    _PCE_GetAuthStats_Handler,
+		},
+		{
+			MethodName: "GetSRPolicies",
+			Handler:    _PCE_GetSRPolicies_Handler,
+		},
+		{
+			MethodName: "CreateUpdSRPolicy",
+			Handler:    _PCE_CreateUpdSRPolicy_Handler,
+		},
+		{
+			MethodName: "DelSRPolicy",
+			Handler:    _PCE_DelSRPolicy_Handler,
+		},
+		{
+			MethodName: "GetSRLSPs",
+			Handler:    _PCE_GetSRLSPs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pceapi.proto",
+}
+
+func (m *StartBGPRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *StartBGPRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StartBGPRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LSPReply) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *StartBGPReplay) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *StartBGPReplay) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StartBGPReplay) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.LSPs) > 0 {
-		for _, e := range m.LSPs {
-			l = e.Size()
-			n += 1 + l + sovPceapi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StopBGPRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StopBGPRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StopBGPRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StopBGPReplay) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StopBGPReplay) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StopBGPReplay) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.PccName) > 0 {
+		i -= len(m.PccName)
+		copy(dAtA[i:], m.PccName)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.PccName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Session) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Session) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Session) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.TLS {
+		i--
+		if m.TLS {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.DeadTimer != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.DeadTimer))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Keepalive != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.Keepalive))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.State != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.State))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MsgCount != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.MsgCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuthStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuthStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuthStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuthStatsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuthStatsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuthStatsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Scope) > 0 {
+		i -= len(m.Scope)
+		copy(dAtA[i:], m.Scope)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Scope)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.AOKeyNotFound != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.AOKeyNotFound))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.AOBad != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.AOBad))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.AORequired != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.AORequired))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MD5Failure != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.MD5Failure))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MD5Unexpected != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.MD5Unexpected))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MD5NotFound != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.MD5NotFound))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Sessions) > 0 {
+		for iNdEx := len(m.Sessions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Sessions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPceapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LSPRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LSPRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LSPRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.PccName) > 0 {
+		i -= len(m.PccName)
+		copy(dAtA[i:], m.PccName)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.PccName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LSP) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LSP) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LSP) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Metrics) > 0 {
+		for iNdEx := len(m.Metrics) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metrics[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPceapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x9a
+		}
+	}
+	if m.IncludeAll != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.IncludeAll))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.IncludeAny != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.IncludeAny))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.ExcludeAny != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.ExcludeAny))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.SRPID != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.SRPID))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.LSPID != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.LSPID))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.PLSPID != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.PLSPID))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.BW != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.BW))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.LocalProtect {
+		i--
+		if m.LocalProtect {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.HoldPrio != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.HoldPrio))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.SetupPrio != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.SetupPrio))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Dst) > 0 {
+		i -= len(m.Dst)
+		copy(dAtA[i:], m.Dst)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Dst)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.Src) > 0 {
+		i -= len(m.Src)
+		copy(dAtA[i:], m.Src)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Src)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Oper != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.Oper))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Admin {
+		i--
+		if m.Admin {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Remove {
+		i--
+		if m.Remove {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Sync {
+		i--
+		if m.Sync {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Delegate {
+		i--
+		if m.Delegate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Metric) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Metric) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Metric) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Value != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.Value))))
+		i--
+		dAtA[i] = 0x25
+	}
+	if m.Computed {
+		i--
+		if m.Computed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Bound {
+		i--
+		if m.Bound {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Type != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LSPReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LSPReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LSPReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.LSPs) > 0 {
+		for iNdEx := len(m.LSPs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LSPs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPceapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SRHop) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SRHop) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SRHop) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.IPv6NodeID) > 0 {
+		i -= len(m.IPv6NodeID)
+		copy(dAtA[i:], m.IPv6NodeID)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.IPv6NodeID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.IPv4NodeID) > 0 {
+		i -= len(m.IPv4NodeID)
+		copy(dAtA[i:], m.IPv4NodeID)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.IPv4NodeID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.SID != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.SID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CandidatePath) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CandidatePath) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CandidatePath) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Hops) > 0 {
+		for iNdEx := len(m.Hops) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPceapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.BW != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.BW))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Discriminator != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.Discriminator))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Preference != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.Preference))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SRPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SRPolicy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SRPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.CandidatePaths) > 0 {
+		for iNdEx := len(m.CandidatePaths) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CandidatePaths[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPceapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.AssociationID != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.AssociationID))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Endpoint) > 0 {
+		i -= len(m.Endpoint)
+		copy(dAtA[i:], m.Endpoint)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Endpoint)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Color != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.Color))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.HeadEnd) > 0 {
+		i -= len(m.HeadEnd)
+		copy(dAtA[i:], m.HeadEnd)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.HeadEnd)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SRPoliciesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SRPoliciesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SRPoliciesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SRPoliciesReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SRPoliciesReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SRPoliciesReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.SRPolicies) > 0 {
+		for iNdEx := len(m.SRPolicies) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SRPolicies[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPceapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DelSRPolicyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DelSRPolicyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DelSRPolicyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DelSRPolicyReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DelSRPolicyReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DelSRPolicyReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SRLSP) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SRLSP) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SRLSP) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.SkipReason) > 0 {
+		i -= len(m.SkipReason)
+		copy(dAtA[i:], m.SkipReason)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.SkipReason)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.BindingSID != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.BindingSID))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Hops) > 0 {
+		for iNdEx := len(m.Hops) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPceapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.Admin {
+		i--
+		if m.Admin {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Delegate {
+		i--
+		if m.Delegate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.BW != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.BW))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.PST != 0 {
+		i = encodeVarintPceapi(dAtA, i, uint64(m.PST))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Dst) > 0 {
+		i -= len(m.Dst)
+		copy(dAtA[i:], m.Dst)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Dst)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Src) > 0 {
+		i -= len(m.Src)
+		copy(dAtA[i:], m.Src)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Src)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPceapi(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SRLSPRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SRLSPRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SRLSPRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SRLSPReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SRLSPReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SRLSPReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.SRLSPs) > 0 {
+		for iNdEx := len(m.SRLSPs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SRLSPs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPceapi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintPceapi(dAtA []byte, offset int, v uint64) int {
+	offset -= sovPceapi(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *StartBGPRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StartBGPReplay) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StopBGPRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StopBGPReplay) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SessionsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PccName)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Session) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.MsgCount != 0 {
+		n += 1 + sovPceapi(uint64(m.MsgCount))
+	}
+	if m.State != 0 {
+		n += 1 + sovPceapi(uint64(m.State))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.Keepalive != 0 {
+		n += 1 + sovPceapi(uint64(m.Keepalive))
+	}
+	if m.DeadTimer != 0 {
+		n += 1 + sovPceapi(uint64(m.DeadTimer))
+	}
+	if m.TLS {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AuthStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AuthStatsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MD5NotFound != 0 {
+		n += 1 + sovPceapi(uint64(m.MD5NotFound))
+	}
+	if m.MD5Unexpected != 0 {
+		n += 1 + sovPceapi(uint64(m.MD5Unexpected))
+	}
+	if m.MD5Failure != 0 {
+		n += 1 + sovPceapi(uint64(m.MD5Failure))
+	}
+	if m.AORequired != 0 {
+		n += 1 + sovPceapi(uint64(m.AORequired))
+	}
+	if m.AOBad != 0 {
+		n += 1 + sovPceapi(uint64(m.AOBad))
+	}
+	if m.AOKeyNotFound != 0 {
+		n += 1 + sovPceapi(uint64(m.AOKeyNotFound))
+	}
+	l = len(m.Scope)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SessionsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Sessions) > 0 {
+		for _, e := range m.Sessions {
+			l = e.Size()
+			n += 1 + l + sovPceapi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LSPRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PccName)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LSP) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Delegate {
+		n += 2
+	}
+	if m.Sync {
+		n += 2
+	}
+	if m.Remove {
+		n += 2
+	}
+	if m.Admin {
+		n += 2
+	}
+	if m.Oper != 0 {
+		n += 1 + sovPceapi(uint64(m.Oper))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	l = len(m.Src)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	l = len(m.Dst)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.SetupPrio != 0 {
+		n += 1 + sovPceapi(uint64(m.SetupPrio))
+	}
+	if m.HoldPrio != 0 {
+		n += 1 + sovPceapi(uint64(m.HoldPrio))
+	}
+	if m.LocalProtect {
+		n += 2
+	}
+	if m.BW != 0 {
+		n += 1 + sovPceapi(uint64(m.BW))
+	}
+	if m.PLSPID != 0 {
+		n += 1 + sovPceapi(uint64(m.PLSPID))
+	}
+	if m.LSPID != 0 {
+		n += 1 + sovPceapi(uint64(m.LSPID))
+	}
+	if m.SRPID != 0 {
+		n += 1 + sovPceapi(uint64(m.SRPID))
+	}
+	if m.ExcludeAny != 0 {
+		n += 2 + sovPceapi(uint64(m.ExcludeAny))
+	}
+	if m.IncludeAny != 0 {
+		n += 2 + sovPceapi(uint64(m.IncludeAny))
+	}
+	if m.IncludeAll != 0 {
+		n += 2 + sovPceapi(uint64(m.IncludeAll))
+	}
+	if len(m.Metrics) > 0 {
+		for _, e := range m.Metrics {
+			l = e.Size()
+			n += 2 + l + sovPceapi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Metric) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovPceapi(uint64(m.Type))
+	}
+	if m.Bound {
+		n += 2
+	}
+	if m.Computed {
+		n += 2
+	}
+	if m.Value != 0 {
+		n += 5
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LSPReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LSPs) > 0 {
+		for _, e := range m.LSPs {
+			l = e.Size()
+			n += 1 + l + sovPceapi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SRHop) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SID != 0 {
+		n += 1 + sovPceapi(uint64(m.SID))
+	}
+	l = len(m.IPv4NodeID)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	l = len(m.IPv6NodeID)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CandidatePath) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.Preference != 0 {
+		n += 1 + sovPceapi(uint64(m.Preference))
+	}
+	if m.Discriminator != 0 {
+		n += 1 + sovPceapi(uint64(m.Discriminator))
+	}
+	if m.BW != 0 {
+		n += 1 + sovPceapi(uint64(m.BW))
+	}
+	if len(m.Hops) > 0 {
+		for _, e := range m.Hops {
+			l = e.Size()
+			n += 1 + l + sovPceapi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SRPolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	l = len(m.HeadEnd)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.Color != 0 {
+		n += 1 + sovPceapi(uint64(m.Color))
+	}
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.AssociationID != 0 {
+		n += 1 + sovPceapi(uint64(m.AssociationID))
+	}
+	if len(m.CandidatePaths) > 0 {
+		for _, e := range m.CandidatePaths {
+			l = e.Size()
+			n += 1 + l + sovPceapi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SRPoliciesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SRPoliciesReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.SRPolicies) > 0 {
+		for _, e := range m.SRPolicies {
+			l = e.Size()
+			n += 1 + l + sovPceapi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DelSRPolicyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DelSRPolicyReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SRLSP) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	l = len(m.Src)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	l = len(m.Dst)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.PST != 0 {
+		n += 1 + sovPceapi(uint64(m.PST))
+	}
+	if m.BW != 0 {
+		n += 1 + sovPceapi(uint64(m.BW))
+	}
+	if m.Delegate {
+		n += 2
+	}
+	if m.Admin {
+		n += 2
+	}
+	if len(m.Hops) > 0 {
+		for _, e := range m.Hops {
+			l = e.Size()
+			n += 1 + l + sovPceapi(uint64(l))
+		}
+	}
+	if m.BindingSID != 0 {
+		n += 1 + sovPceapi(uint64(m.BindingSID))
+	}
+	l = len(m.SkipReason)
+	if l > 0 {
+		n += 1 + l + sovPceapi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SRLSPRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SRLSPReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.SRLSPs) > 0 {
+		for _, e := range m.SRLSPs {
+			l = e.Size()
+			n += 1 + l + sovPceapi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovPceapi(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozPceapi(x uint64) (n int) {
+	return sovPceapi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *StartBGPRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StartBGPRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StartBGPRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StartBGPReplay) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StartBGPReplay: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StartBGPReplay: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StopBGPRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StopBGPRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StopBGPRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StopBGPReplay) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StopBGPReplay: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StopBGPReplay: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SessionsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SessionsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SessionsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PccName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PccName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Session) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Session: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Session: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MsgCount", wireType)
+			}
+			m.MsgCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MsgCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= SessionState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keepalive", wireType)
+			}
+			m.Keepalive = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Keepalive |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeadTimer", wireType)
+			}
+			m.DeadTimer = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeadTimer |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TLS", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TLS = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuthStatsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuthStatsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuthStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuthStatsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuthStatsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuthStatsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MD5NotFound", wireType)
+			}
+			m.MD5NotFound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MD5NotFound |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MD5Unexpected", wireType)
+			}
+			m.MD5Unexpected = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MD5Unexpected |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MD5Failure", wireType)
+			}
+			m.MD5Failure = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MD5Failure |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AORequired", wireType)
+			}
+			m.AORequired = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AORequired |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AOBad", wireType)
+			}
+			m.AOBad = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AOBad |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AOKeyNotFound", wireType)
+			}
+			m.AOKeyNotFound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AOKeyNotFound |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scope", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Scope = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SessionsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SessionsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SessionsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sessions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sessions = append(m.Sessions, &Session{})
+			if err := m.Sessions[len(m.Sessions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LSPRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LSPRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LSPRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PccName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PccName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LSP) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LSP: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LSP: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delegate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Delegate = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sync", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Sync = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remove", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Remove = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Admin = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Oper", wireType)
+			}
+			m.Oper = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Oper |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Src", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Src = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dst", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dst = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetupPrio", wireType)
+			}
+			m.SetupPrio = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SetupPrio |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HoldPrio", wireType)
+			}
+			m.HoldPrio = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HoldPrio |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalProtect", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LocalProtect = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BW", wireType)
+			}
+			m.BW = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BW |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PLSPID", wireType)
+			}
+			m.PLSPID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PLSPID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LSPID", wireType)
+			}
+			m.LSPID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LSPID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SRPID", wireType)
+			}
+			m.SRPID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SRPID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeAny", wireType)
+			}
+			m.ExcludeAny = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExcludeAny |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeAny", wireType)
+			}
+			m.IncludeAny = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IncludeAny |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeAll", wireType)
+			}
+			m.IncludeAll = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IncludeAll |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metrics = append(m.Metrics, &Metric{})
+			if err := m.Metrics[len(m.Metrics)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func sovPceapi(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozPceapi(x uint64) (n int) {
-	return sovPceapi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *StartBGPRequest) Unmarshal(dAtA []byte) error {
+func (m *Metric) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1621,12 +5086,82 @@ func (m *StartBGPRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StartBGPRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Metric: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StartBGPRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Metric: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bound", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Bound = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Computed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Computed = bool(v != 0)
+		case 4:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.Value = float32(math.Float32frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPceapi(dAtA[iNdEx:])
@@ -1649,7 +5184,7 @@ func (m *StartBGPRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StartBGPReplay) Unmarshal(dAtA []byte) error {
+func (m *LSPReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1672,12 +5207,46 @@ func (m *StartBGPReplay) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StartBGPReplay: wiretype end group for non-group")
+			return fmt.Errorf("proto: LSPReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StartBGPReplay: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LSPReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LSPs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LSPs = append(m.LSPs, &LSP{})
+			if err := m.LSPs[len(m.LSPs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPceapi(dAtA[iNdEx:])
@@ -1700,7 +5269,7 @@ func (m *StartBGPReplay) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StopBGPRequest) Unmarshal(dAtA []byte) error {
+func (m *SRHop) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1723,12 +5292,95 @@ func (m *StopBGPRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StopBGPRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SRHop: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StopBGPRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SRHop: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SID", wireType)
+			}
+			m.SID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IPv4NodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IPv4NodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IPv6NodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IPv6NodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPceapi(dAtA[iNdEx:])
@@ -1751,7 +5403,7 @@ func (m *StopBGPRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StopBGPReplay) Unmarshal(dAtA []byte) error {
+func (m *CandidatePath) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1774,68 +5426,106 @@ func (m *StopBGPReplay) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StopBGPReplay: wiretype end group for non-group")
+			return fmt.Errorf("proto: CandidatePath: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StopBGPReplay: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CandidatePath: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPceapi(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPceapi
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SessionsRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPceapi
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Preference", wireType)
+			}
+			m.Preference = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Preference |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Discriminator", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Discriminator = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Discriminator |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SessionsRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SessionsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BW", wireType)
+			}
+			m.BW = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPceapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BW |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PccName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hops", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -1845,23 +5535,25 @@ func (m *SessionsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPceapi
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPceapi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PccName = string(dAtA[iNdEx:postIndex])
+			m.Hops = append(m.Hops, &SRHop{})
+			if err := m.Hops[len(m.Hops)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1885,7 +5577,7 @@ func (m *SessionsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Session) Unmarshal(dAtA []byte) error {
+func (m *SRPolicy) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1908,15 +5600,15 @@ func (m *Session) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Session: wiretype end group for non-group")
+			return fmt.Errorf("proto: SRPolicy: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Session: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SRPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1944,13 +5636,13 @@ func (m *Session) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MsgCount", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeadEnd", wireType)
 			}
-			m.MsgCount = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -1960,16 +5652,29 @@ func (m *Session) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MsgCount |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HeadEnd = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Color", wireType)
 			}
-			m.State = 0
+			m.Color = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -1979,14 +5684,14 @@ func (m *Session) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= int32(b&0x7F) << shift
+				m.Color |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2014,13 +5719,13 @@ func (m *Session) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Address = string(dAtA[iNdEx:postIndex])
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keepalive", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AssociationID", wireType)
 			}
-			m.Keepalive = 0
+			m.AssociationID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -2030,16 +5735,16 @@ func (m *Session) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Keepalive |= uint32(b&0x7F) << shift
+				m.AssociationID |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeadTimer", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CandidatePaths", wireType)
 			}
-			m.DeadTimer = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -2049,11 +5754,26 @@ func (m *Session) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DeadTimer |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CandidatePaths = append(m.CandidatePaths, &CandidatePath{})
+			if err := m.CandidatePaths[len(m.CandidatePaths)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPceapi(dAtA[iNdEx:])
@@ -2076,7 +5796,7 @@ func (m *Session) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SessionsReply) Unmarshal(dAtA []byte) error {
+func (m *SRPoliciesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2099,15 +5819,66 @@ func (m *SessionsReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SessionsReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: SRPoliciesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SessionsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SRPoliciesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SRPoliciesReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SRPoliciesReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SRPoliciesReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sessions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SRPolicies", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2134,8 +5905,8 @@ func (m *SessionsReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sessions = append(m.Sessions, &Session{})
-			if err := m.Sessions[len(m.Sessions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.SRPolicies = append(m.SRPolicies, &SRPolicy{})
+			if err := m.SRPolicies[len(m.SRPolicies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2161,7 +5932,7 @@ func (m *SessionsReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LSPRequest) Unmarshal(dAtA []byte) error {
+func (m *DelSRPolicyRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2184,15 +5955,15 @@ func (m *LSPRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LSPRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DelSRPolicyRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LSPRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DelSRPolicyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PccName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2220,7 +5991,7 @@ func (m *LSPRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PccName = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2244,7 +6015,7 @@ func (m *LSPRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LSP) Unmarshal(dAtA []byte) error {
+func (m *DelSRPolicyReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2267,112 +6038,64 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LSP: wiretype end group for non-group")
+			return fmt.Errorf("proto: DelSRPolicyReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LSP: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DelSRPolicyReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Delegate", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPceapi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Delegate = bool(v != 0)
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sync", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPceapi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Sync = bool(v != 0)
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Remove", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPceapi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.Remove = bool(v != 0)
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPceapi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Admin = bool(v != 0)
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Oper", wireType)
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SRLSP) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
 			}
-			m.Oper = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPceapi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Oper |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-		case 6:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SRLSP: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SRLSP: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
@@ -2404,7 +6127,7 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 			}
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Src", wireType)
 			}
@@ -2436,7 +6159,7 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 			}
 			m.Src = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Dst", wireType)
 			}
@@ -2468,11 +6191,11 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 			}
 			m.Dst = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SetupPrio", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PST", wireType)
 			}
-			m.SetupPrio = 0
+			m.PST = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -2482,16 +6205,16 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SetupPrio |= uint32(b&0x7F) << shift
+				m.PST |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 10:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HoldPrio", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BW", wireType)
 			}
-			m.HoldPrio = 0
+			m.BW = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -2501,14 +6224,14 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.HoldPrio |= uint32(b&0x7F) << shift
+				m.BW |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LocalProtect", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Delegate", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -2525,12 +6248,12 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.LocalProtect = bool(v != 0)
-		case 12:
+			m.Delegate = bool(v != 0)
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BW", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
 			}
-			m.BW = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -2540,16 +6263,17 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BW |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 13:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PLSPID", wireType)
+			m.Admin = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hops", wireType)
 			}
-			m.PLSPID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -2559,54 +6283,31 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PLSPID |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 14:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LSPID", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthPceapi
 			}
-			m.LSPID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPceapi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.LSPID |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
 			}
-		case 15:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SRPID", wireType)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.SRPID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPceapi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SRPID |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Hops = append(m.Hops, &SRHop{})
+			if err := m.Hops[len(m.Hops)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		case 16:
+			iNdEx = postIndex
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeAny", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BindingSID", wireType)
 			}
-			m.ExcludeAny = 0
+			m.BindingSID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -2616,16 +6317,16 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExcludeAny |= uint32(b&0x7F) << shift
+				m.BindingSID |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 17:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IncludeAny", wireType)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkipReason", wireType)
 			}
-			m.IncludeAny = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPceapi
@@ -2635,30 +6336,24 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.IncludeAny |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 18:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IncludeAll", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPceapi
 			}
-			m.IncludeAll = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPceapi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.IncludeAll |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.SkipReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPceapi(dAtA[iNdEx:])
@@ -2681,7 +6376,7 @@ func (m *LSP) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LSPReply) Unmarshal(dAtA []byte) error {
+func (m *SRLSPRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2704,15 +6399,66 @@ func (m *LSPReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LSPReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: SRLSPRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LSPReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SRLSPRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPceapi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPceapi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SRLSPReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPceapi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SRLSPReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SRLSPReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LSPs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SRLSPs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2739,8 +6485,8 @@ func (m *LSPReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LSPs = append(m.LSPs, &LSP{})
-			if err := m.LSPs[len(m.LSPs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.SRLSPs = append(m.SRLSPs, &SRLSP{})
+			if err := m.SRLSPs[len(m.SRLSPs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
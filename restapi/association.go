@@ -0,0 +1,54 @@
+package restapi
+
+import (
+	"gopcep/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *handler) getAssociations(c *gin.Context) {
+	c.JSON(200, h.ctr.GetAssociations())
+}
+
+func (h *handler) createUpdAssociation(c *gin.Context) {
+	var g controller.AssociationGroup
+
+	err := c.BindJSON(&g)
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+
+	err = h.ctr.CreateUpdAssociation(&g)
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+	c.JSON(200, g)
+}
+
+func (h *handler) addLSPToAssociation(c *gin.Context) {
+	err := h.ctr.AddLSPToAssociation(c.Param("name"), c.Param("lsp"))
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+	c.JSON(200, c.Param("lsp"))
+}
+
+func (h *handler) delAssociation(c *gin.Context) {
+	err := h.ctr.DelAssociation(c.Param("name"))
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+	c.JSON(200, c.Param("name"))
+}
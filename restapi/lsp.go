@@ -45,3 +45,14 @@ func (h *handler) delLSP(c *gin.Context) {
 
 	c.JSON(200, c.Param("name"))
 }
+
+func (h *handler) getLSPProtection(c *gin.Context) {
+	status, err := h.ctr.GetProtectionStatus(c.Param("name"))
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+	c.JSON(200, status)
+}
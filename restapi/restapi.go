@@ -143,6 +143,9 @@ func addAPIMethods(router *gin.Engine, controller *controller.Controller) {
 	})
 	// PCEP
 	apiV1.GET("/pcepsessions", h.getSessions)
+	apiV1.POST("/pcepsessions/:addr/notification", h.sendNotification)
+	apiV1.POST("/pcepsessions/:addr/resync", h.resync)
+	apiV1.GET("/pcepauthstats", h.getAuthStats)
 	// BGP
 	apiV1.GET("/bgpneighbors", h.getBGPNeighbors)
 	// Router methods
@@ -152,8 +155,18 @@ func addAPIMethods(router *gin.Engine, controller *controller.Controller) {
 	// LSP methods
 	apiV1.POST("/lsp", h.createUpdLSP)
 	apiV1.DELETE("/lsp/:name", h.delLSP)
+	apiV1.GET("/lsp/:name/protection", h.getLSPProtection)
 	apiV1.GET("/pceplsps", h.getLSPs)
 	apiV1.GET("/ctrlsps", h.getNetLSPs)
+	// Association group methods
+	apiV1.POST("/association", h.createUpdAssociation)
+	apiV1.POST("/association/:name/lsp/:lsp", h.addLSPToAssociation)
+	apiV1.DELETE("/association/:name", h.delAssociation)
+	apiV1.GET("/associations", h.getAssociations)
+	// SR Policy methods
+	apiV1.POST("/srpolicy", h.createUpdSRPolicy)
+	apiV1.DELETE("/srpolicy/:name", h.delSRPolicy)
+	apiV1.GET("/srpolicies", h.getSRPolicies)
 }
 
 func Start(cfg *Config, controller *controller.Controller) error {
@@ -13,13 +13,18 @@ func (h *handler) createUpdRouter(c *gin.Context) {
 		c.AbortWithStatusJSON(500, err)
 		return
 	}
+	err = controller.ValidateRouter(&r)
+	if err != nil {
+		c.AbortWithStatusJSON(400, map[string]string{"msg": err.Error()})
+		return
+	}
 	err = h.ctr.CreateUpdRouter(&r)
 	if err != nil {
 
 		c.AbortWithStatusJSON(500, err)
 		return
 	}
-	c.JSON(200, r)
+	c.JSON(200, r.Redacted())
 }
 
 func (h *handler) deleteRouter(c *gin.Context) {
@@ -37,5 +42,9 @@ func (h *handler) listRouters(c *gin.Context) {
 		c.AbortWithStatusJSON(500, err)
 		return
 	}
-	c.JSON(200, routers)
+	redacted := make([]*controller.Router, 0, len(routers))
+	for _, r := range routers {
+		redacted = append(redacted, r.Redacted())
+	}
+	c.JSON(200, redacted)
 }
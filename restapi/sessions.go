@@ -1,6 +1,11 @@
 package restapi
 
-import "github.com/gin-gonic/gin"
+import (
+	"gopcep/pcep"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
 
 func (h *handler) getSessions(c *gin.Context) {
 
@@ -13,3 +18,58 @@ func (h *handler) getSessions(c *gin.Context) {
 
 	c.JSON(200, data)
 }
+
+func (h *handler) getAuthStats(c *gin.Context) {
+	stats, err := h.ctr.GetAuthStats()
+	if err != nil {
+		c.AbortWithStatusJSON(500, err.Error())
+		return
+	}
+	c.JSON(200, stats)
+}
+
+func (h *handler) sendNotification(c *gin.Context) {
+	var n pcep.Notification
+
+	err := c.BindJSON(&n)
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+
+	err = h.ctr.SendNotification(c.Param("addr"), &n)
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+	c.JSON(200, n)
+}
+
+// resync triggers resynchronization of a single LSP
+// when plsp_id is set or of all the LSPs of the PCC otherwise
+func (h *handler) resync(c *gin.Context) {
+	var plspID uint64
+	if id := c.Query("plsp_id"); id != "" {
+		var err error
+		plspID, err = strconv.ParseUint(id, 10, 20)
+		if err != nil {
+			c.AbortWithStatusJSON(500, map[string]string{
+				"msg": err.Error(),
+			})
+			return
+		}
+	}
+
+	err := h.ctr.TriggerResync(c.Param("addr"), uint32(plspID))
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+	c.JSON(200, c.Param("addr"))
+}
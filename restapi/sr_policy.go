@@ -0,0 +1,43 @@
+package restapi
+
+import (
+	"gopcep/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *handler) getSRPolicies(c *gin.Context) {
+	c.JSON(200, h.ctr.GetSRPolicies())
+}
+
+func (h *handler) createUpdSRPolicy(c *gin.Context) {
+	var p controller.SRPolicy
+
+	err := c.BindJSON(&p)
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+
+	err = h.ctr.CreateUpdSRPolicy(&p)
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+	c.JSON(200, p)
+}
+
+func (h *handler) delSRPolicy(c *gin.Context) {
+	err := h.ctr.DelSRPolicy(c.Param("name"))
+	if err != nil {
+		c.AbortWithStatusJSON(500, map[string]string{
+			"msg": err.Error(),
+		})
+		return
+	}
+	c.JSON(200, c.Param("name"))
+}